@@ -8,7 +8,11 @@ import (
 	"github.com/ductong169z/shorten-url/internal/server"
 	"github.com/ductong169z/shorten-url/pkg/cache/redis"
 	"github.com/ductong169z/shorten-url/pkg/database/mysql"
+	"github.com/ductong169z/shorten-url/pkg/jwtkeys"
 	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/mailer"
+	"github.com/ductong169z/shorten-url/pkg/storage"
+	"github.com/ductong169z/shorten-url/pkg/utils"
 
 	_ "github.com/ductong169z/shorten-url/docs" // Swagger docs import
 )
@@ -37,11 +41,24 @@ func main() {
 		appLogger.Fatalf(ctx, "RedisCluster init: %s", err)
 	}
 
+	mediaStorage, err := storage.New(&cfg.Media)
+	if err != nil {
+		appLogger.Fatalf(ctx, "Media storage init: %s", err)
+	}
+
+	jwtKeys, err := jwtkeys.New(&cfg.JWT, cfg.Server.JwtSecretKey, utils.AccessTokenDuration)
+	if err != nil {
+		appLogger.Fatalf(ctx, "JWT keys init: %s", err)
+	}
+
 	s := server.NewServer(
 		cfg,
 		mysqlDB,
 		server.Logger(appLogger),
 		server.Redis(rdb),
+		server.Storage(mediaStorage),
+		server.Mailer(mailer.New(&cfg.Mail)),
+		server.JWTKeys(jwtKeys),
 	)
 	if err = s.Run(); err != nil {
 		log.Fatal(err)
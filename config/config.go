@@ -11,20 +11,101 @@ type Config struct {
 	Redis   RedisConfig
 	Logger  Logger
 	Metrics Metrics
+	Comment CommentConfig
+	Blog    BlogConfig
+	Media   MediaConfig
+	Auth    AuthConfig
+	Mail    MailConfig
+	JWT     JWTConfig
 }
 
 // Server config struct
 type ServerConfig struct {
-	AppVersion        string `env:"APP_VERSION"`
-	Port              string `env:"PORT"`
-	Mode              string `env:"MODE"`
-	JwtSecretKey      string `env:"JWT_SECRET_KEY"`
-	ReadTimeout       int    `env:"READ_TIMEOUT"`
-	WriteTimeout      int    `env:"WRITE_TIMEOUT"`
-	CtxDefaultTimeout int    `env:"CTX_DEFAULT_TIMEOUT"`
-	Debug             bool   `env:"DEBUG"`
-	AppDomain         string `env:"APP_DOMAIN"`
-	ShortURLExpiredAt int    `env:"SHORT_URL_EXPIRED_AT"`
+	AppVersion            string `env:"APP_VERSION"`
+	Port                  string `env:"PORT"`
+	Mode                  string `env:"MODE"`
+	JwtSecretKey          string `env:"JWT_SECRET_KEY"`
+	ReadTimeout           int    `env:"READ_TIMEOUT"`
+	WriteTimeout          int    `env:"WRITE_TIMEOUT"`
+	CtxDefaultTimeout     int    `env:"CTX_DEFAULT_TIMEOUT"`
+	Debug                 bool   `env:"DEBUG"`
+	AppDomain             string `env:"APP_DOMAIN"`
+	ShortURLExpiredAt     int    `env:"SHORT_URL_EXPIRED_AT"`
+	PostSchedulerInterval int    `env:"POST_SCHEDULER_INTERVAL"`
+	// TrustedProxies lists the proxy addresses or CIDRs allowed to set X-Forwarded-For;
+	// without any the client IP is the remote address of the connection
+	TrustedProxies []string `env:"TRUSTED_PROXIES" envSeparator:","`
+}
+
+// Public blog config used for links in feeds and sitemaps
+type BlogConfig struct {
+	URL         string `env:"BLOG_URL"`
+	Title       string `env:"BLOG_TITLE"`
+	Description string `env:"BLOG_DESCRIPTION"`
+	FeedItems   int    `env:"FEED_ITEMS"`
+}
+
+// Media upload config; Storage selects the "local" or "s3" backend
+type MediaConfig struct {
+	Storage        string `env:"MEDIA_STORAGE"`
+	MaxUploadSize  int64  `env:"MEDIA_MAX_UPLOAD_SIZE"`
+	ThumbnailWidth int    `env:"MEDIA_THUMBNAIL_WIDTH"`
+	LocalDir       string `env:"MEDIA_LOCAL_DIR"`
+	BaseURL        string `env:"MEDIA_BASE_URL"`
+	S3Endpoint     string `env:"MEDIA_S3_ENDPOINT"`
+	S3Region       string `env:"MEDIA_S3_REGION"`
+	S3Bucket       string `env:"MEDIA_S3_BUCKET"`
+	S3AccessKey    string `env:"MEDIA_S3_ACCESS_KEY"`
+	S3SecretKey    string `env:"MEDIA_S3_SECRET_KEY"`
+	S3PathStyle    bool   `env:"MEDIA_S3_PATH_STYLE"`
+}
+
+// Account config; the verification and password reset URLs receive the token as their
+// "token" query parameter. TOTPEncryptionKey is the base64 AES-256 key TOTP secrets are
+// stored encrypted with; two-factor authentication cannot be set up without it.
+type AuthConfig struct {
+	EmailVerificationURL    string `env:"EMAIL_VERIFICATION_URL"`
+	EmailVerificationTTL    int    `env:"EMAIL_VERIFICATION_TTL"`
+	PasswordResetURL        string `env:"PASSWORD_RESET_URL"`
+	PasswordResetTTL        int    `env:"PASSWORD_RESET_TTL"`
+	PasswordMinLength       int    `env:"PASSWORD_MIN_LENGTH"`
+	PasswordMinClasses      int    `env:"PASSWORD_MIN_CLASSES"`
+	TOTPIssuer              string `env:"TOTP_ISSUER"`
+	TOTPEncryptionKey       string `env:"TOTP_ENCRYPTION_KEY"`
+	LoginFailureWindow      int    `env:"LOGIN_FAILURE_WINDOW"`
+	LoginFreeAttempts       int    `env:"LOGIN_FREE_ATTEMPTS"`
+	LoginIPFreeAttempts     int    `env:"LOGIN_IP_FREE_ATTEMPTS"`
+	LoginBackoffMax         int    `env:"LOGIN_BACKOFF_MAX"`
+	LoginLockoutThreshold   int    `env:"LOGIN_LOCKOUT_THRESHOLD"`
+	LoginLockoutDuration    int    `env:"LOGIN_LOCKOUT_DURATION"`
+	MailRequestWindow       int    `env:"MAIL_REQUEST_WINDOW"`
+	MailRequestsPerEmail    int    `env:"MAIL_REQUESTS_PER_EMAIL"`
+	MailRequestsPerIP       int    `env:"MAIL_REQUESTS_PER_IP"`
+	RequireVerifiedForLogin bool   `env:"REQUIRE_VERIFIED_EMAIL_FOR_LOGIN"`
+	RequireVerifiedForPosts bool   `env:"REQUIRE_VERIFIED_EMAIL_FOR_POSTS"`
+}
+
+// Access token signing config; KeysFile is a JSON manifest of the RS256/EdDSA keys and when
+// each starts signing. Without it tokens are signed with HS256 and JWT_SECRET_KEY.
+type JWTConfig struct {
+	KeysFile    string `env:"JWT_KEYS_FILE"`
+	AcceptHS256 bool   `env:"JWT_ACCEPT_HS256"`
+}
+
+// Outgoing mail config; Driver selects the "smtp" or "memory" mailer
+type MailConfig struct {
+	Driver       string `env:"MAIL_DRIVER"`
+	From         string `env:"MAIL_FROM"`
+	SMTPHost     string `env:"SMTP_HOST"`
+	SMTPPort     int    `env:"SMTP_PORT"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+}
+
+// Comment moderation config
+type CommentConfig struct {
+	AutoApproveAdmins     bool `env:"COMMENT_AUTO_APPROVE_ADMINS"`
+	AutoApproveKnownUsers bool `env:"COMMENT_AUTO_APPROVE_KNOWN_USERS"`
 }
 
 // Metrics config
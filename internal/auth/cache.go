@@ -3,6 +3,7 @@ package auth
 
 import (
 	"context"
+	"time"
 
 	"github.com/ductong169z/shorten-url/internal/models"
 )
@@ -11,4 +12,18 @@ import (
 type RedisRepository interface {
 	GetUserByIDCtx(ctx context.Context, key string) (*models.User, error)
 	SetUserByIDCtx(ctx context.Context, key string, user *models.User) error
+	DenyAccessToken(ctx context.Context, jti string, ttl time.Duration) error
+	IsAccessTokenDenied(ctx context.Context, jti string) (bool, error)
+	SetLoginChallenge(ctx context.Context, challenge *models.LoginChallenge) error
+	GetLoginChallenge(ctx context.Context, token string) (*models.LoginChallenge, error)
+	DeleteLoginChallenge(ctx context.Context, token string) error
+	IncrLoginChallengeAttempts(ctx context.Context, challenge *models.LoginChallenge) (int, error)
+	IncrLoginFailures(ctx context.Context, subject string, window time.Duration) (int, error)
+	ResetLoginFailures(ctx context.Context, subject string) error
+	SetLoginBackoff(ctx context.Context, subject string, until time.Time) error
+	GetLoginBackoff(ctx context.Context, subject string) (time.Time, error)
+	LockAccount(ctx context.Context, username string, until time.Time) error
+	GetAccountLock(ctx context.Context, username string) (time.Time, error)
+	UnlockAccount(ctx context.Context, username string) error
+	IncrMailRequests(ctx context.Context, subject string, window time.Duration) (int, error)
 }
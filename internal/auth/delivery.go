@@ -8,6 +8,23 @@ import (
 type Handlers interface {
 	Register(c *gin.Context)
 	Login(c *gin.Context)
+	LoginTwoFactor(c *gin.Context)
 	GetUserByID(c *gin.Context)
 	RefreshToken(c *gin.Context)
+	VerifyEmail(c *gin.Context)
+	ResendVerificationEmail(c *gin.Context)
+	ForgotPassword(c *gin.Context)
+	ResetPassword(c *gin.Context)
+	ChangePassword(c *gin.Context)
+	EnrollTOTP(c *gin.Context)
+	ConfirmTOTP(c *gin.Context)
+	DisableTOTP(c *gin.Context)
+	UpdateProfile(c *gin.Context)
+	Logout(c *gin.Context)
+	LogoutAll(c *gin.Context)
+	ListSessions(c *gin.Context)
+	RevokeSession(c *gin.Context)
+	UnlockAccount(c *gin.Context)
+	ChangeRole(c *gin.Context)
+	JWKS(c *gin.Context)
 }
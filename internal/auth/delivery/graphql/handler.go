@@ -7,6 +7,7 @@ import (
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/ductong169z/shorten-url/config"
 	"github.com/ductong169z/shorten-url/internal/auth"
+	"github.com/ductong169z/shorten-url/pkg/jwtkeys"
 	"github.com/ductong169z/shorten-url/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
@@ -18,8 +19,8 @@ type Handler struct {
 }
 
 // NewHandler creates a new auth GraphQL handler
-func NewHandler(cfg *config.Config, usecase auth.UseCase, logger logger.Logger) *Handler {
-	resolver := NewResolver(cfg, usecase, logger)
+func NewHandler(cfg *config.Config, usecase auth.UseCase, jwtKeys *jwtkeys.KeySet, logger logger.Logger) *Handler {
+	resolver := NewResolver(cfg, usecase, jwtKeys, logger)
 	return &Handler{
 		resolver: resolver,
 		logger:   logger,
@@ -46,7 +47,7 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 		}
 
 		// Handle the GraphQL operation based on the query
-		response, err := h.handleGraphQLOperation(c.Request.Context(), request.Query, request.Variables, request.OperationName)
+		response, err := h.handleGraphQLOperation(withSessionClient(c), request.Query, request.Variables, request.OperationName)
 		if err != nil {
 			c.JSON(500, gin.H{"errors": []gin.H{{
 				"message": err.Error(),
@@ -104,7 +105,6 @@ func (h *Handler) handleGraphQLOperation(ctx context.Context, query string, vari
 			Username: inputVar["username"].(string),
 			Email:    inputVar["email"].(string),
 			Password: inputVar["password"].(string),
-			Role:     inputVar["role"].(string),
 		}
 		return h.resolver.Register(ctx, input)
 	} else if operationName == "refreshToken" || query == "mutation refreshToken" {
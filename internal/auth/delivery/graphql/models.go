@@ -14,7 +14,6 @@ type RegisterInput struct {
 	Username string `json:"username"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
-	Role     string `json:"role"`
 }
 
 type RefreshTokenInput struct {
@@ -23,12 +22,13 @@ type RefreshTokenInput struct {
 
 // Response types
 type UserResponse struct {
-	ID        int    `json:"id"`
-	Username  string `json:"username"`
-	Email     string `json:"email"`
-	Role      string `json:"role"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"updatedAt"`
+	ID            int    `json:"id"`
+	Username      string `json:"username"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"emailVerified"`
+	Role          string `json:"role"`
+	CreatedAt     string `json:"createdAt"`
+	UpdatedAt     string `json:"updatedAt"`
 }
 
 type AuthResponse struct {
@@ -53,12 +53,13 @@ func fromUserModel(user *models.User) *UserResponse {
 	}
 
 	return &UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		Role:      user.Role.String(),
-		CreatedAt: formatTime(user.CreatedAt),
-		UpdatedAt: formatTime(user.UpdatedAt),
+		ID:            user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		Role:          user.Role.String(),
+		CreatedAt:     formatTime(user.CreatedAt),
+		UpdatedAt:     formatTime(user.UpdatedAt),
 	}
 }
 
@@ -7,22 +7,26 @@ import (
 	"github.com/ductong169z/shorten-url/config"
 	"github.com/ductong169z/shorten-url/internal/auth"
 	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/jwtkeys"
 	"github.com/ductong169z/shorten-url/pkg/logger"
 	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/gin-gonic/gin"
 )
 
 // Resolver is the GraphQL resolver for auth operations
 type Resolver struct {
 	cfg     *config.Config
 	usecase auth.UseCase
+	jwtKeys *jwtkeys.KeySet
 	logger  logger.Logger
 }
 
 // NewResolver creates a new auth GraphQL resolver
-func NewResolver(cfg *config.Config, usecase auth.UseCase, logger logger.Logger) *Resolver {
+func NewResolver(cfg *config.Config, usecase auth.UseCase, jwtKeys *jwtkeys.KeySet, logger logger.Logger) *Resolver {
 	return &Resolver{
 		cfg:     cfg,
 		usecase: usecase,
+		jwtKeys: jwtKeys,
 		logger:  logger,
 	}
 }
@@ -43,17 +47,21 @@ func (r *Resolver) Login(ctx context.Context, input LoginInput) (*AuthResponse,
 		Password: input.Password,
 	}
 
-	user, err := r.usecase.Login(ctx, loginAttempt)
+	user, challenge, err := r.usecase.Login(ctx, loginAttempt, sessionClientFromCtx(ctx))
 	if err != nil {
 		return nil, mapError(err)
 	}
+	// The second factor can only be given through the REST API
+	if challenge != nil {
+		return nil, mapError(auth.ErrTwoFactorRequired)
+	}
 
-	tokenString, expiredAt, err := utils.GenerateJWTToken(user, r.cfg)
+	tokenString, expiredAt, err := utils.GenerateJWTToken(user, r.jwtKeys)
 	if err != nil {
 		return nil, mapError(err)
 	}
 
-	refreshToken, refreshTokenExpiresAt, err := r.usecase.GenerateRefreshToken(ctx, user.ID)
+	refreshToken, refreshTokenExpiresAt, err := r.usecase.GenerateRefreshToken(ctx, user.ID, sessionClientFromCtx(ctx))
 	if err != nil {
 		return nil, mapError(err)
 	}
@@ -73,7 +81,6 @@ func (r *Resolver) Register(ctx context.Context, input RegisterInput) (*UserResp
 		Username: input.Username,
 		Email:    input.Email,
 		Password: input.Password,
-		Role:     models.UserRole(input.Role),
 	}
 
 	user, err := r.usecase.Register(ctx, newUser)
@@ -86,22 +93,17 @@ func (r *Resolver) Register(ctx context.Context, input RegisterInput) (*UserResp
 
 // RefreshToken resolves the refreshToken mutation
 func (r *Resolver) RefreshToken(ctx context.Context, input RefreshTokenInput) (*RefreshTokenResponse, error) {
-	rt, err := r.usecase.ValidateRefreshToken(ctx, input.RefreshToken)
-	if err != nil || rt.Revoked {
-		return nil, mapError(auth.ErrInvalidToken)
-	}
-
-	user, err := r.usecase.GetUserByID(ctx, rt.UserID)
+	rt, err := r.usecase.RotateRefreshToken(ctx, input.RefreshToken, sessionClientFromCtx(ctx))
 	if err != nil {
 		return nil, mapError(err)
 	}
 
-	tokenString, expiredAt, err := utils.GenerateJWTToken(user, r.cfg)
+	user, err := r.usecase.GetUserByID(ctx, rt.UserID)
 	if err != nil {
 		return nil, mapError(err)
 	}
 
-	newRefreshToken, refreshExp, err := r.usecase.GenerateRefreshToken(ctx, user.ID)
+	tokenString, expiredAt, err := utils.GenerateJWTToken(user, r.jwtKeys)
 	if err != nil {
 		return nil, mapError(err)
 	}
@@ -109,12 +111,25 @@ func (r *Resolver) RefreshToken(ctx context.Context, input RefreshTokenInput) (*
 	return &RefreshTokenResponse{
 		Token:           tokenString,
 		ExpiresAt:       formatTime(expiredAt),
-		RefreshToken:    newRefreshToken,
-		RefreshExpiresAt: formatTime(refreshExp),
+		RefreshToken:    rt.Token,
+		RefreshExpiresAt: formatTime(rt.ExpiresAt),
 	}, nil
 }
 
 // Helper functions
+
+// sessionClientCtxKey carries the device of the GraphQL request to the resolvers
+type sessionClientCtxKey struct{}
+
+func withSessionClient(c *gin.Context) context.Context {
+	return context.WithValue(c.Request.Context(), sessionClientCtxKey{}, utils.GetSessionClient(c))
+}
+
+func sessionClientFromCtx(ctx context.Context) *models.SessionClient {
+	client, _ := ctx.Value(sessionClientCtxKey{}).(*models.SessionClient)
+	return client
+}
+
 func formatTime(t time.Time) string {
 	return t.Format("2006-01-02 15:04:05")
 }
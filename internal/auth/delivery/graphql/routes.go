@@ -4,6 +4,7 @@ import (
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/ductong169z/shorten-url/config"
 	"github.com/ductong169z/shorten-url/internal/auth"
+	"github.com/ductong169z/shorten-url/pkg/jwtkeys"
 	"github.com/ductong169z/shorten-url/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
@@ -20,8 +21,8 @@ import (
 // @Success      200      {object}  object "Successful response"
 // @Failure      400,401  {object}  object "Error response"
 // @Router       /api/v1/graphql [post]
-func RegisterGraphQLRoutes(router *gin.RouterGroup, cfg *config.Config, usecase auth.UseCase, logger logger.Logger) {
-	handler := NewHandler(cfg, usecase, logger)
+func RegisterGraphQLRoutes(router *gin.RouterGroup, cfg *config.Config, usecase auth.UseCase, jwtKeys *jwtkeys.KeySet, logger logger.Logger) {
+	handler := NewHandler(cfg, usecase, jwtKeys, logger)
 	
 	// Register playground route (for development)
 	// @Summary      Auth GraphQL Playground
@@ -57,7 +58,7 @@ func RegisterGraphQLRoutes(router *gin.RouterGroup, cfg *config.Config, usecase
 		}
 
 		// Handle the GraphQL operation
-		response, err := handler.handleGraphQLOperation(c.Request.Context(), request.Query, request.Variables, request.OperationName)
+		response, err := handler.handleGraphQLOperation(withSessionClient(c), request.Query, request.Variables, request.OperationName)
 		if err != nil {
 			c.JSON(500, gin.H{"errors": []gin.H{{
 				"message": err.Error(),
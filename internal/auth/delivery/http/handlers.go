@@ -1,12 +1,14 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/ductong169z/shorten-url/config"
 	"github.com/ductong169z/shorten-url/internal/auth"
 	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/jwtkeys"
 	"github.com/ductong169z/shorten-url/pkg/logger"
 	"github.com/ductong169z/shorten-url/pkg/response"
 	"github.com/ductong169z/shorten-url/pkg/utils"
@@ -20,12 +22,13 @@ var GenerateJWTTokenFunc = utils.GenerateJWTToken
 type handlers struct {
 	cfg     *config.Config
 	usecase auth.UseCase
+	jwtKeys *jwtkeys.KeySet
 	logger  logger.Logger
 }
 
 // NewNewsHandlers News handlers constructor
-func NewHandlers(cfg *config.Config, usecase auth.UseCase, logger logger.Logger) auth.Handlers {
-	return &handlers{cfg: cfg, usecase: usecase, logger: logger}
+func NewHandlers(cfg *config.Config, usecase auth.UseCase, jwtKeys *jwtkeys.KeySet, logger logger.Logger) auth.Handlers {
+	return &handlers{cfg: cfg, usecase: usecase, jwtKeys: jwtKeys, logger: logger}
 }
 
 // GetUserByID godoc
@@ -58,13 +61,14 @@ func (h *handlers) GetUserByID(c *gin.Context) {
 
 // Login godoc
 // @Summary      User login
-// @Description  Authenticate user and return JWT and refresh token
+// @Description  Authenticate user and return JWT and refresh token. Users with two-factor authentication get a challenge to answer at /auth/login/2fa instead. Repeated failures make the username or IP wait (429) and eventually lock the account (423); both set Retry-After.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        loginRequest  body      LoginRequest  true  "Login credentials"
 // @Success      200           {object}  AuthSuccessResponse
-// @Failure      400,401       {object}  response.Response
+// @Success      202           {object}  LoginChallengeResponse
+// @Failure      400,401,403,423,429  {object}  response.Response
 // @Router       /auth/login [post]
 func (h *handlers) Login(c *gin.Context) {
 
@@ -78,19 +82,62 @@ func (h *handlers) Login(c *gin.Context) {
 		Username: loginRequest.Username,
 		Password: loginRequest.Password, // Truyền password dạng plaintext cho repo
 	}
-	user, err := h.usecase.Login(c.Request.Context(), loginAttempt)
+	user, challenge, err := h.usecase.Login(c.Request.Context(), loginAttempt, utils.GetSessionClient(c))
 	if err != nil {
+		var blocked *auth.LoginBlockedError
+		if errors.As(err, &blocked) {
+			c.Header("Retry-After", strconv.Itoa(blocked.RetryAfter()))
+		}
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+	if challenge != nil {
+		c.JSON(http.StatusAccepted, FromLoginChallengeModel(challenge))
+		return
+	}
+
+	h.respondWithTokens(c, user)
+}
+
+// LoginTwoFactor godoc
+// @Summary      Complete two-factor login
+// @Description  Answer the challenge of a password login with a TOTP or recovery code and return JWT and refresh token. Wrong codes count as failed logins of the username, so they are throttled (429) and lock the account (423) like wrong passwords; both set Retry-After.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        loginTwoFactorRequest  body      LoginTwoFactorRequest  true  "Challenge token and code"
+// @Success      200                    {object}  AuthSuccessResponse
+// @Failure      400,401,423,429        {object}  response.Response
+// @Router       /auth/login/2fa [post]
+func (h *handlers) LoginTwoFactor(c *gin.Context) {
+	var req LoginTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		response.WithMappedError(c, err, auth.MapError)
 		return
 	}
 
-	tokenString, expiredAt, err := utils.GenerateJWTToken(user, h.cfg)
+	user, err := h.usecase.CompleteLoginChallenge(c.Request.Context(), req.ChallengeToken, req.Code, utils.GetSessionClient(c))
 	if err != nil {
+		var blocked *auth.LoginBlockedError
+		if errors.As(err, &blocked) {
+			c.Header("Retry-After", strconv.Itoa(blocked.RetryAfter()))
+		}
 		response.WithMappedError(c, err, auth.MapError)
 		return
 	}
 
-	refreshToken, refreshTokenExpiresAt, err := h.usecase.GenerateRefreshToken(c.Request.Context(), user.ID)
+	h.respondWithTokens(c, user)
+}
+
+// respondWithTokens starts a session for user and responds with its JWT and refresh token
+func (h *handlers) respondWithTokens(c *gin.Context, user *models.User) {
+	tokenString, expiredAt, err := utils.GenerateJWTToken(user, h.jwtKeys)
+	if err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	refreshToken, refreshTokenExpiresAt, err := h.usecase.GenerateRefreshToken(c.Request.Context(), user.ID, utils.GetSessionClient(c))
 	if err != nil {
 		response.WithMappedError(c, err, auth.MapError)
 		return
@@ -109,7 +156,7 @@ func (h *handlers) Login(c *gin.Context) {
 
 // RefreshToken godoc
 // @Summary      Refresh JWT token
-// @Description  Issue a new JWT and refresh token
+// @Description  Issue a new JWT and rotate the refresh token; the presented token stops working, and presenting it again revokes every token of the login session
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -124,28 +171,19 @@ func (h *handlers) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	rt, err := h.usecase.ValidateRefreshToken(c.Request.Context(), req.RefreshToken)
-	if err != nil || rt.Revoked {
-		response.WithMappedError(c, auth.ErrInvalidToken, auth.MapError)
-		return
-	}
-
-	user, err := h.usecase.GetUserByID(c.Request.Context(), rt.UserID)
+	rt, err := h.usecase.RotateRefreshToken(c.Request.Context(), req.RefreshToken, utils.GetSessionClient(c))
 	if err != nil {
 		response.WithMappedError(c, err, auth.MapError)
 		return
 	}
 
-	// // Optionally revoke the used refresh token (rotation)
-	// h.usecase.RevokeRefreshToken(c.Request.Context(), req.RefreshToken)
-
-	tokenString, expiredAt, err := utils.GenerateJWTToken(user, h.cfg)
+	user, err := h.usecase.GetUserByID(c.Request.Context(), rt.UserID)
 	if err != nil {
 		response.WithMappedError(c, err, auth.MapError)
 		return
 	}
 
-	newRefreshToken, refreshExp, err := h.usecase.GenerateRefreshToken(c.Request.Context(), user.ID)
+	tokenString, expiredAt, err := utils.GenerateJWTToken(user, h.jwtKeys)
 	if err != nil {
 		response.WithMappedError(c, err, auth.MapError)
 		return
@@ -154,15 +192,15 @@ func (h *handlers) RefreshToken(c *gin.Context) {
 	response := RefreshTokenResponse{
 		Token:        tokenString,
 		ExpiresAt:    FormatTime(expiredAt),
-		RefreshToken: newRefreshToken,
-		RefreshExp:   FormatTime(refreshExp),
+		RefreshToken: rt.Token,
+		RefreshExp:   FormatTime(rt.ExpiresAt),
 	}
 	c.JSON(http.StatusOK, response)
 }
 
 // Register godoc
 // @Summary      Register new user
-// @Description  Create a new user account
+// @Description  Create a new user account and email a link to verify its address
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -181,7 +219,6 @@ func (h *handlers) Register(c *gin.Context) {
 		Username: registerRequest.Username,
 		Email:    registerRequest.Email,
 		Password: registerRequest.Password,
-		Role:     models.UserRole(registerRequest.Role),
 	}
 	user, err := h.usecase.Register(c.Request.Context(), newUser)
 	if err != nil {
@@ -192,3 +229,359 @@ func (h *handlers) Register(c *gin.Context) {
 	responseUser := FromUserModel(user)
 	response.WithOK(c, responseUser)
 }
+
+// VerifyEmail godoc
+// @Summary      Verify email address
+// @Description  Mark the email of the user the emailed verification token was issued to as verified
+// @Tags         auth
+// @Param        token  query     string  true  "Verification token from the email"
+// @Success      204
+// @Failure      400    {object}  response.Response
+// @Router       /auth/verify [get]
+func (h *handlers) VerifyEmail(c *gin.Context) {
+	if err := h.usecase.VerifyEmail(c.Request.Context(), c.Query("token")); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	response.WithNoContent(c)
+}
+
+// ResendVerificationEmail godoc
+// @Summary      Resend verification email
+// @Description  Email a new verification link if the address belongs to an unverified account; the response is the same either way. Too many requests for an address or from an IP are refused (429).
+// @Tags         auth
+// @Accept       json
+// @Param        resendVerificationRequest  body      ResendVerificationRequest  true  "Email address of the account"
+// @Success      202
+// @Failure      400,429                    {object}  response.Response
+// @Router       /auth/verify/resend [post]
+func (h *handlers) ResendVerificationEmail(c *gin.Context) {
+	var req ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	if err := h.usecase.ResendVerificationEmail(c.Request.Context(), req.Email, utils.GetSessionClient(c)); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ForgotPassword godoc
+// @Summary      Request a password reset
+// @Description  Email a single-use password reset link if the address belongs to an account; the response is the same either way. Too many requests for an address or from an IP are refused (429).
+// @Tags         auth
+// @Accept       json
+// @Param        forgotPasswordRequest  body      ForgotPasswordRequest  true  "Email address of the account"
+// @Success      202
+// @Failure      400,429                {object}  response.Response
+// @Router       /auth/password/forgot [post]
+func (h *handlers) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	if err := h.usecase.ForgotPassword(c.Request.Context(), req.Email, utils.GetSessionClient(c)); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ResetPassword godoc
+// @Summary      Reset password
+// @Description  Set a new password with the token from a password reset email and log the user out of every device
+// @Tags         auth
+// @Accept       json
+// @Param        resetPasswordRequest  body      ResetPasswordRequest  true  "Reset token and new password"
+// @Success      204
+// @Failure      400                   {object}  response.Response
+// @Router       /auth/password/reset [post]
+func (h *handlers) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	if err := h.usecase.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	response.WithNoContent(c)
+}
+
+// ChangePassword godoc
+// @Summary      Change password
+// @Description  Replace the password of the logged-in user and log out every session except the one of the given refresh token
+// @Tags         auth
+// @Accept       json
+// @Param        changePasswordRequest  body      ChangePasswordRequest  true  "Current and new password"
+// @Success      204
+// @Failure      400,401                {object}  response.Response
+// @Router       /auth/password/change [post]
+func (h *handlers) ChangePassword(c *gin.Context) {
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	if err := h.usecase.ChangePassword(c.Request.Context(), req.RefreshToken, req.CurrentPassword, req.NewPassword); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	response.WithNoContent(c)
+}
+
+// EnrollTOTP godoc
+// @Summary      Start two-factor enrollment
+// @Description  Generate a TOTP secret for the logged-in user; scan the otpauth URI as a QR code and confirm with a code to enable two-factor authentication
+// @Tags         auth
+// @Produce      json
+// @Success      200      {object}  TOTPEnrollmentResponse
+// @Failure      401,409  {object}  response.Response
+// @Router       /auth/2fa/enroll [post]
+func (h *handlers) EnrollTOTP(c *gin.Context) {
+	enrollment, err := h.usecase.EnrollTOTP(c.Request.Context())
+	if err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	response.WithOK(c, FromTOTPEnrollmentModel(enrollment))
+}
+
+// ConfirmTOTP godoc
+// @Summary      Confirm two-factor enrollment
+// @Description  Enable two-factor authentication with a code from the authenticator app and return single-use recovery codes, which are only shown once
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        twoFactorCodeRequest  body      TwoFactorCodeRequest  true  "TOTP code"
+// @Success      200                   {object}  RecoveryCodesResponse
+// @Failure      400,401,409           {object}  response.Response
+// @Router       /auth/2fa/confirm [post]
+func (h *handlers) ConfirmTOTP(c *gin.Context) {
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	codes, err := h.usecase.ConfirmTOTP(c.Request.Context(), req.Code)
+	if err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	response.WithOK(c, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableTOTP godoc
+// @Summary      Disable two-factor authentication
+// @Description  Turn two-factor authentication off with the current password and a TOTP or recovery code
+// @Tags         auth
+// @Accept       json
+// @Param        disableTOTPRequest  body      DisableTOTPRequest  true  "Current password and TOTP or recovery code"
+// @Success      204
+// @Failure      400,401             {object}  response.Response
+// @Router       /auth/2fa/disable [post]
+func (h *handlers) DisableTOTP(c *gin.Context) {
+	var req DisableTOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	if err := h.usecase.DisableTOTP(c.Request.Context(), req.CurrentPassword, req.Code); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	response.WithNoContent(c)
+}
+
+// UpdateProfile godoc
+// @Summary      Update own profile
+// @Description  Edit the display name, bio, avatar and social links of the logged-in user; omitted fields are left unchanged
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        profileRequest  body      ProfileRequest  true  "Profile fields to change"
+// @Success      200             {object}  ProfileResponse
+// @Failure      400,401         {object}  response.Response
+// @Router       /auth/me [patch]
+func (h *handlers) UpdateProfile(c *gin.Context) {
+	var req ProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	profile, err := h.usecase.UpdateProfile(c.Request.Context(), req.ToModel())
+	if err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	response.WithOK(c, FromProfileModel(profile))
+}
+
+// Logout godoc
+// @Summary      Log out
+// @Description  Revoke the given refresh token and the access token used for this request
+// @Tags         auth
+// @Accept       json
+// @Param        logoutRequest  body      RefreshTokenRequest  true  "Refresh token of the session"
+// @Success      204
+// @Failure      400,401        {object}  response.Response
+// @Router       /auth/logout [post]
+func (h *handlers) Logout(c *gin.Context) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	if err := h.usecase.Logout(c.Request.Context(), req.RefreshToken); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	response.WithNoContent(c)
+}
+
+// LogoutAll godoc
+// @Summary      Log out everywhere
+// @Description  Revoke every refresh token of the current user and the access token used for this request
+// @Tags         auth
+// @Success      204
+// @Failure      401  {object}  response.Response
+// @Router       /auth/logout-all [post]
+func (h *handlers) LogoutAll(c *gin.Context) {
+	if err := h.usecase.LogoutAll(c.Request.Context()); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	response.WithNoContent(c)
+}
+
+// ListSessions godoc
+// @Summary      List sessions
+// @Description  List the devices the current user is logged in on, most recently used first
+// @Tags         auth
+// @Produce      json
+// @Success      200  {array}   SessionResponse
+// @Failure      401  {object}  response.Response
+// @Router       /auth/sessions [get]
+func (h *handlers) ListSessions(c *gin.Context) {
+	sessions, err := h.usecase.ListSessions(c.Request.Context())
+	if err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	response.WithOK(c, FromSessionModels(sessions))
+}
+
+// RevokeSession godoc
+// @Summary      Revoke a session
+// @Description  Log one device of the current user out
+// @Tags         auth
+// @Param        id   path      int  true  "Session ID"
+// @Success      204
+// @Failure      400,401,404  {object}  response.Response
+// @Router       /auth/sessions/{id} [delete]
+func (h *handlers) RevokeSession(c *gin.Context) {
+	sessionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	if err := h.usecase.RevokeSession(c.Request.Context(), sessionID); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	response.WithNoContent(c)
+}
+
+// UnlockAccount godoc
+// @Summary      Unlock an account
+// @Description  Lift the lockout of a user after repeated failed logins and forget those failures. Requires the users:manage permission.
+// @Tags         auth
+// @Param        userId  path  int  true  "User ID"
+// @Success      204
+// @Failure      400,401,403,404  {object}  response.Response
+// @Router       /auth/user/{userId}/unlock [post]
+func (h *handlers) UnlockAccount(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	if err := h.usecase.UnlockAccount(c.Request.Context(), userID); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	response.WithNoContent(c)
+}
+
+// ChangeRole godoc
+// @Summary      Change the role of a user
+// @Description  Grant a user another role. Users cannot change their own role. The new role applies once the access token of the user is refreshed. Requires the users:manage permission.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        userId             path      int                true  "User ID"
+// @Param        changeRoleRequest  body      ChangeRoleRequest  true  "New role"
+// @Success      200                {object}  UserResponse
+// @Failure      400,401,403,404    {object}  response.Response
+// @Router       /auth/user/{userId}/role [put]
+func (h *handlers) ChangeRole(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	var req ChangeRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	user, err := h.usecase.ChangeRole(c.Request.Context(), userID, models.UserRole(req.Role))
+	if err != nil {
+		response.WithMappedError(c, err, auth.MapError)
+		return
+	}
+
+	response.WithOK(c, FromUserModel(user))
+}
+
+// JWKS godoc
+// @Summary      Access token signing keys
+// @Description  Public keys access tokens are verified with, as a JSON Web Key Set. Tokens name their key in the kid header; keys scheduled to start signing are listed ahead of time.
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  jwtkeys.JSONWebKeySet
+// @Router       /.well-known/jwks.json [get]
+func (h *handlers) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.jwtKeys.JWKS())
+}
@@ -15,6 +15,7 @@ import (
 	mock "github.com/ductong169z/shorten-url/internal/auth/mocks"
 	"github.com/ductong169z/shorten-url/internal/models"
 
+	"github.com/ductong169z/shorten-url/pkg/jwtkeys"
 	"github.com/ductong169z/shorten-url/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -39,8 +40,7 @@ func TestHandlers_Register(t *testing.T) {
 			givenInput: `{
 				"username": "test",
 				"email": "test@email.com",
-				"password": "pass",
-				"role": "user"
+				"password": "pass"
 			}`,
 			mockUseCase: mockUseCase{
 				expCall: true,
@@ -48,24 +48,23 @@ func TestHandlers_Register(t *testing.T) {
 					Username: "test",
 					Email:    "test@email.com",
 					Password: "pass",
-					Role:     models.RoleUser,
 				},
 				output: models.User{
 					ID:       1,
 					Username: "test",
 					Email:    "test@email.com",
 					Password: "pass",
-					Role:     models.RoleUser,
+					Role:     models.RoleReader,
 				},
 				err: nil,
 			},
-			expBody: `{"message":"Success","result":{"id":1,"username":"test","email":"test@email.com","role":"user","created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00"}}`,
+			expBody: `{"message":"Success","result":{"id":1,"username":"test","email":"test@email.com","email_verified":false,"role":"reader","created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00"}}`,
 
 			expErr:  nil,
 			expCode: http.StatusOK,
 		},
 		"invalid input": {
-			givenInput: `{"username": "", "email": "invalid", "password": "", "role": "user"}`,
+			givenInput: `{"username": "", "email": "invalid", "password": ""}`,
 			mockUseCase: mockUseCase{
 				expCall: false,
 			},
@@ -77,8 +76,7 @@ func TestHandlers_Register(t *testing.T) {
 			givenInput: `{
 				"username": "test2",
 				"email": "test2@email.com",
-				"password": "pass2",
-				"role": "user"
+				"password": "pass2"
 			}`,
 			mockUseCase: mockUseCase{
 				expCall: true,
@@ -86,7 +84,6 @@ func TestHandlers_Register(t *testing.T) {
 					Username: "test2",
 					Email:    "test2@email.com",
 					Password: "pass2",
-					Role:     models.RoleUser,
 				},
 				err: assert.AnError,
 			},
@@ -98,8 +95,7 @@ func TestHandlers_Register(t *testing.T) {
 			givenInput: `{
 				"username": "test3",
 				"email": "duplicate@email.com",
-				"password": "pass3",
-				"role": "user"
+				"password": "pass3"
 			}`,
 			mockUseCase: mockUseCase{
 				expCall: true,
@@ -107,7 +103,6 @@ func TestHandlers_Register(t *testing.T) {
 					Username: "test3",
 					Email:    "duplicate@email.com",
 					Password: "pass3",
-					Role:     models.RoleUser,
 				},
 				err: errors.New("email already exists"),
 			},
@@ -119,8 +114,7 @@ func TestHandlers_Register(t *testing.T) {
 			givenInput: `{
 				"username": "duplicate",
 				"email": "duplicate@email.com",
-				"password": "pass4",
-				"role": "user"
+				"password": "pass4"
 			}`,
 			mockUseCase: mockUseCase{
 				expCall: true,
@@ -128,7 +122,6 @@ func TestHandlers_Register(t *testing.T) {
 					Username: "duplicate",
 					Email:    "duplicate@email.com",
 					Password: "pass4",
-					Role:     models.RoleUser,
 				},
 				err: errors.New("username already exists"),
 			},
@@ -140,8 +133,7 @@ func TestHandlers_Register(t *testing.T) {
 			givenInput: `{
 				"username": "test4",
 				"email": "invalid",
-				"password": "pass4",
-				"role": "user"
+				"password": "pass4"
 			}`,
 			mockUseCase: mockUseCase{
 				expCall: false,
@@ -151,41 +143,27 @@ func TestHandlers_Register(t *testing.T) {
 			expCode: http.StatusInternalServerError,
 		},
 		"empty username": {
-			givenInput:  `{"username": "", "email": "emptyuser@email.com", "password": "pass", "role": "user"}`,
+			givenInput:  `{"username": "", "email": "emptyuser@email.com", "password": "pass"}`,
 			mockUseCase: mockUseCase{expCall: false},
 			expBody:     `{"message":"Internal server error"}`,
 			expErr:      nil,
 			expCode:     http.StatusInternalServerError,
 		},
 		"empty password": {
-			givenInput:  `{"username": "test5", "email": "emptypass@email.com", "password": "", "role": "user"}`,
+			givenInput:  `{"username": "test5", "email": "emptypass@email.com", "password": ""}`,
 			mockUseCase: mockUseCase{expCall: false},
 			expBody:     `{"message":"Internal server error"}`,
 			expErr:      nil,
 			expCode:     http.StatusInternalServerError,
 		},
 		"empty email": {
-			givenInput:  `{"username": "test6", "email": "", "password": "pass6", "role": "user"}`,
-			mockUseCase: mockUseCase{expCall: false},
-			expBody:     `{"message":"Internal server error"}`,
-			expErr:      nil,
-			expCode:     http.StatusInternalServerError,
-		},
-		"empty role": {
-			givenInput:  `{"username": "test7", "email": "role@email.com", "password": "pass7", "role": ""}`,
+			givenInput:  `{"username": "test6", "email": "", "password": "pass6"}`,
 			mockUseCase: mockUseCase{expCall: false},
 			expBody:     `{"message":"Internal server error"}`,
 			expErr:      nil,
 			expCode:     http.StatusInternalServerError,
 		},
-		"invalid role": {
-			givenInput:  `{"username": "test8", "email": "invrole@email.com", "password": "pass8", "role": "superuser"}`,
-			mockUseCase: mockUseCase{expCall: false},
-			expBody:     `{"message":"Internal server error"}`,
-			expErr:      nil,
-			expCode:     http.StatusInternalServerError,
-		},
-		"admin role": {
+		"requested role is ignored": {
 			givenInput: `{"username": "admin1", "email": "admin@email.com", "password": "adminpass", "role": "admin"}`,
 			mockUseCase: mockUseCase{
 				expCall: true,
@@ -193,55 +171,53 @@ func TestHandlers_Register(t *testing.T) {
 					Username: "admin1",
 					Email:    "admin@email.com",
 					Password: "adminpass",
-					Role:     models.RoleAdmin,
 				},
 				output: models.User{
 					ID:       2,
 					Username: "admin1",
 					Email:    "admin@email.com",
 					Password: "adminpass",
-					Role:     models.RoleAdmin,
+					Role:     models.RoleReader,
 				},
 				err: nil,
 			},
-			expBody: `{"message":"Success","result":{"id":2,"username":"admin1","email":"admin@email.com","role":"admin","created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00"}}`,
+			expBody: `{"message":"Success","result":{"id":2,"username":"admin1","email":"admin@email.com","email_verified":false,"role":"reader","created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00"}}`,
 			expErr:  nil,
 			expCode: http.StatusOK,
 		},
 		"all fields empty": {
-			givenInput:  `{"username": "", "email": "", "password": "", "role": ""}`,
+			givenInput:  `{"username": "", "email": "", "password": ""}`,
 			mockUseCase: mockUseCase{expCall: false},
 			expBody:     `{"message":"Internal server error"}`,
 			expErr:      nil,
 			expCode:     http.StatusInternalServerError,
 		},
 		"email format edge": {
-			givenInput:  `{"username": "test9", "email": "test9@", "password": "pass9", "role": "user"}`,
+			givenInput:  `{"username": "test9", "email": "test9@", "password": "pass9"}`,
 			mockUseCase: mockUseCase{expCall: false},
 			expBody:     `{"message":"Internal server error"}`,
 			expErr:      nil,
 			expCode:     http.StatusInternalServerError,
 		},
 		"extra field": {
-			givenInput: `{"username": "test10", "email": "test10@email.com", "password": "pass10", "role": "user", "extra": "field"}`,
+			givenInput: `{"username": "test10", "email": "test10@email.com", "password": "pass10", "extra": "field"}`,
 			mockUseCase: mockUseCase{
 				expCall: true,
 				input: &models.User{
 					Username: "test10",
 					Email:    "test10@email.com",
 					Password: "pass10",
-					Role:     models.RoleUser,
 				},
 				output: models.User{
 					ID:       3,
 					Username: "test10",
 					Email:    "test10@email.com",
 					Password: "pass10",
-					Role:     models.RoleUser,
+					Role:     models.RoleReader,
 				},
 				err: nil,
 			},
-			expBody: `{"message":"Success","result":{"id":3,"username":"test10","email":"test10@email.com","role":"user","created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00"}}`,
+			expBody: `{"message":"Success","result":{"id":3,"username":"test10","email":"test10@email.com","email_verified":false,"role":"reader","created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00"}}`,
 			expErr:  nil,
 			expCode: http.StatusOK,
 		},
@@ -270,7 +246,7 @@ func TestHandlers_Register(t *testing.T) {
 			}
 
 			// When
-			h := authhttp.NewHandlers(cfg, mockUseCase, apiLogger)
+			h := authhttp.NewHandlers(cfg, mockUseCase, jwtkeys.NewHMAC(cfg.Server.JwtSecretKey), apiLogger)
 			h.Register(c)
 
 			// Then
@@ -292,6 +268,7 @@ func TestHandlers_Login(t *testing.T) {
 		loginInput                *models.User
 		loginOutput               models.User
 		loginErr                  error
+		loginChallenge            *models.LoginChallenge
 		expGenerateRefreshCall    bool
 		generateRefreshUserID     int
 		generateRefreshToken      string
@@ -300,11 +277,12 @@ func TestHandlers_Login(t *testing.T) {
 	}
 
 	tcs := map[string]struct {
-		givenInput  string
-		mockUseCase mockUseCase
-		expBody     string
-		expErr      error
-		expCode     int
+		givenInput    string
+		mockUseCase   mockUseCase
+		expBody       string
+		expErr        error
+		expRetryAfter string
+		expCode       int
 	}{
 		"success": {
 			givenInput: `{
@@ -332,7 +310,26 @@ func TestHandlers_Login(t *testing.T) {
 				generateRefreshErr:     nil,
 			},
 			expCode: http.StatusOK,
-			expBody: `{"token":"*","expires_at":"*","refresh_token":"refresh_token_123","refresh_token_expires_at":"*","user":{"id":1,"username":"test","email":"test@example.com","role":"user","created_at":"*","updated_at":"*"}}`,
+			expBody: `{"token":"*","expires_at":"*","refresh_token":"refresh_token_123","refresh_token_expires_at":"*","user":{"id":1,"username":"test","email":"test@example.com","email_verified":false,"role":"user","created_at":"*","updated_at":"*"}}`,
+		},
+		"two_factor_challenge": {
+			givenInput: `{
+				"username": "test",
+				"password": "pass"
+			}`,
+			mockUseCase: mockUseCase{
+				expLoginCall: true,
+				loginInput: &models.User{
+					Username: "test",
+					Password: "pass",
+				},
+				loginChallenge: &models.LoginChallenge{
+					Token:     "challenge_123",
+					UserID:    1,
+					ExpiresAt: time.Now().Add(5 * time.Minute),
+				},
+			},
+			expCode: http.StatusAccepted,
 		},
 		"empty_username": {
 			givenInput: `{
@@ -365,6 +362,38 @@ func TestHandlers_Login(t *testing.T) {
 			},
 			expCode: http.StatusUnauthorized,
 		},
+		"account_locked": {
+			givenInput: `{
+				"username": "test",
+				"password": "pass"
+			}`,
+			mockUseCase: mockUseCase{
+				expLoginCall: true,
+				loginInput: &models.User{
+					Username: "test",
+					Password: "pass",
+				},
+				loginErr: &auth.LoginBlockedError{Err: auth.ErrAccountLocked, Until: time.Now().Add(10 * time.Minute)},
+			},
+			expRetryAfter: "600",
+			expCode:       http.StatusLocked,
+		},
+		"too_many_attempts": {
+			givenInput: `{
+				"username": "test",
+				"password": "pass"
+			}`,
+			mockUseCase: mockUseCase{
+				expLoginCall: true,
+				loginInput: &models.User{
+					Username: "test",
+					Password: "pass",
+				},
+				loginErr: &auth.LoginBlockedError{Err: auth.ErrTooManyLoginAttempts, Until: time.Now().Add(4 * time.Second)},
+			},
+			expRetryAfter: "4",
+			expCode:       http.StatusTooManyRequests,
+		},
 		"invalid_json": {
 			givenInput:  `{"username": "test", "password":}`,
 			mockUseCase: mockUseCase{},
@@ -416,10 +445,14 @@ func TestHandlers_Login(t *testing.T) {
 			c.Request.Header.Add("Content-Type", "application/json")
 
 			if tc.mockUseCase.expLoginCall {
-				mockUseCase.EXPECT().Login(gomock.Any(), gomock.Eq(tc.mockUseCase.loginInput)).Return(&tc.mockUseCase.loginOutput, tc.mockUseCase.loginErr)
+				loginOutput := &tc.mockUseCase.loginOutput
+				if tc.mockUseCase.loginChallenge != nil {
+					loginOutput = nil
+				}
+				mockUseCase.EXPECT().Login(gomock.Any(), gomock.Eq(tc.mockUseCase.loginInput), gomock.Any()).Return(loginOutput, tc.mockUseCase.loginChallenge, tc.mockUseCase.loginErr)
 				
 				if tc.mockUseCase.loginErr == nil && tc.mockUseCase.expGenerateRefreshCall {
-					mockUseCase.EXPECT().GenerateRefreshToken(gomock.Any(), tc.mockUseCase.generateRefreshUserID).Return(
+					mockUseCase.EXPECT().GenerateRefreshToken(gomock.Any(), tc.mockUseCase.generateRefreshUserID, gomock.Any()).Return(
 						tc.mockUseCase.generateRefreshToken, 
 						tc.mockUseCase.generateRefreshExpiry, 
 						tc.mockUseCase.generateRefreshErr,
@@ -428,11 +461,12 @@ func TestHandlers_Login(t *testing.T) {
 			}
 
 			// When
-			h := authhttp.NewHandlers(cfg, mockUseCase, apiLogger)
+			h := authhttp.NewHandlers(cfg, mockUseCase, jwtkeys.NewHMAC(cfg.Server.JwtSecretKey), apiLogger)
 			h.Login(c)
 
 			// Then
 			assert.Equal(t, tc.expCode, w.Code)
+			assert.Equal(t, tc.expRetryAfter, w.Header().Get("Retry-After"))
 		})
 	}
 }
@@ -464,7 +498,7 @@ func TestHandlers_GetUserByID(t *testing.T) {
 				},
 				err: nil,
 			},
-			expBody: `{"message":"Success","result":{"id":1,"username":"test","email":"test@example.com","role":"user","created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00"}}`,
+			expBody: `{"message":"Success","result":{"id":1,"username":"test","email":"test@example.com","email_verified":false,"role":"user","created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00"}}`,
 			expCode: http.StatusOK,
 		},
 		"admin_user": {
@@ -480,7 +514,7 @@ func TestHandlers_GetUserByID(t *testing.T) {
 				},
 				err: nil,
 			},
-			expBody: `{"message":"Success","result":{"id":2,"username":"admin","email":"admin@example.com","role":"admin","created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00"}}`,
+			expBody: `{"message":"Success","result":{"id":2,"username":"admin","email":"admin@example.com","email_verified":false,"role":"admin","created_at":"0001-01-01 00:00:00","updated_at":"0001-01-01 00:00:00"}}`,
 			expCode: http.StatusOK,
 		},
 		"zero_id": {
@@ -545,7 +579,7 @@ func TestHandlers_GetUserByID(t *testing.T) {
 			}
 
 			// When
-			h := authhttp.NewHandlers(cfg, mockUseCase, apiLogger)
+			h := authhttp.NewHandlers(cfg, mockUseCase, jwtkeys.NewHMAC(cfg.Server.JwtSecretKey), apiLogger)
 			h.GetUserByID(c)
 
 			// Then
@@ -559,19 +593,14 @@ func TestHandlers_GetUserByID(t *testing.T) {
 
 func TestHandlers_RefreshToken(t *testing.T) {
 	type mockUseCase struct {
-		expValidateCall       bool
-		validateInput         string
-		validateOutput        models.RefreshToken
-		validateErr           error
-		expGetUserCall        bool
-		getUserID             int
-		getUserOutput         models.User
-		getUserErr            error
-		expGenerateRefreshCall bool
-		generateRefreshUserID  int
-		generateRefreshToken   string
-		generateRefreshExpiry  time.Time
-		generateRefreshErr     error
+		expRotateCall  bool
+		rotateInput    string
+		rotateOutput   models.RefreshToken
+		rotateErr      error
+		expGetUserCall bool
+		getUserID      int
+		getUserOutput  models.User
+		getUserErr     error
 	}
 
 	tcs := map[string]struct {
@@ -585,16 +614,15 @@ func TestHandlers_RefreshToken(t *testing.T) {
 				"refresh_token": "valid_refresh_token"
 			}`,
 			mockUseCase: mockUseCase{
-				expValidateCall: true,
-				validateInput:   "valid_refresh_token",
-				validateOutput: models.RefreshToken{
-					ID:        1,
+				expRotateCall: true,
+				rotateInput:   "valid_refresh_token",
+				rotateOutput: models.RefreshToken{
+					ID:        2,
 					UserID:    1,
-					Token:     "valid_refresh_token",
-					ExpiresAt: time.Now().Add(time.Hour * 24),
-					Revoked:   false,
+					Token:     "new_refresh_token",
+					FamilyID:  "family-1",
+					ExpiresAt: time.Now().Add(time.Hour * 24 * 7),
 				},
-				validateErr:    nil,
 				expGetUserCall: true,
 				getUserID:      1,
 				getUserOutput: models.User{
@@ -603,12 +631,6 @@ func TestHandlers_RefreshToken(t *testing.T) {
 					Email:    "test@example.com",
 					Role:     models.RoleUser,
 				},
-				getUserErr:             nil,
-				expGenerateRefreshCall: true,
-				generateRefreshUserID:  1,
-				generateRefreshToken:   "new_refresh_token",
-				generateRefreshExpiry:  time.Now().Add(time.Hour * 24 * 7),
-				generateRefreshErr:     nil,
 			},
 			expCode: http.StatusOK,
 			expBody: `{"token":"*","expires_at":"*","refresh_token":"new_refresh_token","refresh_expires_at":"*"}`,
@@ -618,16 +640,15 @@ func TestHandlers_RefreshToken(t *testing.T) {
 				"refresh_token": "admin_refresh_token"
 			}`,
 			mockUseCase: mockUseCase{
-				expValidateCall: true,
-				validateInput:   "admin_refresh_token",
-				validateOutput: models.RefreshToken{
-					ID:        5,
+				expRotateCall: true,
+				rotateInput:   "admin_refresh_token",
+				rotateOutput: models.RefreshToken{
+					ID:        6,
 					UserID:    2,
-					Token:     "admin_refresh_token",
-					ExpiresAt: time.Now().Add(time.Hour * 24),
-					Revoked:   false,
+					Token:     "new_admin_refresh_token",
+					FamilyID:  "family-2",
+					ExpiresAt: time.Now().Add(time.Hour * 24 * 7),
 				},
-				validateErr:    nil,
 				expGetUserCall: true,
 				getUserID:      2,
 				getUserOutput: models.User{
@@ -636,12 +657,6 @@ func TestHandlers_RefreshToken(t *testing.T) {
 					Email:    "admin@example.com",
 					Role:     models.RoleAdmin,
 				},
-				getUserErr:             nil,
-				expGenerateRefreshCall: true,
-				generateRefreshUserID:  2,
-				generateRefreshToken:   "new_admin_refresh_token",
-				generateRefreshExpiry:  time.Now().Add(time.Hour * 24 * 7),
-				generateRefreshErr:     nil,
 			},
 			expCode: http.StatusOK,
 			expBody: `{"token":"*","expires_at":"*","refresh_token":"new_admin_refresh_token","refresh_expires_at":"*"}`,
@@ -658,27 +673,20 @@ func TestHandlers_RefreshToken(t *testing.T) {
 				"refresh_token": "invalid_token"
 			}`,
 			mockUseCase: mockUseCase{
-				expValidateCall: true,
-				validateInput:   "invalid_token",
-				validateErr:     auth.ErrInvalidToken,
+				expRotateCall: true,
+				rotateInput:   "invalid_token",
+				rotateErr:     auth.ErrInvalidToken,
 			},
 			expCode: http.StatusUnauthorized,
 		},
-		"revoked_token": {
+		"reused_token": {
 			givenInput: `{
-				"refresh_token": "revoked_token"
+				"refresh_token": "rotated_token"
 			}`,
 			mockUseCase: mockUseCase{
-				expValidateCall: true,
-				validateInput:   "revoked_token",
-				validateOutput: models.RefreshToken{
-					ID:        2,
-					UserID:    1,
-					Token:     "revoked_token",
-					ExpiresAt: time.Now().Add(time.Hour * 24),
-					Revoked:   true,
-				},
-				validateErr: nil,
+				expRotateCall: true,
+				rotateInput:   "rotated_token",
+				rotateErr:     auth.ErrRefreshTokenReused,
 			},
 			expCode: http.StatusUnauthorized,
 		},
@@ -687,49 +695,28 @@ func TestHandlers_RefreshToken(t *testing.T) {
 				"refresh_token": "valid_token_user_not_found"
 			}`,
 			mockUseCase: mockUseCase{
-				expValidateCall: true,
-				validateInput:   "valid_token_user_not_found",
-				validateOutput: models.RefreshToken{
+				expRotateCall: true,
+				rotateInput:   "valid_token_user_not_found",
+				rotateOutput: models.RefreshToken{
 					ID:        3,
 					UserID:    999,
-					Token:     "valid_token_user_not_found",
-					ExpiresAt: time.Now().Add(time.Hour * 24),
-					Revoked:   false,
+					Token:     "new_token_user_not_found",
+					ExpiresAt: time.Now().Add(time.Hour * 24 * 7),
 				},
-				validateErr:    nil,
 				expGetUserCall: true,
 				getUserID:      999,
 				getUserErr:     auth.ErrUserNotFound,
 			},
 			expCode: http.StatusNotFound,
 		},
-		"refresh_token_generation_error": {
+		"rotation_error": {
 			givenInput: `{
-				"refresh_token": "valid_token_refresh_error"
+				"refresh_token": "valid_token_rotation_error"
 			}`,
 			mockUseCase: mockUseCase{
-				expValidateCall: true,
-				validateInput:   "valid_token_refresh_error",
-				validateOutput: models.RefreshToken{
-					ID:        4,
-					UserID:    2,
-					Token:     "valid_token_refresh_error",
-					ExpiresAt: time.Now().Add(time.Hour * 24),
-					Revoked:   false,
-				},
-				validateErr:    nil,
-				expGetUserCall: true,
-				getUserID:      2,
-				getUserOutput: models.User{
-					ID:       2,
-					Username: "test2",
-					Email:    "test2@example.com",
-					Role:     models.RoleUser,
-				},
-				getUserErr:             nil,
-				expGenerateRefreshCall: true,
-				generateRefreshUserID:  2,
-				generateRefreshErr:     errors.New("refresh token generation error"),
+				expRotateCall: true,
+				rotateInput:   "valid_token_rotation_error",
+				rotateErr:     errors.New("refresh token rotation error"),
 			},
 			expCode: http.StatusInternalServerError,
 		},
@@ -758,24 +745,20 @@ func TestHandlers_RefreshToken(t *testing.T) {
 			c.Request, _ = http.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewBuffer([]byte(tc.givenInput)))
 			c.Request.Header.Add("Content-Type", "application/json")
 
-			if tc.mockUseCase.expValidateCall {
-				mockUseCase.EXPECT().ValidateRefreshToken(gomock.Any(), tc.mockUseCase.validateInput).Return(&tc.mockUseCase.validateOutput, tc.mockUseCase.validateErr)
+			if tc.mockUseCase.expRotateCall {
+				var rotated *models.RefreshToken
+				if tc.mockUseCase.rotateErr == nil {
+					rotated = &tc.mockUseCase.rotateOutput
+				}
+				mockUseCase.EXPECT().RotateRefreshToken(gomock.Any(), tc.mockUseCase.rotateInput, gomock.Any()).Return(rotated, tc.mockUseCase.rotateErr)
 
-				if tc.mockUseCase.validateErr == nil && !tc.mockUseCase.validateOutput.Revoked && tc.mockUseCase.expGetUserCall {
+				if tc.mockUseCase.expGetUserCall {
 					mockUseCase.EXPECT().GetUserByID(gomock.Any(), tc.mockUseCase.getUserID).Return(&tc.mockUseCase.getUserOutput, tc.mockUseCase.getUserErr)
-
-					if tc.mockUseCase.getUserErr == nil && tc.mockUseCase.expGenerateRefreshCall {
-						mockUseCase.EXPECT().GenerateRefreshToken(gomock.Any(), tc.mockUseCase.generateRefreshUserID).Return(
-							tc.mockUseCase.generateRefreshToken,
-							tc.mockUseCase.generateRefreshExpiry,
-							tc.mockUseCase.generateRefreshErr,
-						)
-					}
 				}
 			}
 
 			// When
-			h := authhttp.NewHandlers(cfg, mockUseCase, apiLogger)
+			h := authhttp.NewHandlers(cfg, mockUseCase, jwtkeys.NewHMAC(cfg.Server.JwtSecretKey), apiLogger)
 			h.RefreshToken(c)
 
 			// Then
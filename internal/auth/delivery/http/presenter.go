@@ -19,20 +19,75 @@ type AuthSuccessResponse struct {
 	User                  UserResponse `json:"user"`
 }
 
+// LoginChallengeResponse is returned by a password login that needs a second factor
+type LoginChallengeResponse struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+	ExpiresAt         string `json:"expires_at"`
+}
+
+type LoginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// DisableTOTPRequest takes the current password besides the code, as ChangePasswordRequest does
+type DisableTOTPRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	Code            string `json:"code" binding:"required"`
+}
+
+type TOTPEnrollmentResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
 type RegisterRequest struct {
 	Username string `json:"username" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
-	Role     string `json:"role" binding:"required,oneof=admin user"`
+}
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required" enums:"admin,editor,author,reader,user"`
 }
 
 type UserResponse struct {
-	ID        int    `json:"id"`
-	Username  string `json:"username"`
-	Email     string `json:"email,omitempty"`
-	Role      string `json:"role,omitempty"`
-	CreatedAt string `json:"created_at,omitempty"`
-	UpdatedAt string `json:"updated_at,omitempty"`
+	ID            int    `json:"id"`
+	Username      string `json:"username"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified bool   `json:"email_verified"`
+	Role          string `json:"role,omitempty"`
+	CreatedAt     string `json:"created_at,omitempty"`
+	UpdatedAt     string `json:"updated_at,omitempty"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ChangePasswordRequest carries the refresh token of the session that stays logged in
+type ChangePasswordRequest struct {
+	RefreshToken    string `json:"refresh_token" binding:"required"`
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
 }
 
 type RefreshTokenRequest struct {
@@ -46,6 +101,22 @@ type RefreshTokenResponse struct {
 	RefreshExp   string `json:"refresh_expires_at"`
 }
 
+// ProfileRequest edits the current user's profile; omitted fields are left unchanged
+type ProfileRequest struct {
+	DisplayName *string            `json:"display_name,omitempty"`
+	Bio         *string            `json:"bio,omitempty"`
+	AvatarURL   *string            `json:"avatar_url,omitempty"`
+	SocialLinks *map[string]string `json:"social_links,omitempty"`
+}
+
+type ProfileResponse struct {
+	UserID      int               `json:"user_id"`
+	DisplayName string            `json:"display_name"`
+	Bio         string            `json:"bio"`
+	AvatarURL   string            `json:"avatar_url"`
+	SocialLinks map[string]string `json:"social_links"`
+}
+
 func FormatTime(time time.Time) string {
 	return time.Format("2006-01-02 15:04:05")
 }
@@ -56,12 +127,13 @@ func FromUserModel(user *models.User) UserResponse {
 	}
 
 	return UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		Role:      user.Role.String(),
-		CreatedAt: FormatTime(user.CreatedAt),
-		UpdatedAt: FormatTime(user.UpdatedAt),
+		ID:            user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		Role:          user.Role.String(),
+		CreatedAt:     FormatTime(user.CreatedAt),
+		UpdatedAt:     FormatTime(user.UpdatedAt),
 	}
 
 }
@@ -78,3 +150,69 @@ func FromUserModelList(users []*models.User) []UserResponse {
 
 	return userResponses
 }
+
+func (r *ProfileRequest) ToModel() *models.UserProfileUpdate {
+	update := &models.UserProfileUpdate{
+		DisplayName: r.DisplayName,
+		Bio:         r.Bio,
+		AvatarURL:   r.AvatarURL,
+	}
+	if r.SocialLinks != nil {
+		links := models.SocialLinks(*r.SocialLinks)
+		update.SocialLinks = &links
+	}
+	return update
+}
+
+func FromProfileModel(profile *models.UserProfile) ProfileResponse {
+	links := profile.SocialLinks
+	if links == nil {
+		links = models.SocialLinks{}
+	}
+
+	return ProfileResponse{
+		UserID:      profile.UserID,
+		DisplayName: profile.DisplayName,
+		Bio:         profile.Bio,
+		AvatarURL:   profile.AvatarURL,
+		SocialLinks: links,
+	}
+}
+
+// SessionResponse is a device the user is logged in on
+type SessionResponse struct {
+	ID         int    `json:"id"`
+	UserAgent  string `json:"user_agent"`
+	IP         string `json:"ip"`
+	LastUsedAt string `json:"last_used_at"`
+	ExpiresAt  string `json:"expires_at"`
+}
+
+func FromSessionModels(tokens []*models.RefreshToken) []SessionResponse {
+	sessions := make([]SessionResponse, len(tokens))
+	for i, rt := range tokens {
+		sessions[i] = SessionResponse{
+			ID:         rt.ID,
+			UserAgent:  rt.UserAgent,
+			IP:         rt.IP,
+			LastUsedAt: FormatTime(rt.LastUsedAt),
+			ExpiresAt:  FormatTime(rt.ExpiresAt),
+		}
+	}
+	return sessions
+}
+
+func FromLoginChallengeModel(challenge *models.LoginChallenge) LoginChallengeResponse {
+	return LoginChallengeResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    challenge.Token,
+		ExpiresAt:         FormatTime(challenge.ExpiresAt),
+	}
+}
+
+func FromTOTPEnrollmentModel(enrollment *models.TOTPEnrollment) TOTPEnrollmentResponse {
+	return TOTPEnrollmentResponse{
+		Secret:     enrollment.Secret,
+		OTPAuthURI: enrollment.URI,
+	}
+}
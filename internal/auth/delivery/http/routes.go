@@ -3,6 +3,7 @@ package http
 import (
 	"github.com/ductong169z/shorten-url/internal/auth"
 	"github.com/ductong169z/shorten-url/internal/middleware"
+	"github.com/ductong169z/shorten-url/internal/models"
 	"github.com/gin-gonic/gin"
 )
 
@@ -10,7 +11,28 @@ import (
 func MapRoutes(group *gin.RouterGroup, h auth.Handlers, mw *middleware.MiddlewareManager) {
 	group.POST("/register", h.Register)
 	group.POST("/login", h.Login)
+	group.POST("/login/2fa", h.LoginTwoFactor)
 	group.POST("/refresh", h.RefreshToken)
+	group.GET("/verify", h.VerifyEmail)
+	group.POST("/verify/resend", h.ResendVerificationEmail)
+	group.POST("/password/forgot", h.ForgotPassword)
+	group.POST("/password/reset", h.ResetPassword)
 	group.Use(mw.AuthJWTMiddleware())
 	group.GET("/user/:userId", h.GetUserByID)
+	group.PATCH("/me", h.UpdateProfile)
+	group.POST("/password/change", h.ChangePassword)
+	group.POST("/2fa/enroll", h.EnrollTOTP)
+	group.POST("/2fa/confirm", h.ConfirmTOTP)
+	group.POST("/2fa/disable", h.DisableTOTP)
+	group.POST("/logout", h.Logout)
+	group.POST("/logout-all", h.LogoutAll)
+	group.GET("/sessions", h.ListSessions)
+	group.DELETE("/sessions/:id", h.RevokeSession)
+	group.POST("/user/:userId/unlock", mw.RequirePermission(models.PermUsersManage), h.UnlockAccount)
+	group.PUT("/user/:userId/role", mw.RequirePermission(models.PermUsersManage), h.ChangeRole)
+}
+
+// Map the well-known routes, served without the API prefix
+func MapWellKnownRoutes(group *gin.RouterGroup, h auth.Handlers) {
+	group.GET("/.well-known/jwks.json", h.JWKS)
 }
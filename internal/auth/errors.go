@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"encoding/json"
 	"strconv"
+	"math"
+	"time"
 	"github.com/gin-gonic/gin"
 )
 
@@ -18,6 +20,42 @@ const (
 	errInvalidCredentials = "invalid credentials"
 	// errInvalidToken is returned when a token is invalid or expired.
 	errInvalidToken = "invalid token"
+	// errRefreshTokenReused is returned when an already rotated refresh token is presented again.
+	errRefreshTokenReused = "refresh token reused, please log in again"
+	// errEmailNotVerified is returned when an unverified user logs in while verification is required.
+	errEmailNotVerified = "email not verified"
+	// errInvalidVerificationToken is returned when an email verification token is forged, expired or outdated.
+	errInvalidVerificationToken = "invalid verification token"
+	// errInvalidResetToken is returned when a password reset token is unknown, expired or already used.
+	errInvalidResetToken = "invalid or expired reset token"
+	// errPasswordTooShort is returned when a new password is shorter than the policy allows.
+	errPasswordTooShort = "password is too short"
+	// errPasswordTooLong is returned when a new password is longer than 72 bytes.
+	errPasswordTooLong = "password is too long"
+	// errPasswordTooSimple is returned when a new password mixes too few kinds of characters.
+	errPasswordTooSimple = "password must mix lower case, upper case, digits or symbols"
+	// errPasswordTooCommon is returned when a new password is on the common password list.
+	errPasswordTooCommon = "password is too common"
+	// errPasswordContainsAccount is returned when a new password contains the username or email.
+	errPasswordContainsAccount = "password must not contain the username or email"
+	// errIncorrectPassword is returned when the current password given to change it is wrong.
+	errIncorrectPassword = "current password is incorrect"
+	// errTOTPAlreadyEnabled is returned when enrolling or confirming while two-factor authentication is on.
+	errTOTPAlreadyEnabled = "two-factor authentication is already enabled"
+	// errTOTPNotEnabled is returned when confirming or disabling without the matching enrollment state.
+	errTOTPNotEnabled = "two-factor authentication is not enabled"
+	// errInvalidTwoFactorCode is returned when a TOTP or recovery code is wrong or was already used.
+	errInvalidTwoFactorCode = "invalid two-factor code"
+	// errInvalidLoginChallenge is returned when a login challenge is unknown, expired or out of attempts.
+	errInvalidLoginChallenge = "invalid or expired login challenge"
+	// errTwoFactorRequired is returned by logins that cannot complete a two-factor challenge.
+	errTwoFactorRequired = "two-factor authentication required"
+	// errTooManyLoginAttempts is returned while a username or IP has to wait after failed logins.
+	errTooManyLoginAttempts = "too many login attempts, please try again later"
+	// errAccountLocked is returned while an account is locked after repeated failed logins.
+	errAccountLocked = "account is temporarily locked"
+	// errTooManyMailRequests is returned while an email address or IP has asked for too many account emails.
+	errTooManyMailRequests = "too many email requests, please try again later"
 	// errFailedToCheckUsername is returned when a username check fails.
 	errFailedToCheckUsername = "failed to check username"
 	// errFailedToCheckEmail is returned when an email check fails.
@@ -26,6 +64,20 @@ const (
 	errFailedToHashPassword = "failed to hash password"
 	// errFailedToRegisterUser is returned when user registration fails.
 	errFailedToRegisterUser = "failed to register user"
+	// errInvalidRole is returned when a role is not one of the known roles.
+	errInvalidRole = "invalid role"
+	// errCannotChangeOwnRole is returned when users try to change their own role.
+	errCannotChangeOwnRole = "cannot change your own role"
+	// errSessionNotFound is returned when the session does not exist or belongs to another user.
+	errSessionNotFound = "session not found"
+	// errInvalidDisplayName is returned when the display name is too long.
+	errInvalidDisplayName = "invalid display name"
+	// errInvalidBio is returned when the bio is too long.
+	errInvalidBio = "invalid bio"
+	// errInvalidAvatarURL is returned when the avatar is not an http(s) URL.
+	errInvalidAvatarURL = "invalid avatar url"
+	// errInvalidSocialLinks is returned when social links have bad names or URLs.
+	errInvalidSocialLinks = "invalid social links"
 )
 
 var (
@@ -37,6 +89,42 @@ var (
 	ErrInvalidCredentials = errors.New(errInvalidCredentials)
 	// ErrInvalidToken indicates an invalid or expired token.
 	ErrInvalidToken = errors.New(errInvalidToken)
+	// ErrRefreshTokenReused indicates a rotated refresh token was presented again and its family was revoked.
+	ErrRefreshTokenReused = errors.New(errRefreshTokenReused)
+	// ErrEmailNotVerified indicates the user has to verify their email first.
+	ErrEmailNotVerified = errors.New(errEmailNotVerified)
+	// ErrInvalidVerificationToken indicates an invalid email verification token.
+	ErrInvalidVerificationToken = errors.New(errInvalidVerificationToken)
+	// ErrInvalidResetToken indicates an invalid password reset token.
+	ErrInvalidResetToken = errors.New(errInvalidResetToken)
+	// ErrPasswordTooShort indicates a password below the minimum length.
+	ErrPasswordTooShort = errors.New(errPasswordTooShort)
+	// ErrPasswordTooLong indicates a password bcrypt cannot hash in full.
+	ErrPasswordTooLong = errors.New(errPasswordTooLong)
+	// ErrPasswordTooSimple indicates a password with too few character classes.
+	ErrPasswordTooSimple = errors.New(errPasswordTooSimple)
+	// ErrPasswordTooCommon indicates a commonly used password.
+	ErrPasswordTooCommon = errors.New(errPasswordTooCommon)
+	// ErrPasswordContainsAccount indicates a password containing the username or email.
+	ErrPasswordContainsAccount = errors.New(errPasswordContainsAccount)
+	// ErrIncorrectPassword indicates a wrong current password.
+	ErrIncorrectPassword = errors.New(errIncorrectPassword)
+	// ErrTOTPAlreadyEnabled indicates two-factor authentication is already enabled.
+	ErrTOTPAlreadyEnabled = errors.New(errTOTPAlreadyEnabled)
+	// ErrTOTPNotEnabled indicates two-factor authentication is not enabled or not enrolled.
+	ErrTOTPNotEnabled = errors.New(errTOTPNotEnabled)
+	// ErrInvalidTwoFactorCode indicates a wrong or reused TOTP or recovery code.
+	ErrInvalidTwoFactorCode = errors.New(errInvalidTwoFactorCode)
+	// ErrInvalidLoginChallenge indicates an unusable login challenge.
+	ErrInvalidLoginChallenge = errors.New(errInvalidLoginChallenge)
+	// ErrTwoFactorRequired indicates the login needs a second factor.
+	ErrTwoFactorRequired = errors.New(errTwoFactorRequired)
+	// ErrTooManyLoginAttempts indicates a login attempt during the backoff after failed logins.
+	ErrTooManyLoginAttempts = errors.New(errTooManyLoginAttempts)
+	// ErrAccountLocked indicates a login attempt on a locked account.
+	ErrAccountLocked = errors.New(errAccountLocked)
+	// ErrTooManyMailRequests indicates too many verification or password reset emails were asked for.
+	ErrTooManyMailRequests = errors.New(errTooManyMailRequests)
 	// ErrFailedToCheckUsername indicates a failure to check username.
 	ErrFailedToCheckUsername = errors.New(errFailedToCheckUsername)
 	// ErrFailedToCheckEmail indicates a failure to check email.
@@ -45,8 +133,45 @@ var (
 	ErrFailedToHashPassword = errors.New(errFailedToHashPassword)
 	// ErrFailedToRegisterUser indicates a failure to register user.
 	ErrFailedToRegisterUser = errors.New(errFailedToRegisterUser)
+	// ErrInvalidRole indicates an unknown role.
+	ErrInvalidRole = errors.New(errInvalidRole)
+	// ErrCannotChangeOwnRole indicates users changing their own role.
+	ErrCannotChangeOwnRole = errors.New(errCannotChangeOwnRole)
+	// ErrSessionNotFound indicates that the session was not found.
+	ErrSessionNotFound = errors.New(errSessionNotFound)
+	// ErrInvalidDisplayName indicates an invalid display name.
+	ErrInvalidDisplayName = errors.New(errInvalidDisplayName)
+	// ErrInvalidBio indicates an invalid bio.
+	ErrInvalidBio = errors.New(errInvalidBio)
+	// ErrInvalidAvatarURL indicates an invalid avatar URL.
+	ErrInvalidAvatarURL = errors.New(errInvalidAvatarURL)
+	// ErrInvalidSocialLinks indicates invalid social links.
+	ErrInvalidSocialLinks = errors.New(errInvalidSocialLinks)
 )
 
+// LoginBlockedError wraps ErrTooManyLoginAttempts or ErrAccountLocked with the time the block ends.
+type LoginBlockedError struct {
+	Err   error
+	Until time.Time
+}
+
+func (e *LoginBlockedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LoginBlockedError) Unwrap() error {
+	return e.Err
+}
+
+// RetryAfter returns the whole seconds left until the block ends, at least one.
+func (e *LoginBlockedError) RetryAfter() int {
+	seconds := int(math.Ceil(time.Until(e.Until).Seconds()))
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
 // MapError maps an authentication error to an HTTP status code and message.
 // It provides a unified way to translate auth errors to HTTP responses.
 func MapError(err error) (status int, message string) {
@@ -70,6 +195,42 @@ func MapError(err error) (status int, message string) {
 		return http.StatusUnauthorized, errInvalidCredentials
 	case errors.Is(err, ErrInvalidToken):
 		return http.StatusUnauthorized, errInvalidToken
+	case errors.Is(err, ErrRefreshTokenReused):
+		return http.StatusUnauthorized, errRefreshTokenReused
+	case errors.Is(err, ErrEmailNotVerified):
+		return http.StatusForbidden, errEmailNotVerified
+	case errors.Is(err, ErrInvalidVerificationToken):
+		return http.StatusBadRequest, errInvalidVerificationToken
+	case errors.Is(err, ErrInvalidResetToken):
+		return http.StatusBadRequest, errInvalidResetToken
+	case errors.Is(err, ErrPasswordTooShort):
+		return http.StatusBadRequest, errPasswordTooShort
+	case errors.Is(err, ErrPasswordTooLong):
+		return http.StatusBadRequest, errPasswordTooLong
+	case errors.Is(err, ErrPasswordTooSimple):
+		return http.StatusBadRequest, errPasswordTooSimple
+	case errors.Is(err, ErrPasswordTooCommon):
+		return http.StatusBadRequest, errPasswordTooCommon
+	case errors.Is(err, ErrPasswordContainsAccount):
+		return http.StatusBadRequest, errPasswordContainsAccount
+	case errors.Is(err, ErrIncorrectPassword):
+		return http.StatusBadRequest, errIncorrectPassword
+	case errors.Is(err, ErrTOTPAlreadyEnabled):
+		return http.StatusConflict, errTOTPAlreadyEnabled
+	case errors.Is(err, ErrTOTPNotEnabled):
+		return http.StatusBadRequest, errTOTPNotEnabled
+	case errors.Is(err, ErrInvalidTwoFactorCode):
+		return http.StatusBadRequest, errInvalidTwoFactorCode
+	case errors.Is(err, ErrInvalidLoginChallenge):
+		return http.StatusUnauthorized, errInvalidLoginChallenge
+	case errors.Is(err, ErrTwoFactorRequired):
+		return http.StatusUnauthorized, errTwoFactorRequired
+	case errors.Is(err, ErrTooManyLoginAttempts):
+		return http.StatusTooManyRequests, errTooManyLoginAttempts
+	case errors.Is(err, ErrAccountLocked):
+		return http.StatusLocked, errAccountLocked
+	case errors.Is(err, ErrTooManyMailRequests):
+		return http.StatusTooManyRequests, errTooManyMailRequests
 	case errors.Is(err, ErrFailedToCheckUsername):
 		return http.StatusInternalServerError, errFailedToCheckUsername
 	case errors.Is(err, ErrFailedToCheckEmail):
@@ -78,6 +239,20 @@ func MapError(err error) (status int, message string) {
 		return http.StatusInternalServerError, errFailedToHashPassword
 	case errors.Is(err, ErrFailedToRegisterUser):
 		return http.StatusInternalServerError, errFailedToRegisterUser
+	case errors.Is(err, ErrInvalidRole):
+		return http.StatusBadRequest, errInvalidRole
+	case errors.Is(err, ErrCannotChangeOwnRole):
+		return http.StatusForbidden, errCannotChangeOwnRole
+	case errors.Is(err, ErrSessionNotFound):
+		return http.StatusNotFound, errSessionNotFound
+	case errors.Is(err, ErrInvalidDisplayName):
+		return http.StatusBadRequest, errInvalidDisplayName
+	case errors.Is(err, ErrInvalidBio):
+		return http.StatusBadRequest, errInvalidBio
+	case errors.Is(err, ErrInvalidAvatarURL):
+		return http.StatusBadRequest, errInvalidAvatarURL
+	case errors.Is(err, ErrInvalidSocialLinks):
+		return http.StatusBadRequest, errInvalidSocialLinks
 	default:
 		return http.StatusInternalServerError, "Internal server error"
 	}
@@ -35,6 +35,48 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockRepository) ChangePassword(ctx context.Context, userID int, passwordHash, keepFamilyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, passwordHash, keepFamilyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockRepositoryMockRecorder) ChangePassword(ctx, userID, passwordHash, keepFamilyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockRepository)(nil).ChangePassword), ctx, userID, passwordHash, keepFamilyID)
+}
+
+// ConfirmTOTP mocks base method.
+func (m *MockRepository) ConfirmTOTP(ctx context.Context, userID int, step int64, recoveryCodeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", ctx, userID, step, recoveryCodeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockRepositoryMockRecorder) ConfirmTOTP(ctx, userID, step, recoveryCodeHashes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*MockRepository)(nil).ConfirmTOTP), ctx, userID, step, recoveryCodeHashes)
+}
+
+// CreatePasswordResetToken mocks base method.
+func (m *MockRepository) CreatePasswordResetToken(ctx context.Context, token *models.PasswordResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
+func (mr *MockRepositoryMockRecorder) CreatePasswordResetToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockRepository)(nil).CreatePasswordResetToken), ctx, token)
+}
+
 // CreateRefreshToken mocks base method.
 func (m *MockRepository) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
 	m.ctrl.T.Helper()
@@ -49,6 +91,65 @@ func (mr *MockRepositoryMockRecorder) CreateRefreshToken(ctx, token interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockRepository)(nil).CreateRefreshToken), ctx, token)
 }
 
+// DeleteTOTP mocks base method.
+func (m *MockRepository) DeleteTOTP(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTOTP", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTOTP indicates an expected call of DeleteTOTP.
+func (mr *MockRepositoryMockRecorder) DeleteTOTP(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTOTP", reflect.TypeOf((*MockRepository)(nil).DeleteTOTP), ctx, userID)
+}
+
+// GetPasswordResetToken mocks base method.
+func (m *MockRepository) GetPasswordResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordResetToken", ctx, tokenHash)
+	ret0, _ := ret[0].(*models.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordResetToken indicates an expected call of GetPasswordResetToken.
+func (mr *MockRepositoryMockRecorder) GetPasswordResetToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetToken", reflect.TypeOf((*MockRepository)(nil).GetPasswordResetToken), ctx, tokenHash)
+}
+
+// GetProfile mocks base method.
+func (m *MockRepository) GetProfile(ctx context.Context, userID int) (*models.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", ctx, userID)
+	ret0, _ := ret[0].(*models.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockRepositoryMockRecorder) GetProfile(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockRepository)(nil).GetProfile), ctx, userID)
+}
+
+// GetRefreshTokenByID mocks base method.
+func (m *MockRepository) GetRefreshTokenByID(ctx context.Context, id int) (*models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshTokenByID", ctx, id)
+	ret0, _ := ret[0].(*models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshTokenByID indicates an expected call of GetRefreshTokenByID.
+func (mr *MockRepositoryMockRecorder) GetRefreshTokenByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshTokenByID", reflect.TypeOf((*MockRepository)(nil).GetRefreshTokenByID), ctx, id)
+}
+
 // GetRefreshTokenByToken mocks base method.
 func (m *MockRepository) GetRefreshTokenByToken(ctx context.Context, token string) (*models.RefreshToken, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +165,21 @@ func (mr *MockRepositoryMockRecorder) GetRefreshTokenByToken(ctx, token interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshTokenByToken", reflect.TypeOf((*MockRepository)(nil).GetRefreshTokenByToken), ctx, token)
 }
 
+// GetTOTP mocks base method.
+func (m *MockRepository) GetTOTP(ctx context.Context, userID int) (*models.UserTOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTOTP", ctx, userID)
+	ret0, _ := ret[0].(*models.UserTOTP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTOTP indicates an expected call of GetTOTP.
+func (mr *MockRepositoryMockRecorder) GetTOTP(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTOTP", reflect.TypeOf((*MockRepository)(nil).GetTOTP), ctx, userID)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +225,21 @@ func (mr *MockRepositoryMockRecorder) GetUserByUsername(ctx, username interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockRepository)(nil).GetUserByUsername), ctx, username)
 }
 
+// ListActiveRefreshTokens mocks base method.
+func (m *MockRepository) ListActiveRefreshTokens(ctx context.Context, userID int) ([]*models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveRefreshTokens", ctx, userID)
+	ret0, _ := ret[0].([]*models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveRefreshTokens indicates an expected call of ListActiveRefreshTokens.
+func (mr *MockRepositoryMockRecorder) ListActiveRefreshTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveRefreshTokens", reflect.TypeOf((*MockRepository)(nil).ListActiveRefreshTokens), ctx, userID)
+}
+
 // Login mocks base method.
 func (m *MockRepository) Login(ctx context.Context, user *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +255,20 @@ func (mr *MockRepositoryMockRecorder) Login(ctx, user interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockRepository)(nil).Login), ctx, user)
 }
 
+// MarkEmailVerified mocks base method.
+func (m *MockRepository) MarkEmailVerified(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerified", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEmailVerified indicates an expected call of MarkEmailVerified.
+func (mr *MockRepositoryMockRecorder) MarkEmailVerified(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerified", reflect.TypeOf((*MockRepository)(nil).MarkEmailVerified), ctx, userID)
+}
+
 // Register mocks base method.
 func (m *MockRepository) Register(ctx context.Context, user *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -139,6 +284,21 @@ func (mr *MockRepositoryMockRecorder) Register(ctx, user interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockRepository)(nil).Register), ctx, user)
 }
 
+// ResetPassword mocks base method.
+func (m *MockRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, tokenHash, passwordHash)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockRepositoryMockRecorder) ResetPassword(ctx, tokenHash, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockRepository)(nil).ResetPassword), ctx, tokenHash, passwordHash)
+}
+
 // RevokeRefreshToken mocks base method.
 func (m *MockRepository) RevokeRefreshToken(ctx context.Context, token string) error {
 	m.ctrl.T.Helper()
@@ -152,3 +312,117 @@ func (mr *MockRepositoryMockRecorder) RevokeRefreshToken(ctx, token interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshToken", reflect.TypeOf((*MockRepository)(nil).RevokeRefreshToken), ctx, token)
 }
+
+// RevokeRefreshTokenFamily mocks base method.
+func (m *MockRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshTokenFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshTokenFamily indicates an expected call of RevokeRefreshTokenFamily.
+func (mr *MockRepositoryMockRecorder) RevokeRefreshTokenFamily(ctx, familyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshTokenFamily", reflect.TypeOf((*MockRepository)(nil).RevokeRefreshTokenFamily), ctx, familyID)
+}
+
+// RevokeUserRefreshTokens mocks base method.
+func (m *MockRepository) RevokeUserRefreshTokens(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserRefreshTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserRefreshTokens indicates an expected call of RevokeUserRefreshTokens.
+func (mr *MockRepositoryMockRecorder) RevokeUserRefreshTokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserRefreshTokens", reflect.TypeOf((*MockRepository)(nil).RevokeUserRefreshTokens), ctx, userID)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockRepository) RotateRefreshToken(ctx context.Context, oldTokenID int, next *models.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", ctx, oldTokenID, next)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockRepositoryMockRecorder) RotateRefreshToken(ctx, oldTokenID, next interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockRepository)(nil).RotateRefreshToken), ctx, oldTokenID, next)
+}
+
+// SaveTOTP mocks base method.
+func (m *MockRepository) SaveTOTP(ctx context.Context, totp *models.UserTOTP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTOTP", ctx, totp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTOTP indicates an expected call of SaveTOTP.
+func (mr *MockRepositoryMockRecorder) SaveTOTP(ctx, totp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTOTP", reflect.TypeOf((*MockRepository)(nil).SaveTOTP), ctx, totp)
+}
+
+// UpdateRole mocks base method.
+func (m *MockRepository) UpdateRole(ctx context.Context, userID int, role models.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockRepositoryMockRecorder) UpdateRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockRepository)(nil).UpdateRole), ctx, userID, role)
+}
+
+// UpsertProfile mocks base method.
+func (m *MockRepository) UpsertProfile(ctx context.Context, profile *models.UserProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertProfile", ctx, profile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertProfile indicates an expected call of UpsertProfile.
+func (mr *MockRepositoryMockRecorder) UpsertProfile(ctx, profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertProfile", reflect.TypeOf((*MockRepository)(nil).UpsertProfile), ctx, profile)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockRepository) UseRecoveryCode(ctx context.Context, userID int, codeHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, codeHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockRepositoryMockRecorder) UseRecoveryCode(ctx, userID, codeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockRepository)(nil).UseRecoveryCode), ctx, userID, codeHash)
+}
+
+// UseTOTPStep mocks base method.
+func (m *MockRepository) UseTOTPStep(ctx context.Context, userID int, step int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", ctx, userID, step)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockRepositoryMockRecorder) UseTOTPStep(ctx, userID, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MockRepository)(nil).UseTOTPStep), ctx, userID, step)
+}
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/ductong169z/shorten-url/internal/models"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,79 @@ func (m *MockRedisRepository) EXPECT() *MockRedisRepositoryMockRecorder {
 	return m.recorder
 }
 
+// DeleteLoginChallenge mocks base method.
+func (m *MockRedisRepository) DeleteLoginChallenge(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoginChallenge", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoginChallenge indicates an expected call of DeleteLoginChallenge.
+func (mr *MockRedisRepositoryMockRecorder) DeleteLoginChallenge(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoginChallenge", reflect.TypeOf((*MockRedisRepository)(nil).DeleteLoginChallenge), ctx, token)
+}
+
+// DenyAccessToken mocks base method.
+func (m *MockRedisRepository) DenyAccessToken(ctx context.Context, jti string, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DenyAccessToken", ctx, jti, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DenyAccessToken indicates an expected call of DenyAccessToken.
+func (mr *MockRedisRepositoryMockRecorder) DenyAccessToken(ctx, jti, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DenyAccessToken", reflect.TypeOf((*MockRedisRepository)(nil).DenyAccessToken), ctx, jti, ttl)
+}
+
+// GetAccountLock mocks base method.
+func (m *MockRedisRepository) GetAccountLock(ctx context.Context, username string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountLock", ctx, username)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountLock indicates an expected call of GetAccountLock.
+func (mr *MockRedisRepositoryMockRecorder) GetAccountLock(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountLock", reflect.TypeOf((*MockRedisRepository)(nil).GetAccountLock), ctx, username)
+}
+
+// GetLoginBackoff mocks base method.
+func (m *MockRedisRepository) GetLoginBackoff(ctx context.Context, subject string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginBackoff", ctx, subject)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginBackoff indicates an expected call of GetLoginBackoff.
+func (mr *MockRedisRepositoryMockRecorder) GetLoginBackoff(ctx, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginBackoff", reflect.TypeOf((*MockRedisRepository)(nil).GetLoginBackoff), ctx, subject)
+}
+
+// GetLoginChallenge mocks base method.
+func (m *MockRedisRepository) GetLoginChallenge(ctx context.Context, token string) (*models.LoginChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginChallenge", ctx, token)
+	ret0, _ := ret[0].(*models.LoginChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginChallenge indicates an expected call of GetLoginChallenge.
+func (mr *MockRedisRepositoryMockRecorder) GetLoginChallenge(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginChallenge", reflect.TypeOf((*MockRedisRepository)(nil).GetLoginChallenge), ctx, token)
+}
+
 // GetUserByIDCtx mocks base method.
 func (m *MockRedisRepository) GetUserByIDCtx(ctx context.Context, key string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +124,122 @@ func (mr *MockRedisRepositoryMockRecorder) GetUserByIDCtx(ctx, key interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByIDCtx", reflect.TypeOf((*MockRedisRepository)(nil).GetUserByIDCtx), ctx, key)
 }
 
+// IncrLoginChallengeAttempts mocks base method.
+func (m *MockRedisRepository) IncrLoginChallengeAttempts(ctx context.Context, challenge *models.LoginChallenge) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrLoginChallengeAttempts", ctx, challenge)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrLoginChallengeAttempts indicates an expected call of IncrLoginChallengeAttempts.
+func (mr *MockRedisRepositoryMockRecorder) IncrLoginChallengeAttempts(ctx, challenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrLoginChallengeAttempts", reflect.TypeOf((*MockRedisRepository)(nil).IncrLoginChallengeAttempts), ctx, challenge)
+}
+
+// IncrLoginFailures mocks base method.
+func (m *MockRedisRepository) IncrLoginFailures(ctx context.Context, subject string, window time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrLoginFailures", ctx, subject, window)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrLoginFailures indicates an expected call of IncrLoginFailures.
+func (mr *MockRedisRepositoryMockRecorder) IncrLoginFailures(ctx, subject, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrLoginFailures", reflect.TypeOf((*MockRedisRepository)(nil).IncrLoginFailures), ctx, subject, window)
+}
+
+// IncrMailRequests mocks base method.
+func (m *MockRedisRepository) IncrMailRequests(ctx context.Context, subject string, window time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrMailRequests", ctx, subject, window)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrMailRequests indicates an expected call of IncrMailRequests.
+func (mr *MockRedisRepositoryMockRecorder) IncrMailRequests(ctx, subject, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrMailRequests", reflect.TypeOf((*MockRedisRepository)(nil).IncrMailRequests), ctx, subject, window)
+}
+
+// IsAccessTokenDenied mocks base method.
+func (m *MockRedisRepository) IsAccessTokenDenied(ctx context.Context, jti string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAccessTokenDenied", ctx, jti)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAccessTokenDenied indicates an expected call of IsAccessTokenDenied.
+func (mr *MockRedisRepositoryMockRecorder) IsAccessTokenDenied(ctx, jti interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAccessTokenDenied", reflect.TypeOf((*MockRedisRepository)(nil).IsAccessTokenDenied), ctx, jti)
+}
+
+// LockAccount mocks base method.
+func (m *MockRedisRepository) LockAccount(ctx context.Context, username string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAccount", ctx, username, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockAccount indicates an expected call of LockAccount.
+func (mr *MockRedisRepositoryMockRecorder) LockAccount(ctx, username, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAccount", reflect.TypeOf((*MockRedisRepository)(nil).LockAccount), ctx, username, until)
+}
+
+// ResetLoginFailures mocks base method.
+func (m *MockRedisRepository) ResetLoginFailures(ctx context.Context, subject string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetLoginFailures", ctx, subject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetLoginFailures indicates an expected call of ResetLoginFailures.
+func (mr *MockRedisRepositoryMockRecorder) ResetLoginFailures(ctx, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLoginFailures", reflect.TypeOf((*MockRedisRepository)(nil).ResetLoginFailures), ctx, subject)
+}
+
+// SetLoginBackoff mocks base method.
+func (m *MockRedisRepository) SetLoginBackoff(ctx context.Context, subject string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLoginBackoff", ctx, subject, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLoginBackoff indicates an expected call of SetLoginBackoff.
+func (mr *MockRedisRepositoryMockRecorder) SetLoginBackoff(ctx, subject, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLoginBackoff", reflect.TypeOf((*MockRedisRepository)(nil).SetLoginBackoff), ctx, subject, until)
+}
+
+// SetLoginChallenge mocks base method.
+func (m *MockRedisRepository) SetLoginChallenge(ctx context.Context, challenge *models.LoginChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLoginChallenge", ctx, challenge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLoginChallenge indicates an expected call of SetLoginChallenge.
+func (mr *MockRedisRepositoryMockRecorder) SetLoginChallenge(ctx, challenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLoginChallenge", reflect.TypeOf((*MockRedisRepository)(nil).SetLoginChallenge), ctx, challenge)
+}
+
 // SetUserByIDCtx mocks base method.
 func (m *MockRedisRepository) SetUserByIDCtx(ctx context.Context, key string, user *models.User) error {
 	m.ctrl.T.Helper()
@@ -63,3 +253,17 @@ func (mr *MockRedisRepositoryMockRecorder) SetUserByIDCtx(ctx, key, user interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserByIDCtx", reflect.TypeOf((*MockRedisRepository)(nil).SetUserByIDCtx), ctx, key, user)
 }
+
+// UnlockAccount mocks base method.
+func (m *MockRedisRepository) UnlockAccount(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockAccount", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockAccount indicates an expected call of UnlockAccount.
+func (mr *MockRedisRepositoryMockRecorder) UnlockAccount(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockAccount", reflect.TypeOf((*MockRedisRepository)(nil).UnlockAccount), ctx, username)
+}
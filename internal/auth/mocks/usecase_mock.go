@@ -36,10 +36,112 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockUseCase) ChangePassword(ctx context.Context, refreshToken, currentPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, refreshToken, currentPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockUseCaseMockRecorder) ChangePassword(ctx, refreshToken, currentPassword, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUseCase)(nil).ChangePassword), ctx, refreshToken, currentPassword, newPassword)
+}
+
+// ChangeRole mocks base method.
+func (m *MockUseCase) ChangeRole(ctx context.Context, userID int, role models.UserRole) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeRole", ctx, userID, role)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeRole indicates an expected call of ChangeRole.
+func (mr *MockUseCaseMockRecorder) ChangeRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeRole", reflect.TypeOf((*MockUseCase)(nil).ChangeRole), ctx, userID, role)
+}
+
+// CompleteLoginChallenge mocks base method.
+func (m *MockUseCase) CompleteLoginChallenge(ctx context.Context, token, code string, client *models.SessionClient) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteLoginChallenge", ctx, token, code, client)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteLoginChallenge indicates an expected call of CompleteLoginChallenge.
+func (mr *MockUseCaseMockRecorder) CompleteLoginChallenge(ctx, token, code, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteLoginChallenge", reflect.TypeOf((*MockUseCase)(nil).CompleteLoginChallenge), ctx, token, code, client)
+}
+
+// ConfirmTOTP mocks base method.
+func (m *MockUseCase) ConfirmTOTP(ctx context.Context, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", ctx, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockUseCaseMockRecorder) ConfirmTOTP(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*MockUseCase)(nil).ConfirmTOTP), ctx, code)
+}
+
+// DisableTOTP mocks base method.
+func (m *MockUseCase) DisableTOTP(ctx context.Context, currentPassword, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", ctx, currentPassword, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockUseCaseMockRecorder) DisableTOTP(ctx, currentPassword, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockUseCase)(nil).DisableTOTP), ctx, currentPassword, code)
+}
+
+// EnrollTOTP mocks base method.
+func (m *MockUseCase) EnrollTOTP(ctx context.Context) (*models.TOTPEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTOTP", ctx)
+	ret0, _ := ret[0].(*models.TOTPEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTOTP indicates an expected call of EnrollTOTP.
+func (mr *MockUseCaseMockRecorder) EnrollTOTP(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTOTP", reflect.TypeOf((*MockUseCase)(nil).EnrollTOTP), ctx)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockUseCase) ForgotPassword(ctx context.Context, email string, client *models.SessionClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", ctx, email, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockUseCaseMockRecorder) ForgotPassword(ctx, email, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockUseCase)(nil).ForgotPassword), ctx, email, client)
+}
+
 // GenerateRefreshToken mocks base method.
-func (m *MockUseCase) GenerateRefreshToken(ctx context.Context, userID int) (string, time.Time, error) {
+func (m *MockUseCase) GenerateRefreshToken(ctx context.Context, userID int, client *models.SessionClient) (string, time.Time, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateRefreshToken", ctx, userID)
+	ret := m.ctrl.Call(m, "GenerateRefreshToken", ctx, userID, client)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(time.Time)
 	ret2, _ := ret[2].(error)
@@ -47,9 +149,9 @@ func (m *MockUseCase) GenerateRefreshToken(ctx context.Context, userID int) (str
 }
 
 // GenerateRefreshToken indicates an expected call of GenerateRefreshToken.
-func (mr *MockUseCaseMockRecorder) GenerateRefreshToken(ctx, userID interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) GenerateRefreshToken(ctx, userID, client interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRefreshToken", reflect.TypeOf((*MockUseCase)(nil).GenerateRefreshToken), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRefreshToken", reflect.TypeOf((*MockUseCase)(nil).GenerateRefreshToken), ctx, userID, client)
 }
 
 // GetUserByID mocks base method.
@@ -67,19 +169,78 @@ func (mr *MockUseCaseMockRecorder) GetUserByID(ctx, userId interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUseCase)(nil).GetUserByID), ctx, userId)
 }
 
-// Login mocks base method.
-func (m *MockUseCase) Login(ctx context.Context, user *models.User) (*models.User, error) {
+// IsAccessTokenRevoked mocks base method.
+func (m *MockUseCase) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", ctx, user)
-	ret0, _ := ret[0].(*models.User)
+	ret := m.ctrl.Call(m, "IsAccessTokenRevoked", ctx, jti)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// IsAccessTokenRevoked indicates an expected call of IsAccessTokenRevoked.
+func (mr *MockUseCaseMockRecorder) IsAccessTokenRevoked(ctx, jti interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAccessTokenRevoked", reflect.TypeOf((*MockUseCase)(nil).IsAccessTokenRevoked), ctx, jti)
+}
+
+// ListSessions mocks base method.
+func (m *MockUseCase) ListSessions(ctx context.Context) ([]*models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx)
+	ret0, _ := ret[0].([]*models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockUseCaseMockRecorder) ListSessions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockUseCase)(nil).ListSessions), ctx)
+}
+
+// Login mocks base method.
+func (m *MockUseCase) Login(ctx context.Context, user *models.User, client *models.SessionClient) (*models.User, *models.LoginChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, user, client)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(*models.LoginChallenge)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // Login indicates an expected call of Login.
-func (mr *MockUseCaseMockRecorder) Login(ctx, user interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) Login(ctx, user, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUseCase)(nil).Login), ctx, user, client)
+}
+
+// Logout mocks base method.
+func (m *MockUseCase) Logout(ctx context.Context, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockUseCaseMockRecorder) Logout(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUseCase)(nil).Logout), ctx, refreshToken)
+}
+
+// LogoutAll mocks base method.
+func (m *MockUseCase) LogoutAll(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockUseCaseMockRecorder) LogoutAll(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUseCase)(nil).Login), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockUseCase)(nil).LogoutAll), ctx)
 }
 
 // Register mocks base method.
@@ -97,6 +258,34 @@ func (mr *MockUseCaseMockRecorder) Register(ctx, user interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUseCase)(nil).Register), ctx, user)
 }
 
+// ResendVerificationEmail mocks base method.
+func (m *MockUseCase) ResendVerificationEmail(ctx context.Context, email string, client *models.SessionClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerificationEmail", ctx, email, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerificationEmail indicates an expected call of ResendVerificationEmail.
+func (mr *MockUseCaseMockRecorder) ResendVerificationEmail(ctx, email, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerificationEmail", reflect.TypeOf((*MockUseCase)(nil).ResendVerificationEmail), ctx, email, client)
+}
+
+// ResetPassword mocks base method.
+func (m *MockUseCase) ResetPassword(ctx context.Context, token, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUseCaseMockRecorder) ResetPassword(ctx, token, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUseCase)(nil).ResetPassword), ctx, token, password)
+}
+
 // RevokeRefreshToken mocks base method.
 func (m *MockUseCase) RevokeRefreshToken(ctx context.Context, token string) error {
 	m.ctrl.T.Helper()
@@ -111,17 +300,74 @@ func (mr *MockUseCaseMockRecorder) RevokeRefreshToken(ctx, token interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshToken", reflect.TypeOf((*MockUseCase)(nil).RevokeRefreshToken), ctx, token)
 }
 
-// ValidateRefreshToken mocks base method.
-func (m *MockUseCase) ValidateRefreshToken(ctx context.Context, token string) (*models.RefreshToken, error) {
+// RevokeSession mocks base method.
+func (m *MockUseCase) RevokeSession(ctx context.Context, sessionID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockUseCaseMockRecorder) RevokeSession(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockUseCase)(nil).RevokeSession), ctx, sessionID)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockUseCase) RotateRefreshToken(ctx context.Context, token string, client *models.SessionClient) (*models.RefreshToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateRefreshToken", ctx, token)
+	ret := m.ctrl.Call(m, "RotateRefreshToken", ctx, token, client)
 	ret0, _ := ret[0].(*models.RefreshToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ValidateRefreshToken indicates an expected call of ValidateRefreshToken.
-func (mr *MockUseCaseMockRecorder) ValidateRefreshToken(ctx, token interface{}) *gomock.Call {
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockUseCaseMockRecorder) RotateRefreshToken(ctx, token, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockUseCase)(nil).RotateRefreshToken), ctx, token, client)
+}
+
+// UnlockAccount mocks base method.
+func (m *MockUseCase) UnlockAccount(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockAccount", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockAccount indicates an expected call of UnlockAccount.
+func (mr *MockUseCaseMockRecorder) UnlockAccount(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockAccount", reflect.TypeOf((*MockUseCase)(nil).UnlockAccount), ctx, userID)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockUseCase) UpdateProfile(ctx context.Context, update *models.UserProfileUpdate) (*models.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, update)
+	ret0, _ := ret[0].(*models.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockUseCaseMockRecorder) UpdateProfile(ctx, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUseCase)(nil).UpdateProfile), ctx, update)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockUseCase) VerifyEmail(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockUseCaseMockRecorder) VerifyEmail(ctx, token interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateRefreshToken", reflect.TypeOf((*MockUseCase)(nil).ValidateRefreshToken), ctx, token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUseCase)(nil).VerifyEmail), ctx, token)
 }
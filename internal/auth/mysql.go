@@ -14,7 +14,26 @@ type Repository interface {
 	GetUserByID(ctx context.Context, userId int) (*models.User, error)
 	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	MarkEmailVerified(ctx context.Context, userID int) error
+	UpdateRole(ctx context.Context, userID int, role models.UserRole) error
+	CreatePasswordResetToken(ctx context.Context, token *models.PasswordResetToken) error
+	GetPasswordResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error)
+	ResetPassword(ctx context.Context, tokenHash string, passwordHash string) (int, error)
+	ChangePassword(ctx context.Context, userID int, passwordHash string, keepFamilyID string) error
 	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
 	GetRefreshTokenByToken(ctx context.Context, token string) (*models.RefreshToken, error)
+	GetRefreshTokenByID(ctx context.Context, id int) (*models.RefreshToken, error)
+	ListActiveRefreshTokens(ctx context.Context, userID int) ([]*models.RefreshToken, error)
 	RevokeRefreshToken(ctx context.Context, token string) error
+	RotateRefreshToken(ctx context.Context, oldTokenID int, next *models.RefreshToken) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID int) error
+	GetTOTP(ctx context.Context, userID int) (*models.UserTOTP, error)
+	SaveTOTP(ctx context.Context, totp *models.UserTOTP) error
+	ConfirmTOTP(ctx context.Context, userID int, step int64, recoveryCodeHashes []string) error
+	UseTOTPStep(ctx context.Context, userID int, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID int, codeHash string) (bool, error)
+	DeleteTOTP(ctx context.Context, userID int) error
+	GetProfile(ctx context.Context, userID int) (*models.UserProfile, error)
+	UpsertProfile(ctx context.Context, profile *models.UserProfile) error
 }
@@ -3,10 +3,31 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"time"
 
 	"github.com/ductong169z/shorten-url/internal/auth"
 	"github.com/ductong169z/shorten-url/internal/models"
 	"github.com/ductong169z/shorten-url/pkg/cache/redis"
+
+	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
+)
+
+const (
+	// denylistPrefix keys the jti of access tokens revoked by a logout
+	denylistPrefix = "api-jwt-denylist:"
+	// challengePrefix keys the pending two-factor login challenges
+	challengePrefix = "api-login-challenge:"
+	// challengeAttemptsPrefix keys the number of codes tried against a login challenge
+	challengeAttemptsPrefix = "api-login-challenge-attempts:"
+	// loginFailuresPrefix keys the failed login counters per username or IP
+	loginFailuresPrefix = "api-login-failures:"
+	// loginBackoffPrefix keys the time until which a username or IP may not try to log in
+	loginBackoffPrefix = "api-login-backoff:"
+	// accountLockPrefix keys the time until which a locked account may not log in
+	accountLockPrefix = "api-account-lock:"
+	// mailRequestsPrefix keys the verification and password reset email counters per address or IP
+	mailRequestsPrefix = "api-mail-requests:"
 )
 
 // News redis repository
@@ -47,3 +68,151 @@ func (r *redisRepo) SetUserByIDCtx(ctx context.Context, key string, user *models
 	}
 	return nil // Return nil if no error occurred
 }
+
+// DenyAccessToken implements auth.RedisRepository.
+// The entry only needs to live until the token expires on its own.
+func (r *redisRepo) DenyAccessToken(ctx context.Context, jti string, ttl time.Duration) error {
+	return r.rdb.Set(ctx, denylistPrefix+jti, "1", ttl)
+}
+
+// SetLoginChallenge implements auth.RedisRepository.
+// The challenge is kept until it expires.
+func (r *redisRepo) SetLoginChallenge(ctx context.Context, challenge *models.LoginChallenge) error {
+	ttl := time.Until(challenge.ExpiresAt)
+	if ttl <= 0 {
+		return r.DeleteLoginChallenge(ctx, challenge.Token)
+	}
+	data, err := json.Marshal(challenge)
+	if err != nil {
+		return err
+	}
+	return r.rdb.Set(ctx, challengePrefix+challenge.Token, string(data), ttl)
+}
+
+// GetLoginChallenge implements auth.RedisRepository.
+func (r *redisRepo) GetLoginChallenge(ctx context.Context, token string) (*models.LoginChallenge, error) {
+	data, err := r.rdb.Get(ctx, challengePrefix+token)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, pkgErrors.NotFound
+		}
+		return nil, err
+	}
+
+	var challenge models.LoginChallenge
+	if err := json.Unmarshal([]byte(data), &challenge); err != nil {
+		return nil, err
+	}
+	challenge.Token = token
+	return &challenge, nil
+}
+
+// DeleteLoginChallenge implements auth.RedisRepository.
+// Keys are deleted one by one as they may live in different cluster slots.
+func (r *redisRepo) DeleteLoginChallenge(ctx context.Context, token string) error {
+	if err := r.rdb.Del(ctx, challengePrefix+token); err != nil {
+		return err
+	}
+	return r.rdb.Del(ctx, challengeAttemptsPrefix+token)
+}
+
+// IncrLoginChallengeAttempts implements auth.RedisRepository.
+// The counter is incremented atomically so that parallel codes are all counted, and expires
+// with the challenge.
+func (r *redisRepo) IncrLoginChallengeAttempts(ctx context.Context, challenge *models.LoginChallenge) (int, error) {
+	ttl := time.Until(challenge.ExpiresAt)
+	if ttl <= 0 {
+		return 0, pkgErrors.NotFound
+	}
+	count, err := r.rdb.Incr(ctx, challengeAttemptsPrefix+challenge.Token, ttl)
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// IncrLoginFailures implements auth.RedisRepository.
+// The counter is forgotten once no failure happened for the whole window.
+func (r *redisRepo) IncrLoginFailures(ctx context.Context, subject string, window time.Duration) (int, error) {
+	count, err := r.rdb.Incr(ctx, loginFailuresPrefix+subject, window)
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// ResetLoginFailures implements auth.RedisRepository.
+// Keys are deleted one by one as they may live in different cluster slots.
+func (r *redisRepo) ResetLoginFailures(ctx context.Context, subject string) error {
+	if err := r.rdb.Del(ctx, loginFailuresPrefix+subject); err != nil {
+		return err
+	}
+	return r.rdb.Del(ctx, loginBackoffPrefix+subject)
+}
+
+// SetLoginBackoff implements auth.RedisRepository.
+func (r *redisRepo) SetLoginBackoff(ctx context.Context, subject string, until time.Time) error {
+	return r.setUntil(ctx, loginBackoffPrefix+subject, until)
+}
+
+// GetLoginBackoff implements auth.RedisRepository.
+func (r *redisRepo) GetLoginBackoff(ctx context.Context, subject string) (time.Time, error) {
+	return r.getUntil(ctx, loginBackoffPrefix+subject)
+}
+
+// LockAccount implements auth.RedisRepository.
+func (r *redisRepo) LockAccount(ctx context.Context, username string, until time.Time) error {
+	return r.setUntil(ctx, accountLockPrefix+username, until)
+}
+
+// GetAccountLock implements auth.RedisRepository.
+func (r *redisRepo) GetAccountLock(ctx context.Context, username string) (time.Time, error) {
+	return r.getUntil(ctx, accountLockPrefix+username)
+}
+
+// UnlockAccount implements auth.RedisRepository.
+func (r *redisRepo) UnlockAccount(ctx context.Context, username string) error {
+	return r.rdb.Del(ctx, accountLockPrefix+username)
+}
+
+// IncrMailRequests implements auth.RedisRepository.
+// The counter is forgotten once no email was asked for during the whole window.
+func (r *redisRepo) IncrMailRequests(ctx context.Context, subject string, window time.Duration) (int, error) {
+	count, err := r.rdb.Incr(ctx, mailRequestsPrefix+subject, window)
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// setUntil stores until at key for as long as it lies in the future
+func (r *redisRepo) setUntil(ctx context.Context, key string, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return r.rdb.Del(ctx, key)
+	}
+	return r.rdb.Set(ctx, key, until.UTC().Format(time.RFC3339Nano), ttl)
+}
+
+// getUntil reads a time stored by setUntil, pkgErrors.NotFound when there is none
+func (r *redisRepo) getUntil(ctx context.Context, key string) (time.Time, error) {
+	data, err := r.rdb.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return time.Time{}, pkgErrors.NotFound
+		}
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339Nano, string(data))
+}
+
+// IsAccessTokenDenied implements auth.RedisRepository.
+func (r *redisRepo) IsAccessTokenDenied(ctx context.Context, jti string) (bool, error) {
+	if _, err := r.rdb.Get(ctx, denylistPrefix+jti); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -3,11 +3,13 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/ductong169z/shorten-url/internal/auth"
 	"github.com/ductong169z/shorten-url/internal/models"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
 )
@@ -27,6 +29,9 @@ func (r *repo) GetUserByID(ctx context.Context, userId int) (*models.User, error
 
 	var user models.User
 	if err := r.db.WithContext(ctx).Where("id = ?", userId).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, pkgErrors.NotFound
+		}
 		return nil, err
 	}
 	return &user, nil
@@ -80,6 +85,99 @@ func (r *repo) GetUserByEmail(ctx context.Context, email string) (*models.User,
 	return &user, nil
 }
 
+// MarkEmailVerified implements auth.Repository.
+func (r *repo) MarkEmailVerified(ctx context.Context, userID int) error {
+	return r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("email_verified", true).Error
+}
+
+// UpdateRole implements auth.Repository.
+func (r *repo) UpdateRole(ctx context.Context, userID int, role models.UserRole) error {
+	return r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("role", role).Error
+}
+
+// CreatePasswordResetToken implements auth.Repository.
+func (r *repo) CreatePasswordResetToken(ctx context.Context, token *models.PasswordResetToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+// GetPasswordResetToken implements auth.Repository.
+// Tokens that are used or expired are reported as invalid.
+func (r *repo) GetPasswordResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	if err := r.db.WithContext(ctx).
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, time.Now()).
+		First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, auth.ErrInvalidResetToken
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ResetPassword implements auth.Repository.
+// The token is spent, the password replaced and every refresh token of the user revoked
+// in one transaction, so a token works at most once even under concurrent requests.
+// It returns the ID of the user whose password was reset.
+func (r *repo) ResetPassword(ctx context.Context, tokenHash string, passwordHash string) (int, error) {
+	var userID int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		var token models.PasswordResetToken
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+			First(&token).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return auth.ErrInvalidResetToken
+			}
+			return err
+		}
+
+		// Older reset emails of the user stop working as well
+		if err := tx.Model(&models.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", token.UserID).
+			Update("used_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.User{}).
+			Where("id = ?", token.UserID).
+			Update("password", passwordHash).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.RefreshToken{}).
+			Where("user_id = ? AND revoked = ?", token.UserID, false).
+			Update("revoked", true).Error; err != nil {
+			return err
+		}
+
+		userID = token.UserID
+		return nil
+	})
+	return userID, err
+}
+
+// ChangePassword implements auth.Repository.
+// The password is replaced and every refresh token of the user outside keepFamilyID
+// revoked in one transaction.
+func (r *repo) ChangePassword(ctx context.Context, userID int, passwordHash string, keepFamilyID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).
+			Where("id = ?", userID).
+			Update("password", passwordHash).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.RefreshToken{}).
+			Where("user_id = ? AND family_id <> ? AND revoked = ?", userID, keepFamilyID, false).
+			Update("revoked", true).Error
+	})
+}
+
 // CreateRefreshToken implements auth.Repository.
 func (r *repo) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
 	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
@@ -100,6 +198,32 @@ func (r *repo) GetRefreshTokenByToken(ctx context.Context, token string) (*model
 	return &rt, nil
 }
 
+// GetRefreshTokenByID implements auth.Repository.
+func (r *repo) GetRefreshTokenByID(ctx context.Context, id int) (*models.RefreshToken, error) {
+	var rt models.RefreshToken
+	if err := r.db.WithContext(ctx).First(&rt, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, pkgErrors.NotFound
+		}
+		return nil, err
+	}
+	return &rt, nil
+}
+
+// ListActiveRefreshTokens implements auth.Repository.
+// Rotation revokes the previous token, so there is one active token per session.
+func (r *repo) ListActiveRefreshTokens(ctx context.Context, userID int) ([]*models.RefreshToken, error) {
+	var tokens []*models.RefreshToken
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked = ? AND expires_at > ?", userID, false, time.Now()).
+		Order("last_used_at DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
 // RevokeRefreshToken implements auth.Repository.
 func (r *repo) RevokeRefreshToken(ctx context.Context, token string) error {
 	if err := r.db.WithContext(ctx).Where("token = ?", token).Delete(&models.RefreshToken{}).Error; err != nil {
@@ -107,3 +231,146 @@ func (r *repo) RevokeRefreshToken(ctx context.Context, token string) error {
 	}
 	return nil
 }
+
+// RotateRefreshToken implements auth.Repository.
+// The old token is revoked and its successor stored in one transaction; an old
+// token that is already revoked, e.g. by a concurrent refresh, is reported as reused.
+func (r *repo) RotateRefreshToken(ctx context.Context, oldTokenID int, next *models.RefreshToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND revoked = ?", oldTokenID, false).
+			Update("revoked", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return auth.ErrRefreshTokenReused
+		}
+		return tx.Create(next).Error
+	})
+}
+
+// RevokeRefreshTokenFamily implements auth.Repository.
+func (r *repo) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	return r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked = ?", familyID, false).
+		Update("revoked", true).Error
+}
+
+// RevokeUserRefreshTokens implements auth.Repository.
+func (r *repo) RevokeUserRefreshTokens(ctx context.Context, userID int) error {
+	return r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked = ?", userID, false).
+		Update("revoked", true).Error
+}
+
+// GetTOTP implements auth.Repository.
+func (r *repo) GetTOTP(ctx context.Context, userID int) (*models.UserTOTP, error) {
+	var totp models.UserTOTP
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&totp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, pkgErrors.NotFound
+		}
+		return nil, err
+	}
+	return &totp, nil
+}
+
+// SaveTOTP implements auth.Repository.
+// A pending enrollment of the user is replaced by the new secret.
+func (r *repo) SaveTOTP(ctx context.Context, totp *models.UserTOTP) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"secret", "confirmed_at", "last_used_step", "updated_at"}),
+		}).
+		Create(totp).Error
+}
+
+// ConfirmTOTP implements auth.Repository.
+// Enabling two-factor authentication and replacing the recovery codes happen in one
+// transaction; an enrollment that is already confirmed is reported as enabled.
+func (r *repo) ConfirmTOTP(ctx context.Context, userID int, step int64, recoveryCodeHashes []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.UserTOTP{}).
+			Where("user_id = ? AND confirmed_at IS NULL", userID).
+			Updates(map[string]interface{}{"confirmed_at": time.Now(), "last_used_step": step})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return auth.ErrTOTPAlreadyEnabled
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+		codes := make([]*models.UserRecoveryCode, len(recoveryCodeHashes))
+		for i, hash := range recoveryCodeHashes {
+			codes[i] = &models.UserRecoveryCode{UserID: userID, CodeHash: hash}
+		}
+		return tx.Create(codes).Error
+	})
+}
+
+// UseTOTPStep implements auth.Repository.
+// It records step as used and reports false if it, or a later step, was used before.
+func (r *repo) UseTOTPStep(ctx context.Context, userID int, step int64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.UserTOTP{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// UseRecoveryCode implements auth.Repository.
+// It spends the code and reports false if the user has no unused code with that hash.
+func (r *repo) UseRecoveryCode(ctx context.Context, userID int, codeHash string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.UserRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Limit(1).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteTOTP implements auth.Repository.
+func (r *repo) DeleteTOTP(ctx context.Context, userID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.UserTOTP{}).Error
+	})
+}
+
+// GetProfile implements auth.Repository.
+// A user who never saved a profile gets an empty one.
+func (r *repo) GetProfile(ctx context.Context, userID int) (*models.UserProfile, error) {
+	var profile models.UserProfile
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&profile).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &models.UserProfile{UserID: userID}, nil
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// UpsertProfile implements auth.Repository.
+func (r *repo) UpsertProfile(ctx context.Context, profile *models.UserProfile) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"display_name", "bio", "avatar_url", "social_links", "updated_at"}),
+		}).
+		Create(profile).Error
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
+)
+
+// newMockDB opens gorm on a sqlmock connection
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func TestRepo_GetUserByID(t *testing.T) {
+	tcs := map[string]struct {
+		rows   *sqlmock.Rows
+		expErr error
+	}{
+		"found": {
+			rows: sqlmock.NewRows([]string{"id", "username", "role"}).AddRow(7, "jane", "editor"),
+		},
+		"not found": {
+			rows:   sqlmock.NewRows([]string{"id", "username", "role"}),
+			expErr: pkgErrors.NotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			mock.ExpectQuery("SELECT \\* FROM `users` WHERE id = \\?").WithArgs(7).WillReturnRows(tc.rows)
+
+			user, err := NewRepository(db).GetUserByID(context.Background(), 7)
+			require.NoError(t, mock.ExpectationsWereMet())
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 7, user.ID)
+			assert.Equal(t, "jane", user.Username)
+		})
+	}
+}
@@ -10,11 +10,44 @@ import (
 // Auth use case
 type UseCase interface {
 	Register(ctx context.Context, user *models.User) (*models.User, error)
-	Login(ctx context.Context, user *models.User) (*models.User, error)
+	Login(ctx context.Context, user *models.User, client *models.SessionClient) (*models.User, *models.LoginChallenge, error)
 	GetUserByID(ctx context.Context, userId int) (*models.User, error)
 
+	// Email verification methods
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerificationEmail(ctx context.Context, email string, client *models.SessionClient) error
+
+	// Password reset methods
+	ForgotPassword(ctx context.Context, email string, client *models.SessionClient) error
+	ResetPassword(ctx context.Context, token string, password string) error
+	ChangePassword(ctx context.Context, refreshToken string, currentPassword string, newPassword string) error
+
 	// Refresh token methods
-	GenerateRefreshToken(ctx context.Context, userID int) (string, time.Time, error)
-	ValidateRefreshToken(ctx context.Context, token string) (*models.RefreshToken, error)
+	GenerateRefreshToken(ctx context.Context, userID int, client *models.SessionClient) (string, time.Time, error)
+	RotateRefreshToken(ctx context.Context, token string, client *models.SessionClient) (*models.RefreshToken, error)
 	RevokeRefreshToken(ctx context.Context, token string) error
+
+	// Logout methods
+	Logout(ctx context.Context, refreshToken string) error
+	LogoutAll(ctx context.Context) error
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+
+	// Session methods
+	ListSessions(ctx context.Context) ([]*models.RefreshToken, error)
+	RevokeSession(ctx context.Context, sessionID int) error
+
+	// Two-factor authentication methods
+	EnrollTOTP(ctx context.Context) (*models.TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, currentPassword string, code string) error
+	CompleteLoginChallenge(ctx context.Context, token string, code string, client *models.SessionClient) (*models.User, error)
+
+	// Account lockout methods
+	UnlockAccount(ctx context.Context, userID int) error
+
+	// Role management methods
+	ChangeRole(ctx context.Context, userID int, role models.UserRole) (*models.User, error)
+
+	// Profile methods
+	UpdateProfile(ctx context.Context, update *models.UserProfileUpdate) (*models.UserProfile, error)
 }
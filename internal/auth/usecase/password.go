@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	_ "embed"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/auth"
+	"github.com/ductong169z/shorten-url/internal/models"
+)
+
+const (
+	defaultPasswordMinLength  = 8
+	defaultPasswordMinClasses = 2
+	// bcrypt ignores everything after the first 72 bytes
+	maxPasswordBytes = 72
+	// Shorter usernames and email names are too likely to appear by chance
+	minPersonalInfoLength = 3
+)
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+// commonPasswords holds the lower-cased entries of common_passwords.txt
+var commonPasswords = parseCommonPasswords(commonPasswordList)
+
+func parseCommonPasswords(list string) map[string]struct{} {
+	passwords := make(map[string]struct{})
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords[strings.ToLower(line)] = struct{}{}
+	}
+	return passwords
+}
+
+// validatePassword checks password against the configured policy for the account of user
+func validatePassword(cfg *config.AuthConfig, password string, user *models.User) error {
+	minLength := cfg.PasswordMinLength
+	if minLength <= 0 {
+		minLength = defaultPasswordMinLength
+	}
+	minClasses := cfg.PasswordMinClasses
+	if minClasses <= 0 {
+		minClasses = defaultPasswordMinClasses
+	}
+
+	if utf8.RuneCountInString(password) < minLength {
+		return auth.ErrPasswordTooShort
+	}
+	if len(password) > maxPasswordBytes {
+		return auth.ErrPasswordTooLong
+	}
+	if characterClasses(password) < minClasses {
+		return auth.ErrPasswordTooSimple
+	}
+
+	lower := strings.ToLower(password)
+	if _, ok := commonPasswords[lower]; ok {
+		return auth.ErrPasswordTooCommon
+	}
+	emailName, _, _ := strings.Cut(user.Email, "@")
+	for _, info := range []string{user.Username, user.Email, emailName} {
+		info = strings.ToLower(info)
+		if utf8.RuneCountInString(info) >= minPersonalInfoLength && strings.Contains(lower, info) {
+			return auth.ErrPasswordContainsAccount
+		}
+	}
+	return nil
+}
+
+// characterClasses counts which of lower case letters, upper case letters, digits and
+// other characters appear in s
+func characterClasses(s string) int {
+	var lower, upper, digit, other bool
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	classes := 0
+	for _, present := range []bool{lower, upper, digit, other} {
+		if present {
+			classes++
+		}
+	}
+	return classes
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/auth"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePassword(t *testing.T) {
+	user := &models.User{Username: "jane", Email: "jane.doe@example.com"}
+
+	tcs := map[string]struct {
+		cfg      config.AuthConfig
+		password string
+		expErr   error
+	}{
+		"strong password": {
+			password: "correct Horse 9",
+		},
+		"too short": {
+			password: "aB3$",
+			expErr:   auth.ErrPasswordTooShort,
+		},
+		"configured min length": {
+			cfg:      config.AuthConfig{PasswordMinLength: 16},
+			password: "correct Horse 9",
+			expErr:   auth.ErrPasswordTooShort,
+		},
+		"too long for bcrypt": {
+			password: strings.Repeat("aB3$", 19),
+			expErr:   auth.ErrPasswordTooLong,
+		},
+		"single character class": {
+			password: "correcthorsebattery",
+			expErr:   auth.ErrPasswordTooSimple,
+		},
+		"configured character classes": {
+			cfg:      config.AuthConfig{PasswordMinClasses: 4},
+			password: "correct Horse battery",
+			expErr:   auth.ErrPasswordTooSimple,
+		},
+		"common password in another case": {
+			password: "Password123",
+			expErr:   auth.ErrPasswordTooCommon,
+		},
+		"contains the username": {
+			password: "my name is JANE 1",
+			expErr:   auth.ErrPasswordContainsAccount,
+		},
+		"contains the email name": {
+			password: "Jane.Doe-2024",
+			expErr:   auth.ErrPasswordContainsAccount,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			err := validatePassword(&tc.cfg, tc.password, user)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
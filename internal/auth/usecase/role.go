@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ductong169z/shorten-url/internal/auth"
+	"github.com/ductong169z/shorten-url/internal/models"
+	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+// ChangeRole implements auth.UseCase.
+// Users cannot change their own role, so the last admin cannot demote themselves by
+// accident. Access tokens carry the role, so the change applies from the next refresh.
+func (u *usecase) ChangeRole(ctx context.Context, userID int, role models.UserRole) (*models.User, error) {
+	actor, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !role.IsValid() {
+		return nil, auth.ErrInvalidRole
+	}
+	if actor.ID == userID {
+		return nil, auth.ErrCannotChangeOwnRole
+	}
+
+	user, err := u.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pkgErrors.NotFound) {
+			return nil, auth.ErrUserNotFound
+		}
+		return nil, err
+	}
+	if user.Role == role {
+		return user, nil
+	}
+
+	if err := u.repo.UpdateRole(ctx, user.ID, role); err != nil {
+		return nil, err
+	}
+	u.logger.Infof(ctx, "ChangeRole, userID: %v, role: %v -> %v, by userID: %v", user.ID, user.Role, role, actor.ID)
+
+	user.Role = role
+	cacheKey := fmt.Sprintf("%s%d", basePrefix, user.ID)
+	if err := u.redisRepo.SetUserByIDCtx(ctx, cacheKey, user); err != nil {
+		u.logger.Errorf(ctx, "Failed to set user %d in cache (key: %s): %v", user.ID, cacheKey, err)
+	}
+	return user, nil
+}
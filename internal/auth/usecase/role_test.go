@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/auth"
+	mock "github.com/ductong169z/shorten-url/internal/auth/mock"
+	"github.com/ductong169z/shorten-url/internal/auth/repository"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
+)
+
+func TestUseCase_ChangeRole(t *testing.T) {
+	admin := &models.User{ID: 1, Role: models.RoleAdmin}
+
+	tcs := map[string]struct {
+		userID    int
+		role      models.UserRole
+		current   models.UserRole
+		getErr    error
+		expGet    bool
+		expUpdate bool
+		expErr    error
+	}{
+		"promote a reader": {
+			userID:    7,
+			role:      models.RoleEditor,
+			current:   models.RoleReader,
+			expGet:    true,
+			expUpdate: true,
+		},
+		"same role": {
+			userID:  7,
+			role:    models.RoleEditor,
+			current: models.RoleEditor,
+			expGet:  true,
+		},
+		"unknown role": {
+			userID: 7,
+			role:   "superuser",
+			expErr: auth.ErrInvalidRole,
+		},
+		"own role": {
+			userID: admin.ID,
+			role:   models.RoleReader,
+			expErr: auth.ErrCannotChangeOwnRole,
+		},
+		"unknown user": {
+			userID: 7,
+			role:   models.RoleEditor,
+			getErr: pkgErrors.NotFound,
+			expGet: true,
+			expErr: auth.ErrUserNotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			redisRepo := mock.NewMockRedisRepository(ctrl)
+			uc := NewUseCase(cfg, repo, redisRepo, nil, apiLogger)
+
+			if tc.expGet {
+				if tc.getErr != nil {
+					repo.EXPECT().GetUserByID(gomock.Any(), tc.userID).Return(nil, tc.getErr)
+				} else {
+					repo.EXPECT().GetUserByID(gomock.Any(), tc.userID).Return(&models.User{ID: tc.userID, Role: tc.current}, nil)
+				}
+			}
+			if tc.expUpdate {
+				repo.EXPECT().UpdateRole(gomock.Any(), tc.userID, tc.role).Return(nil)
+				redisRepo.EXPECT().SetUserByIDCtx(gomock.Any(), "api-user:7", &models.User{ID: tc.userID, Role: tc.role}).Return(nil)
+			}
+
+			user, err := uc.ChangeRole(withUser(admin), tc.userID, tc.role)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.role, user.Role)
+		})
+	}
+}
+
+// newRepoWithoutUsers returns the MySQL repository on a database where the user lookup finds nothing
+func newRepoWithoutUsers(t *testing.T) auth.Repository {
+	t.Helper()
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		conn.Close()
+	})
+	mock.ExpectQuery("SELECT \\* FROM `users`").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{})
+	require.NoError(t, err)
+	return repository.NewRepository(db)
+}
+
+func TestUseCase_ChangeRoleUnknownUser(t *testing.T) {
+	cfg := &config.Config{}
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+
+	uc := NewUseCase(cfg, newRepoWithoutUsers(t), nil, nil, apiLogger)
+
+	_, err := uc.ChangeRole(withUser(&models.User{ID: 1, Role: models.RoleAdmin}), 7, models.RoleEditor)
+	assert.ErrorIs(t, err, auth.ErrUserNotFound)
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/auth"
+	"github.com/ductong169z/shorten-url/internal/models"
+
+	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
+)
+
+const (
+	defaultLoginFailureWindow    = time.Hour
+	defaultLoginFreeAttempts     = 3
+	defaultLoginIPFreeAttempts   = 20
+	defaultLoginBackoffMax       = 15 * time.Minute
+	defaultLoginLockoutThreshold = 10
+	defaultLoginLockoutDuration  = 30 * time.Minute
+	defaultMailRequestWindow     = time.Hour
+	defaultMailRequestsPerEmail  = 3
+	defaultMailRequestsPerIP     = 20
+
+	// loginBackoffBase is the wait after the first failure past the free attempts, doubled on every further one
+	loginBackoffBase = time.Second
+
+	usernameSubjectPrefix = "username:"
+	ipSubjectPrefix       = "ip:"
+	emailSubjectPrefix    = "email:"
+
+	verificationMailKind  = "verify:"
+	passwordResetMailKind = "reset:"
+)
+
+// UnlockAccount implements auth.UseCase.
+// It lifts the lockout of the user and forgets their failed logins.
+func (u *usecase) UnlockAccount(ctx context.Context, userID int) error {
+	user, err := u.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pkgErrors.NotFound) {
+			return auth.ErrUserNotFound
+		}
+		return err
+	}
+
+	username := normalizeUsername(user.Username)
+	if err := u.redisRepo.UnlockAccount(ctx, username); err != nil {
+		return err
+	}
+	return u.redisRepo.ResetLoginFailures(ctx, usernameSubject(username))
+}
+
+// checkLoginAllowed returns a *auth.LoginBlockedError while the account is locked or the
+// username or IP has to back off. Redis failures are logged and let the login through.
+func (u *usecase) checkLoginAllowed(ctx context.Context, username string, client *models.SessionClient) error {
+	now := time.Now()
+
+	until, err := u.redisRepo.GetAccountLock(ctx, normalizeUsername(username))
+	switch {
+	case err == nil && until.After(now):
+		return &auth.LoginBlockedError{Err: auth.ErrAccountLocked, Until: until}
+	case err != nil && !errors.Is(err, pkgErrors.NotFound):
+		u.logger.Errorf(ctx, "checkLoginAllowed.GetAccountLock, username: %s, err: %v", username, err)
+	}
+
+	var blockedUntil time.Time
+	for _, subject := range loginSubjects(username, client) {
+		until, err := u.redisRepo.GetLoginBackoff(ctx, subject)
+		if err != nil {
+			if !errors.Is(err, pkgErrors.NotFound) {
+				u.logger.Errorf(ctx, "checkLoginAllowed.GetLoginBackoff, subject: %s, err: %v", subject, err)
+			}
+			continue
+		}
+		if until.After(blockedUntil) {
+			blockedUntil = until
+		}
+	}
+	if blockedUntil.After(now) {
+		return &auth.LoginBlockedError{Err: auth.ErrTooManyLoginAttempts, Until: blockedUntil}
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed login against the username and IP. Past the free
+// attempts every failure doubles the backoff, and enough failures lock the account.
+// Unknown usernames are counted as well so that the responses do not reveal which exist.
+func (u *usecase) recordLoginFailure(ctx context.Context, username string, client *models.SessionClient) {
+	cfg := &u.cfg.Auth
+	window := durationOr(cfg.LoginFailureWindow, defaultLoginFailureWindow)
+	now := time.Now()
+
+	for _, subject := range loginSubjects(username, client) {
+		failures, err := u.redisRepo.IncrLoginFailures(ctx, subject, window)
+		if err != nil {
+			u.logger.Errorf(ctx, "recordLoginFailure.IncrLoginFailures, subject: %s, err: %v", subject, err)
+			continue
+		}
+
+		freeAttempts := intOr(cfg.LoginFreeAttempts, defaultLoginFreeAttempts)
+		if strings.HasPrefix(subject, ipSubjectPrefix) {
+			// Many users may share an address behind a NAT
+			freeAttempts = intOr(cfg.LoginIPFreeAttempts, defaultLoginIPFreeAttempts)
+		}
+		if failures > freeAttempts {
+			backoff := loginBackoff(failures-freeAttempts, durationOr(cfg.LoginBackoffMax, defaultLoginBackoffMax))
+			if err := u.redisRepo.SetLoginBackoff(ctx, subject, now.Add(backoff)); err != nil {
+				u.logger.Errorf(ctx, "recordLoginFailure.SetLoginBackoff, subject: %s, err: %v", subject, err)
+			}
+		}
+
+		if strings.HasPrefix(subject, usernameSubjectPrefix) && failures >= intOr(cfg.LoginLockoutThreshold, defaultLoginLockoutThreshold) {
+			u.lockAccount(ctx, normalizeUsername(username), now.Add(durationOr(cfg.LoginLockoutDuration, defaultLoginLockoutDuration)))
+		}
+	}
+}
+
+// lockAccount locks the account and starts counting its failures from zero again
+func (u *usecase) lockAccount(ctx context.Context, username string, until time.Time) {
+	if err := u.redisRepo.LockAccount(ctx, username, until); err != nil {
+		u.logger.Errorf(ctx, "lockAccount.LockAccount, username: %s, err: %v", username, err)
+		return
+	}
+	u.logger.Warnf(ctx, "Account %s locked until %s after repeated failed logins", username, until.Format(time.RFC3339))
+
+	if err := u.redisRepo.ResetLoginFailures(ctx, usernameSubject(username)); err != nil {
+		u.logger.Errorf(ctx, "lockAccount.ResetLoginFailures, username: %s, err: %v", username, err)
+	}
+}
+
+// resetLoginFailures forgets the failed logins of a username after it logged in.
+// The IP keeps its count so one valid account cannot clear it for guesses at others.
+func (u *usecase) resetLoginFailures(ctx context.Context, username string) {
+	if err := u.redisRepo.ResetLoginFailures(ctx, usernameSubject(normalizeUsername(username))); err != nil {
+		u.logger.Errorf(ctx, "resetLoginFailures.ResetLoginFailures, username: %s, err: %v", username, err)
+	}
+}
+
+// checkMailAllowed counts a request for a verification or password reset email against the
+// address and the IP and fails once either asked for too many. Every request is counted,
+// whether the address has an account or not, so the limit reveals nothing about it.
+func (u *usecase) checkMailAllowed(ctx context.Context, kind, email string, client *models.SessionClient) error {
+	cfg := &u.cfg.Auth
+	window := durationOr(cfg.MailRequestWindow, defaultMailRequestWindow)
+
+	subjects := map[string]int{
+		kind + emailSubjectPrefix + strings.ToLower(strings.TrimSpace(email)): intOr(cfg.MailRequestsPerEmail, defaultMailRequestsPerEmail),
+	}
+	if client != nil && client.IP != "" {
+		subjects[kind+ipSubjectPrefix+client.IP] = intOr(cfg.MailRequestsPerIP, defaultMailRequestsPerIP)
+	}
+
+	allowed := true
+	for subject, limit := range subjects {
+		requests, err := u.redisRepo.IncrMailRequests(ctx, subject, window)
+		if err != nil {
+			u.logger.Errorf(ctx, "checkMailAllowed.IncrMailRequests, subject: %s, err: %v", subject, err)
+			continue
+		}
+		if requests > limit {
+			allowed = false
+		}
+	}
+	if !allowed {
+		return auth.ErrTooManyMailRequests
+	}
+	return nil
+}
+
+// loginBackoff returns the wait after the given number of failures past the free attempts
+func loginBackoff(excess int, max time.Duration) time.Duration {
+	backoff := loginBackoffBase
+	for i := 1; i < excess && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		return max
+	}
+	return backoff
+}
+
+// loginSubjects returns the counters a login attempt is throttled by
+func loginSubjects(username string, client *models.SessionClient) []string {
+	subjects := []string{usernameSubject(normalizeUsername(username))}
+	if client != nil && client.IP != "" {
+		subjects = append(subjects, ipSubjectPrefix+client.IP)
+	}
+	return subjects
+}
+
+func usernameSubject(username string) string {
+	return usernameSubjectPrefix + username
+}
+
+// normalizeUsername folds the case like the username column collation does
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+func intOr(value, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// durationOr reads a setting given in seconds
+func durationOr(seconds int, fallback time.Duration) time.Duration {
+	if seconds <= 0 {
+		return fallback
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/auth"
+	mock "github.com/ductong169z/shorten-url/internal/auth/mock"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
+)
+
+// expectLoginAllowed expects the throttling checks of a login without client IP to pass
+func expectLoginAllowed(redisRepo *mock.MockRedisRepository, username string) {
+	redisRepo.EXPECT().GetAccountLock(gomock.Any(), username).Return(time.Time{}, pkgErrors.NotFound)
+	redisRepo.EXPECT().GetLoginBackoff(gomock.Any(), usernameSubjectPrefix+username).Return(time.Time{}, pkgErrors.NotFound)
+}
+
+func TestUseCase_LoginThrottling(t *testing.T) {
+	const ipSubject = "ip:203.0.113.9"
+
+	tcs := map[string]struct {
+		lockedUntil    time.Time
+		ipBackoffUntil time.Time
+		redisErr       error
+		loginOK        bool
+		userFailures   int
+		ipFailures     int
+		expUserBackoff time.Duration
+		expLock        bool
+		expErr         error
+		expRetryAfter  int
+	}{
+		"locked account": {
+			lockedUntil:   time.Now().Add(10 * time.Minute),
+			expErr:        auth.ErrAccountLocked,
+			expRetryAfter: 600,
+		},
+		"IP backing off": {
+			ipBackoffUntil: time.Now().Add(30 * time.Second),
+			expErr:         auth.ErrTooManyLoginAttempts,
+			expRetryAfter:  30,
+		},
+		"failure within the free attempts": {
+			userFailures: 3,
+			ipFailures:   3,
+			expErr:       auth.ErrInvalidCredentials,
+		},
+		"failure past the free attempts backs off": {
+			userFailures:   6,
+			ipFailures:     6,
+			expUserBackoff: 4 * time.Second,
+			expErr:         auth.ErrInvalidCredentials,
+		},
+		"failure reaching the threshold locks the account": {
+			userFailures:   10,
+			ipFailures:     10,
+			expUserBackoff: 64 * time.Second,
+			expLock:        true,
+			expErr:         auth.ErrInvalidCredentials,
+		},
+		"success resets the username": {
+			loginOK: true,
+		},
+		"redis errors let the login through": {
+			redisErr: errors.New("connection refused"),
+			loginOK:  true,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			redisRepo := mock.NewMockRedisRepository(ctrl)
+			uc := NewUseCase(cfg, repo, redisRepo, nil, apiLogger)
+			client := &models.SessionClient{IP: "203.0.113.9"}
+
+			lockErr, backoffErr := pkgErrors.NotFound, pkgErrors.NotFound
+			if tc.redisErr != nil {
+				lockErr, backoffErr = tc.redisErr, tc.redisErr
+			}
+			if !tc.lockedUntil.IsZero() {
+				lockErr = nil
+			}
+			redisRepo.EXPECT().GetAccountLock(gomock.Any(), "jane").Return(tc.lockedUntil, lockErr)
+			if tc.lockedUntil.IsZero() {
+				redisRepo.EXPECT().GetLoginBackoff(gomock.Any(), "username:jane").Return(time.Time{}, backoffErr)
+				ipBackoffErr := backoffErr
+				if !tc.ipBackoffUntil.IsZero() {
+					ipBackoffErr = nil
+				}
+				redisRepo.EXPECT().GetLoginBackoff(gomock.Any(), ipSubject).Return(tc.ipBackoffUntil, ipBackoffErr)
+			}
+
+			blocked := !tc.lockedUntil.IsZero() || !tc.ipBackoffUntil.IsZero()
+			switch {
+			case blocked:
+			case tc.loginOK:
+				repo.EXPECT().Login(gomock.Any(), gomock.Any()).Return(&models.User{ID: 7}, nil)
+				redisRepo.EXPECT().ResetLoginFailures(gomock.Any(), "username:jane").Return(tc.redisErr)
+				repo.EXPECT().GetTOTP(gomock.Any(), 7).Return(nil, pkgErrors.NotFound)
+			default:
+				repo.EXPECT().Login(gomock.Any(), gomock.Any()).Return(nil, errors.New("invalid credentials"))
+				redisRepo.EXPECT().IncrLoginFailures(gomock.Any(), "username:jane", defaultLoginFailureWindow).Return(tc.userFailures, nil)
+				redisRepo.EXPECT().IncrLoginFailures(gomock.Any(), ipSubject, defaultLoginFailureWindow).Return(tc.ipFailures, nil)
+				if tc.expUserBackoff > 0 {
+					redisRepo.EXPECT().SetLoginBackoff(gomock.Any(), "username:jane", gomock.Any()).DoAndReturn(
+						func(_ context.Context, _ string, until time.Time) error {
+							assert.WithinDuration(t, time.Now().Add(tc.expUserBackoff), until, time.Second)
+							return nil
+						},
+					)
+				}
+				if tc.expLock {
+					redisRepo.EXPECT().LockAccount(gomock.Any(), "jane", gomock.Any()).DoAndReturn(
+						func(_ context.Context, _ string, until time.Time) error {
+							assert.WithinDuration(t, time.Now().Add(defaultLoginLockoutDuration), until, time.Second)
+							return nil
+						},
+					)
+					redisRepo.EXPECT().ResetLoginFailures(gomock.Any(), "username:jane").Return(nil)
+				}
+			}
+
+			user, _, err := uc.Login(context.Background(), &models.User{Username: "Jane", Password: "password"}, client)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				if tc.expRetryAfter > 0 {
+					var blockedErr *auth.LoginBlockedError
+					require.ErrorAs(t, err, &blockedErr)
+					assert.Equal(t, tc.expRetryAfter, blockedErr.RetryAfter())
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 7, user.ID)
+		})
+	}
+}
+
+func TestLoginBackoff(t *testing.T) {
+	tcs := map[int]time.Duration{
+		1:  time.Second,
+		2:  2 * time.Second,
+		5:  16 * time.Second,
+		10: 512 * time.Second,
+		11: 15 * time.Minute,
+		64: 15 * time.Minute,
+	}
+
+	for excess, exp := range tcs {
+		assert.Equal(t, exp, loginBackoff(excess, 15*time.Minute), "excess %d", excess)
+	}
+}
+
+func TestUseCase_UnlockAccount(t *testing.T) {
+	tcs := map[string]struct {
+		getErr error
+		expErr error
+	}{
+		"locked user": {},
+		"unknown user": {
+			getErr: pkgErrors.NotFound,
+			expErr: auth.ErrUserNotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			redisRepo := mock.NewMockRedisRepository(ctrl)
+			uc := NewUseCase(cfg, repo, redisRepo, nil, apiLogger)
+
+			if tc.getErr != nil {
+				repo.EXPECT().GetUserByID(gomock.Any(), 7).Return(nil, tc.getErr)
+			} else {
+				repo.EXPECT().GetUserByID(gomock.Any(), 7).Return(&models.User{ID: 7, Username: "Jane"}, nil)
+				redisRepo.EXPECT().UnlockAccount(gomock.Any(), "jane").Return(nil)
+				redisRepo.EXPECT().ResetLoginFailures(gomock.Any(), "username:jane").Return(nil)
+			}
+
+			err := uc.UnlockAccount(context.Background(), 7)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUseCase_UnlockAccountUnknownUser(t *testing.T) {
+	cfg := &config.Config{}
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+
+	uc := NewUseCase(cfg, newRepoWithoutUsers(t), nil, nil, apiLogger)
+
+	err := uc.UnlockAccount(context.Background(), 7)
+	assert.ErrorIs(t, err, auth.ErrUserNotFound)
+}
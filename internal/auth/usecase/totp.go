@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/auth"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/totp"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"golang.org/x/crypto/bcrypt"
+
+	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
+)
+
+const (
+	defaultTOTPIssuer = "Blog"
+	// Codes of the previous and next period are accepted for clock drift
+	totpSkew          = 1
+	recoveryCodeCount = 10
+
+	loginChallengeDuration    = 5 * time.Minute
+	maxLoginChallengeAttempts = 5
+)
+
+// EnrollTOTP implements auth.UseCase.
+// It starts enrollment with a new secret, replacing a pending one; two-factor
+// authentication is enabled by ConfirmTOTP.
+func (u *usecase) EnrollTOTP(ctx context.Context) (*models.TOTPEnrollment, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userTOTP, err := u.repo.GetTOTP(ctx, user.ID)
+	if err != nil && !errors.Is(err, pkgErrors.NotFound) {
+		return nil, err
+	}
+	if userTOTP.Enabled() {
+		return nil, auth.ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := u.sealTOTPSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.SaveTOTP(ctx, &models.UserTOTP{UserID: user.ID, Secret: sealed}); err != nil {
+		return nil, err
+	}
+
+	issuer := u.cfg.Auth.TOTPIssuer
+	if issuer == "" {
+		issuer = defaultTOTPIssuer
+	}
+	return &models.TOTPEnrollment{
+		Secret: secret,
+		URI:    totp.URI(issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTOTP implements auth.UseCase.
+// A code from the authenticator app proves it was set up; two-factor authentication is
+// then enabled and new recovery codes are returned. They are only stored hashed, so this
+// is the one time they can be shown.
+func (u *usecase) ConfirmTOTP(ctx context.Context, code string) ([]string, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userTOTP, err := u.repo.GetTOTP(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, pkgErrors.NotFound) {
+			return nil, auth.ErrTOTPNotEnabled
+		}
+		return nil, err
+	}
+	if userTOTP.Enabled() {
+		return nil, auth.ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := u.openTOTPSecret(userTOTP)
+	if err != nil {
+		return nil, err
+	}
+	step, ok := totp.Validate(secret, code, time.Now(), totpSkew)
+	if !ok {
+		return nil, auth.ErrInvalidTwoFactorCode
+	}
+
+	codes, err := totp.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = hashRecoveryCode(code)
+	}
+	if err := u.repo.ConfirmTOTP(ctx, user.ID, step, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTOTP implements auth.UseCase.
+// It takes the current password and a TOTP or recovery code, so a user who lost their
+// authenticator can turn it off but a stolen access token alone cannot.
+func (u *usecase) DisableTOTP(ctx context.Context, currentPassword string, code string) error {
+	ctxUser, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The user in ctx comes from the access token and carries no password hash
+	user, err := u.repo.GetUserByID(ctx, ctxUser.ID)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)); err != nil {
+		return auth.ErrIncorrectPassword
+	}
+
+	userTOTP, err := u.repo.GetTOTP(ctx, user.ID)
+	if err != nil && !errors.Is(err, pkgErrors.NotFound) {
+		return err
+	}
+	if !userTOTP.Enabled() {
+		return auth.ErrTOTPNotEnabled
+	}
+
+	ok, err := u.checkTwoFactorCode(ctx, userTOTP, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return auth.ErrInvalidTwoFactorCode
+	}
+	return u.repo.DeleteTOTP(ctx, user.ID)
+}
+
+// CompleteLoginChallenge implements auth.UseCase.
+// It answers the challenge Login issued with a TOTP or recovery code and returns the user
+// to issue tokens for. Wrong codes are throttled and lock the account like wrong passwords,
+// and a challenge is dropped after too many of them.
+func (u *usecase) CompleteLoginChallenge(ctx context.Context, token string, code string, client *models.SessionClient) (*models.User, error) {
+	challenge, err := u.redisRepo.GetLoginChallenge(ctx, token)
+	if err != nil {
+		if errors.Is(err, pkgErrors.NotFound) {
+			return nil, auth.ErrInvalidLoginChallenge
+		}
+		return nil, err
+	}
+	if err := u.checkLoginAllowed(ctx, challenge.Username, client); err != nil {
+		return nil, err
+	}
+
+	// The attempt is counted before the code is checked so parallel guesses cannot exceed the limit
+	attempts, err := u.redisRepo.IncrLoginChallengeAttempts(ctx, challenge)
+	if err != nil {
+		if errors.Is(err, pkgErrors.NotFound) {
+			return nil, auth.ErrInvalidLoginChallenge
+		}
+		return nil, err
+	}
+	if attempts > maxLoginChallengeAttempts {
+		u.dropLoginChallenge(ctx, challenge)
+		return nil, auth.ErrInvalidLoginChallenge
+	}
+
+	userTOTP, err := u.repo.GetTOTP(ctx, challenge.UserID)
+	if err != nil && !errors.Is(err, pkgErrors.NotFound) {
+		return nil, err
+	}
+	// Two-factor authentication was disabled since the password was checked
+	if !userTOTP.Enabled() {
+		u.dropLoginChallenge(ctx, challenge)
+		return nil, auth.ErrInvalidLoginChallenge
+	}
+
+	ok, err := u.checkTwoFactorCode(ctx, userTOTP, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		u.recordLoginFailure(ctx, challenge.Username, client)
+		if attempts >= maxLoginChallengeAttempts {
+			u.logger.Errorf(ctx, "Login challenge out of attempts, userID: %v", challenge.UserID)
+			u.dropLoginChallenge(ctx, challenge)
+		}
+		return nil, auth.ErrInvalidTwoFactorCode
+	}
+
+	u.dropLoginChallenge(ctx, challenge)
+	u.resetLoginFailures(ctx, challenge.Username)
+	return u.repo.GetUserByID(ctx, challenge.UserID)
+}
+
+// newLoginChallenge stores a challenge the user has to answer to finish logging in
+func (u *usecase) newLoginChallenge(ctx context.Context, userID int, username string) (*models.LoginChallenge, error) {
+	token, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	challenge := &models.LoginChallenge{
+		Token:     token,
+		UserID:    userID,
+		Username:  username,
+		ExpiresAt: time.Now().Add(loginChallengeDuration),
+	}
+	if err := u.redisRepo.SetLoginChallenge(ctx, challenge); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+func (u *usecase) dropLoginChallenge(ctx context.Context, challenge *models.LoginChallenge) {
+	if err := u.redisRepo.DeleteLoginChallenge(ctx, challenge.Token); err != nil {
+		u.logger.Errorf(ctx, "Failed to delete login challenge of user %d: %v", challenge.UserID, err)
+	}
+}
+
+// checkTwoFactorCode accepts an unused TOTP code or spends a recovery code
+func (u *usecase) checkTwoFactorCode(ctx context.Context, userTOTP *models.UserTOTP, code string) (bool, error) {
+	if isTOTPCode(code) {
+		secret, err := u.openTOTPSecret(userTOTP)
+		if err != nil {
+			return false, err
+		}
+		step, ok := totp.Validate(secret, code, time.Now(), totpSkew)
+		if !ok {
+			return false, nil
+		}
+		return u.repo.UseTOTPStep(ctx, userTOTP.UserID, step)
+	}
+	return u.repo.UseRecoveryCode(ctx, userTOTP.UserID, hashRecoveryCode(code))
+}
+
+// sealTOTPSecret encrypts a secret for storage with the configured key
+func (u *usecase) sealTOTPSecret(secret string) (string, error) {
+	key, err := totp.ParseKey(u.cfg.Auth.TOTPEncryptionKey)
+	if err != nil {
+		return "", err
+	}
+	return totp.Seal(key, secret)
+}
+
+// openTOTPSecret decrypts the stored secret of userTOTP
+func (u *usecase) openTOTPSecret(userTOTP *models.UserTOTP) (string, error) {
+	key, err := totp.ParseKey(u.cfg.Auth.TOTPEncryptionKey)
+	if err != nil {
+		return "", err
+	}
+	return totp.Open(key, userTOTP.Secret)
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// hashRecoveryCode returns the hex SHA-256 of the normalized code
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(totp.NormalizeRecoveryCode(code)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,340 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/auth"
+	mock "github.com/ductong169z/shorten-url/internal/auth/mock"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/totp"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
+)
+
+const (
+	testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	testTOTPKey    = "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE="
+)
+
+// newTOTPConfig returns a config with the key the test secrets are sealed with
+func newTOTPConfig() *config.Config {
+	return &config.Config{Auth: config.AuthConfig{TOTPEncryptionKey: testTOTPKey}}
+}
+
+// sealedTestSecret is testTOTPSecret as it is stored
+func sealedTestSecret() string {
+	key, err := totp.ParseKey(testTOTPKey)
+	if err != nil {
+		panic(err)
+	}
+	sealed, err := totp.Seal(key, testTOTPSecret)
+	if err != nil {
+		panic(err)
+	}
+	return sealed
+}
+
+func currentTOTPCode(t *testing.T) string {
+	code, err := totp.Code(testTOTPSecret, totp.Step(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func enabledTOTP() *models.UserTOTP {
+	confirmedAt := time.Now().Add(-time.Hour)
+	return &models.UserTOTP{UserID: 7, Secret: sealedTestSecret(), ConfirmedAt: &confirmedAt}
+}
+
+func TestUseCase_LoginWithTwoFactor(t *testing.T) {
+	cfg := &config.Config{}
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	redisRepo := mock.NewMockRedisRepository(ctrl)
+	uc := NewUseCase(cfg, repo, redisRepo, nil, apiLogger)
+
+	expectLoginAllowed(redisRepo, "jane")
+	repo.EXPECT().Login(gomock.Any(), gomock.Any()).Return(&models.User{ID: 7}, nil)
+	repo.EXPECT().GetTOTP(gomock.Any(), 7).Return(enabledTOTP(), nil)
+	redisRepo.EXPECT().SetLoginChallenge(gomock.Any(), gomock.Any()).Return(nil)
+
+	user, challenge, err := uc.Login(context.Background(), &models.User{Username: "jane", Password: "password"}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, user)
+	require.NotNil(t, challenge)
+	assert.NotEmpty(t, challenge.Token)
+	assert.Equal(t, 7, challenge.UserID)
+	assert.Equal(t, "jane", challenge.Username)
+	assert.WithinDuration(t, time.Now().Add(loginChallengeDuration), challenge.ExpiresAt, time.Minute)
+}
+
+func TestUseCase_CompleteLoginChallenge(t *testing.T) {
+	challenge := func() *models.LoginChallenge {
+		return &models.LoginChallenge{Token: "challenge", UserID: 7, Username: "jane", ExpiresAt: time.Now().Add(time.Minute)}
+	}
+
+	tcs := map[string]struct {
+		code        func(t *testing.T) string
+		challenge   *models.LoginChallenge
+		getErr      error
+		lockedUntil time.Time
+		attempts    int
+		stepUnused  bool
+		recoveryOK  bool
+		expFailure  bool
+		expDrop     bool
+		expErr      error
+	}{
+		"valid TOTP code": {
+			code:       currentTOTPCode,
+			challenge:  challenge(),
+			attempts:   1,
+			stepUnused: true,
+			expDrop:    true,
+		},
+		"replayed TOTP code": {
+			code:       currentTOTPCode,
+			challenge:  challenge(),
+			attempts:   1,
+			expFailure: true,
+			expErr:     auth.ErrInvalidTwoFactorCode,
+		},
+		"valid recovery code": {
+			code:       func(*testing.T) string { return "abcde-fghjk" },
+			challenge:  challenge(),
+			attempts:   2,
+			recoveryOK: true,
+			expDrop:    true,
+		},
+		"wrong code": {
+			code:       func(*testing.T) string { return "abcde-fghjk" },
+			challenge:  challenge(),
+			attempts:   2,
+			expFailure: true,
+			expErr:     auth.ErrInvalidTwoFactorCode,
+		},
+		"last attempt drops the challenge": {
+			code:       func(*testing.T) string { return "abcde-fghjk" },
+			challenge:  challenge(),
+			attempts:   maxLoginChallengeAttempts,
+			expFailure: true,
+			expDrop:    true,
+			expErr:     auth.ErrInvalidTwoFactorCode,
+		},
+		"attempts past the limit are not checked": {
+			code:      currentTOTPCode,
+			challenge: challenge(),
+			attempts:  maxLoginChallengeAttempts + 1,
+			expDrop:   true,
+			expErr:    auth.ErrInvalidLoginChallenge,
+		},
+		"locked account": {
+			code:        currentTOTPCode,
+			challenge:   challenge(),
+			lockedUntil: time.Now().Add(10 * time.Minute),
+			expErr:      auth.ErrAccountLocked,
+		},
+		"unknown or expired challenge": {
+			code:   currentTOTPCode,
+			getErr: pkgErrors.NotFound,
+			expErr: auth.ErrInvalidLoginChallenge,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := newTOTPConfig()
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			redisRepo := mock.NewMockRedisRepository(ctrl)
+			uc := NewUseCase(cfg, repo, redisRepo, nil, apiLogger)
+
+			code := tc.code(t)
+			redisRepo.EXPECT().GetLoginChallenge(gomock.Any(), "challenge").Return(tc.challenge, tc.getErr)
+			switch {
+			case tc.challenge == nil:
+			case !tc.lockedUntil.IsZero():
+				redisRepo.EXPECT().GetAccountLock(gomock.Any(), "jane").Return(tc.lockedUntil, nil)
+			default:
+				expectLoginAllowed(redisRepo, "jane")
+				redisRepo.EXPECT().IncrLoginChallengeAttempts(gomock.Any(), tc.challenge).Return(tc.attempts, nil)
+			}
+			if tc.attempts > 0 && tc.attempts <= maxLoginChallengeAttempts {
+				repo.EXPECT().GetTOTP(gomock.Any(), 7).Return(enabledTOTP(), nil)
+				if isTOTPCode(code) {
+					repo.EXPECT().UseTOTPStep(gomock.Any(), 7, totp.Step(time.Now())).Return(tc.stepUnused, nil)
+				} else {
+					repo.EXPECT().UseRecoveryCode(gomock.Any(), 7, hashRecoveryCode("ABCDE FGHJK")).Return(tc.recoveryOK, nil)
+				}
+			}
+			if tc.expFailure {
+				redisRepo.EXPECT().IncrLoginFailures(gomock.Any(), "username:jane", defaultLoginFailureWindow).Return(1, nil)
+			}
+			if tc.expDrop {
+				redisRepo.EXPECT().DeleteLoginChallenge(gomock.Any(), "challenge").Return(nil)
+			}
+			if tc.expErr == nil {
+				redisRepo.EXPECT().ResetLoginFailures(gomock.Any(), "username:jane").Return(nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), 7).Return(&models.User{ID: 7}, nil)
+			}
+
+			user, err := uc.CompleteLoginChallenge(context.Background(), "challenge", code, nil)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 7, user.ID)
+		})
+	}
+}
+
+func TestUseCase_EnrollTOTP(t *testing.T) {
+	tcs := map[string]struct {
+		stored *models.UserTOTP
+		getErr error
+		noKey  bool
+		expErr error
+	}{
+		"first enrollment": {
+			getErr: pkgErrors.NotFound,
+		},
+		"pending enrollment is replaced": {
+			stored: &models.UserTOTP{UserID: 7, Secret: sealedTestSecret()},
+		},
+		"already enabled": {
+			stored: enabledTOTP(),
+			expErr: auth.ErrTOTPAlreadyEnabled,
+		},
+		"no encryption key configured": {
+			getErr: pkgErrors.NotFound,
+			noKey:  true,
+			expErr: totp.ErrInvalidKey,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := newTOTPConfig()
+			cfg.Auth.TOTPIssuer = "My Blog"
+			if tc.noKey {
+				cfg.Auth.TOTPEncryptionKey = ""
+			}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			uc := NewUseCase(cfg, repo, nil, nil, apiLogger)
+
+			repo.EXPECT().GetTOTP(gomock.Any(), 7).Return(tc.stored, tc.getErr)
+			var saved *models.UserTOTP
+			if tc.expErr == nil {
+				repo.EXPECT().SaveTOTP(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, userTOTP *models.UserTOTP) error {
+						saved = userTOTP
+						return nil
+					},
+				)
+			}
+
+			enrollment, err := uc.EnrollTOTP(withUser(&models.User{ID: 7, Email: "jane@example.com"}))
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 7, saved.UserID)
+			assert.Nil(t, saved.ConfirmedAt)
+			// Only the encrypted secret is stored
+			assert.NotEqual(t, enrollment.Secret, saved.Secret)
+			key, err := totp.ParseKey(testTOTPKey)
+			require.NoError(t, err)
+			secret, err := totp.Open(key, saved.Secret)
+			require.NoError(t, err)
+			assert.Equal(t, secret, enrollment.Secret)
+			assert.NotEqual(t, testTOTPSecret, enrollment.Secret)
+			assert.Equal(t, totp.URI("My Blog", "jane@example.com", enrollment.Secret), enrollment.URI)
+		})
+	}
+}
+
+func TestUseCase_ConfirmTOTP(t *testing.T) {
+	cfg := newTOTPConfig()
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	uc := NewUseCase(cfg, repo, nil, nil, apiLogger)
+	ctx := withUser(&models.User{ID: 7})
+
+	repo.EXPECT().GetTOTP(gomock.Any(), 7).Return(&models.UserTOTP{UserID: 7, Secret: sealedTestSecret()}, nil).Times(2)
+
+	_, err := uc.ConfirmTOTP(ctx, "000000")
+	assert.ErrorIs(t, err, auth.ErrInvalidTwoFactorCode)
+
+	var hashes []string
+	repo.EXPECT().ConfirmTOTP(gomock.Any(), 7, totp.Step(time.Now()), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ int, _ int64, recoveryCodeHashes []string) error {
+			hashes = recoveryCodeHashes
+			return nil
+		},
+	)
+	codes, err := uc.ConfirmTOTP(ctx, currentTOTPCode(t))
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+	require.Len(t, hashes, recoveryCodeCount)
+	// Only hashes of the codes shown to the user are stored
+	for i, code := range codes {
+		assert.Equal(t, hashRecoveryCode(code), hashes[i])
+		assert.NotContains(t, hashes[i], code)
+	}
+}
+
+func TestUseCase_DisableTOTP(t *testing.T) {
+	cfg := newTOTPConfig()
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	uc := NewUseCase(cfg, repo, nil, nil, apiLogger)
+	ctx := withUser(&models.User{ID: 7})
+
+	passwordHash, err := utils.HashPasswordBcrypt("current Passw0rd")
+	require.NoError(t, err)
+	repo.EXPECT().GetUserByID(gomock.Any(), 7).Return(&models.User{ID: 7, Password: passwordHash}, nil).AnyTimes()
+
+	assert.ErrorIs(t, uc.DisableTOTP(ctx, "wrong password", currentTOTPCode(t)), auth.ErrIncorrectPassword)
+
+	repo.EXPECT().GetTOTP(gomock.Any(), 7).Return(&models.UserTOTP{UserID: 7, Secret: sealedTestSecret()}, nil)
+	assert.ErrorIs(t, uc.DisableTOTP(ctx, "current Passw0rd", currentTOTPCode(t)), auth.ErrTOTPNotEnabled)
+
+	repo.EXPECT().GetTOTP(gomock.Any(), 7).Return(enabledTOTP(), nil)
+	repo.EXPECT().UseTOTPStep(gomock.Any(), 7, totp.Step(time.Now())).Return(false, nil)
+	assert.ErrorIs(t, uc.DisableTOTP(ctx, "current Passw0rd", currentTOTPCode(t)), auth.ErrInvalidTwoFactorCode)
+
+	repo.EXPECT().GetTOTP(gomock.Any(), 7).Return(enabledTOTP(), nil)
+	repo.EXPECT().UseRecoveryCode(gomock.Any(), 7, hashRecoveryCode("abcde-fghjk")).Return(true, nil)
+	repo.EXPECT().DeleteTOTP(gomock.Any(), 7).Return(nil)
+	assert.NoError(t, uc.DisableTOTP(ctx, "current Passw0rd", "abcde-fghjk"))
+}
@@ -2,14 +2,23 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ductong169z/shorten-url/config"
 	"github.com/ductong169z/shorten-url/internal/auth"
 	"github.com/ductong169z/shorten-url/internal/models"
 	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/mailer"
 	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
 
 	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
 )
@@ -17,36 +26,136 @@ import (
 const (
 	basePrefix    = "api-user:"
 	cacheDuration = 3600
+
+	maxDisplayNameLength = 100
+	maxBioLength         = 2000
+	maxURLLength         = 500
+	maxSocialLinks       = 10
+	maxUserAgentLength   = 255
+
+	defaultEmailVerificationTTL = 24 * time.Hour
+	defaultPasswordResetTTL     = time.Hour
 )
 
+// socialNetworkPattern restricts social link keys to short lower-case names
+var socialNetworkPattern = regexp.MustCompile(`^[a-z0-9_-]{1,30}$`)
+
 // News UseCase constructor
-func NewUseCase(cfg *config.Config, repo auth.Repository, redisRepo auth.RedisRepository, logger logger.Logger) auth.UseCase {
-	return &usecase{cfg: cfg, repo: repo, redisRepo: redisRepo, logger: logger}
+func NewUseCase(cfg *config.Config, repo auth.Repository, redisRepo auth.RedisRepository, mailer mailer.Mailer, logger logger.Logger) auth.UseCase {
+	return &usecase{cfg: cfg, repo: repo, redisRepo: redisRepo, mailer: mailer, logger: logger}
 }
 
 // useCase
 
-// GenerateRefreshToken generates and stores a new refresh token for a user
-func (u *usecase) GenerateRefreshToken(ctx context.Context, userID int) (string, time.Time, error) {
-	token, err := utils.GenerateRefreshToken()
+// GenerateRefreshToken generates and stores a new refresh token for a user, starting a new family
+func (u *usecase) GenerateRefreshToken(ctx context.Context, userID int, client *models.SessionClient) (string, time.Time, error) {
+	rt, err := newRefreshToken(userID, uuid.NewString(), client)
 	if err != nil {
 		return "", time.Time{}, err
 	}
-	expiresAt := time.Now().Add(7 * 24 * time.Hour)
-	rt := &models.RefreshToken{
-		UserID:    userID,
-		Token:     token,
-		ExpiresAt: expiresAt,
-	}
 	if err := u.repo.CreateRefreshToken(ctx, rt); err != nil {
 		return "", time.Time{}, err
 	}
-	return token, expiresAt, nil
+	return rt.Token, rt.ExpiresAt, nil
 }
 
-// ValidateRefreshToken checks if a refresh token is valid (not revoked/expired)
-func (u *usecase) ValidateRefreshToken(ctx context.Context, token string) (*models.RefreshToken, error) {
-	return u.repo.GetRefreshTokenByToken(ctx, token)
+// RotateRefreshToken revokes the presented refresh token and returns its successor in the
+// same family. Presenting a token that was already rotated means it leaked, so the whole
+// family is revoked and its owner has to log in again.
+func (u *usecase) RotateRefreshToken(ctx context.Context, token string, client *models.SessionClient) (*models.RefreshToken, error) {
+	rt, err := u.repo.GetRefreshTokenByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, pkgErrors.NotFound) {
+			return nil, auth.ErrInvalidToken
+		}
+		return nil, err
+	}
+	if rt.Revoked {
+		return nil, u.revokeFamily(ctx, rt)
+	}
+	if time.Now().After(rt.ExpiresAt) {
+		return nil, auth.ErrInvalidToken
+	}
+
+	next, err := newRefreshToken(rt.UserID, rt.FamilyID, client)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.RotateRefreshToken(ctx, rt.ID, next); err != nil {
+		if errors.Is(err, auth.ErrRefreshTokenReused) {
+			return nil, u.revokeFamily(ctx, rt)
+		}
+		return nil, err
+	}
+	return next, nil
+}
+
+// revokeFamily revokes every token rotated from the same login as rt
+func (u *usecase) revokeFamily(ctx context.Context, rt *models.RefreshToken) error {
+	u.logger.Errorf(ctx, "Refresh token reuse detected, userID: %v, familyID: %v", rt.UserID, rt.FamilyID)
+	if err := u.repo.RevokeRefreshTokenFamily(ctx, rt.FamilyID); err != nil {
+		return err
+	}
+	return auth.ErrRefreshTokenReused
+}
+
+func newRefreshToken(userID int, familyID string, client *models.SessionClient) (*models.RefreshToken, error) {
+	token, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	rt := &models.RefreshToken{
+		UserID:     userID,
+		Token:      token,
+		FamilyID:   familyID,
+		ExpiresAt:  now.Add(utils.RefreshTokenDuration),
+		LastUsedAt: now,
+	}
+	if client != nil {
+		rt.UserAgent = truncate(client.UserAgent, maxUserAgentLength)
+		rt.IP = client.IP
+	}
+	return rt, nil
+}
+
+// ListSessions returns the active sessions of the current user, most recently used first
+func (u *usecase) ListSessions(ctx context.Context) ([]*models.RefreshToken, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.ListActiveRefreshTokens(ctx, user.ID)
+}
+
+// RevokeSession logs one device of the current user out by revoking its session
+func (u *usecase) RevokeSession(ctx context.Context, sessionID int) error {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	rt, err := u.repo.GetRefreshTokenByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, pkgErrors.NotFound) {
+			return auth.ErrSessionNotFound
+		}
+		return err
+	}
+	// Sessions of other users are reported as missing rather than forbidden
+	if rt.UserID != user.ID {
+		return auth.ErrSessionNotFound
+	}
+
+	return u.repo.RevokeRefreshTokenFamily(ctx, rt.FamilyID)
+}
+
+// truncate cuts s to at most n runes
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
 }
 
 // RevokeRefreshToken marks a refresh token as revoked
@@ -54,11 +163,77 @@ func (u *usecase) RevokeRefreshToken(ctx context.Context, token string) error {
 	return u.repo.RevokeRefreshToken(ctx, token)
 }
 
+// Logout ends the login session of refreshToken, revoking it together with the tokens
+// rotated from it, and denylists the access token of the current request.
+func (u *usecase) Logout(ctx context.Context, refreshToken string) error {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	rt, err := u.repo.GetRefreshTokenByToken(ctx, refreshToken)
+	if err != nil {
+		if errors.Is(err, pkgErrors.NotFound) {
+			return auth.ErrInvalidToken
+		}
+		return err
+	}
+	if rt.UserID != user.ID {
+		u.logger.Errorf(ctx, "Logout, userID: %v, token userID: %v", user.ID, rt.UserID)
+		return auth.ErrInvalidToken
+	}
+
+	if err := u.repo.RevokeRefreshTokenFamily(ctx, rt.FamilyID); err != nil {
+		return err
+	}
+	return u.denyAccessToken(ctx)
+}
+
+// LogoutAll revokes every refresh token of the current user and denylists the access
+// token of the current request. Access tokens held by other devices stay valid until
+// they expire.
+func (u *usecase) LogoutAll(ctx context.Context) error {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := u.repo.RevokeUserRefreshTokens(ctx, user.ID); err != nil {
+		return err
+	}
+	return u.denyAccessToken(ctx)
+}
+
+// IsAccessTokenRevoked reports whether the access token with the given jti was logged out
+func (u *usecase) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	return u.redisRepo.IsAccessTokenDenied(ctx, jti)
+}
+
+// denyAccessToken denylists the access token of the current request until it expires
+func (u *usecase) denyAccessToken(ctx context.Context) error {
+	token, err := utils.GetAccessTokenFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+	// Tokens issued before they carried a jti cannot be denylisted and simply expire
+	if token.ID == "" {
+		return nil
+	}
+	ttl := time.Until(token.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return u.redisRepo.DenyAccessToken(ctx, token.ID, ttl)
+}
+
 type usecase struct {
 	cfg       *config.Config
 	repo      auth.Repository
 	redisRepo auth.RedisRepository
+	mailer    mailer.Mailer
 	logger    logger.Logger
+	// mails tracks the emails sent after their request returned
+	mails sync.WaitGroup
 }
 
 // GetUserByID implements auth.UseCase.
@@ -91,17 +266,46 @@ func (u *usecase) GetUserByID(ctx context.Context, userId int) (*models.User, er
 }
 
 // Login implements auth.UseCase.
-func (u *usecase) Login(ctx context.Context, user *models.User) (*models.User, error) {
+// A user with two-factor authentication gets a challenge instead, which is answered
+// through CompleteLoginChallenge. Failed logins are throttled per username and client IP.
+func (u *usecase) Login(ctx context.Context, user *models.User, client *models.SessionClient) (*models.User, *models.LoginChallenge, error) {
+	username := user.Username
+	if err := u.checkLoginAllowed(ctx, username, client); err != nil {
+		return nil, nil, err
+	}
+
 	user, err := u.repo.Login(ctx, user)
 	if err != nil {
-		return nil, auth.ErrInvalidCredentials
+		u.recordLoginFailure(ctx, username, client)
+		return nil, nil, auth.ErrInvalidCredentials
+	}
+	if u.cfg.Auth.RequireVerifiedForLogin && !user.EmailVerified {
+		return nil, nil, auth.ErrEmailNotVerified
 	}
 
-	return user, nil
+	userTOTP, err := u.repo.GetTOTP(ctx, user.ID)
+	if err != nil && !errors.Is(err, pkgErrors.NotFound) {
+		return nil, nil, err
+	}
+	// The failures are only forgotten once the second factor was given as well
+	if userTOTP.Enabled() {
+		challenge, err := u.newLoginChallenge(ctx, user.ID, username)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, challenge, nil
+	}
+
+	u.resetLoginFailures(ctx, username)
+	return user, nil, nil
 }
 
 // Register implements auth.UseCase.
 func (u *usecase) Register(ctx context.Context, user *models.User) (*models.User, error) {
+	if err := validatePassword(&u.cfg.Auth, user.Password, user); err != nil {
+		return nil, err
+	}
+
 	// Check if username already exists
 	if _, err := u.repo.GetUserByUsername(ctx, user.Username); err == nil {
 		return nil, auth.ErrUserAlreadyExists
@@ -123,7 +327,9 @@ func (u *usecase) Register(ctx context.Context, user *models.User) (*models.User
 	}
 	user.Password = hashedPassword
 
-	// Save user with hashed password
+	// Save user with hashed password and the default role
+	user.EmailVerified = false
+	user.Role = models.DefaultUserRole
 	user, err = u.repo.Register(ctx, user)
 	if err != nil {
 		return nil, auth.ErrFailedToRegisterUser
@@ -135,5 +341,314 @@ func (u *usecase) Register(ctx context.Context, user *models.User) (*models.User
 		u.logger.Errorf(ctx, "Failed to set user %d in cache (key: %s): %v", user.ID, cacheKey, err)
 	}
 
+	// The account exists either way; a lost email can be sent again
+	if err := u.sendVerificationEmail(ctx, user); err != nil {
+		u.logger.Errorf(ctx, "Failed to send verification email to user %d: %v", user.ID, err)
+	}
+
 	return user, nil
 }
+
+// VerifyEmail implements auth.UseCase.
+// Verifying an already verified address succeeds so that opening the link twice is harmless.
+func (u *usecase) VerifyEmail(ctx context.Context, token string) error {
+	userID, email, err := utils.ParseEmailVerificationToken(token, u.cfg.Server.JwtSecretKey)
+	if err != nil {
+		return auth.ErrInvalidVerificationToken
+	}
+
+	user, err := u.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		u.logger.Errorf(ctx, "VerifyEmail, userID: %v: %v", userID, err)
+		return auth.ErrInvalidVerificationToken
+	}
+	// The address changed after the token was sent
+	if user.Email != email {
+		return auth.ErrInvalidVerificationToken
+	}
+	if user.EmailVerified {
+		return nil
+	}
+
+	if err := u.repo.MarkEmailVerified(ctx, user.ID); err != nil {
+		return err
+	}
+	user.EmailVerified = true
+	cacheKey := fmt.Sprintf("%s%d", basePrefix, user.ID)
+	if err := u.redisRepo.SetUserByIDCtx(ctx, cacheKey, user); err != nil {
+		u.logger.Errorf(ctx, "Failed to set user %d in cache (key: %s): %v", user.ID, cacheKey, err)
+	}
+	return nil
+}
+
+// ResendVerificationEmail implements auth.UseCase.
+// Unknown and already verified addresses are ignored and the email is sent after returning,
+// so neither the response nor its timing tells the caller which emails have accounts.
+func (u *usecase) ResendVerificationEmail(ctx context.Context, email string, client *models.SessionClient) error {
+	if err := u.checkMailAllowed(ctx, verificationMailKind, email, client); err != nil {
+		return err
+	}
+
+	u.sendInBackground(ctx, func(ctx context.Context) {
+		user, err := u.repo.GetUserByEmail(ctx, email)
+		if err != nil {
+			if !errors.Is(err, pkgErrors.NotFound) {
+				u.logger.Errorf(ctx, "ResendVerificationEmail, GetUserByEmail: %v", err)
+			}
+			return
+		}
+		if user.EmailVerified {
+			return
+		}
+		if err := u.sendVerificationEmail(ctx, user); err != nil {
+			u.logger.Errorf(ctx, "Failed to send verification email to user %d: %v", user.ID, err)
+		}
+	})
+	return nil
+}
+
+// sendVerificationEmail mails user a link that verifies their email address
+func (u *usecase) sendVerificationEmail(ctx context.Context, user *models.User) error {
+	ttl := time.Duration(u.cfg.Auth.EmailVerificationTTL) * time.Second
+	if ttl <= 0 {
+		ttl = defaultEmailVerificationTTL
+	}
+	token, expiresAt := utils.GenerateEmailVerificationToken(user, u.cfg.Server.JwtSecretKey, ttl)
+
+	link, err := tokenLink(u.cfg.Auth.EmailVerificationURL, token)
+	if err != nil {
+		return err
+	}
+
+	return u.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nOpen the link below to verify your email address. It expires at %s.\n\n%s\n\nIf you did not sign up, you can ignore this email.\n",
+			user.Username,
+			expiresAt.UTC().Format(time.RFC1123),
+			link,
+		),
+	})
+}
+
+// ForgotPassword implements auth.UseCase.
+// It emails a single-use reset link if email belongs to an account. The email is sent after
+// returning and nothing about the outcome is returned, so the caller cannot tell which emails
+// have accounts; failures are only logged.
+func (u *usecase) ForgotPassword(ctx context.Context, email string, client *models.SessionClient) error {
+	if err := u.checkMailAllowed(ctx, passwordResetMailKind, email, client); err != nil {
+		return err
+	}
+
+	u.sendInBackground(ctx, func(ctx context.Context) {
+		user, err := u.repo.GetUserByEmail(ctx, email)
+		if err != nil {
+			if !errors.Is(err, pkgErrors.NotFound) {
+				u.logger.Errorf(ctx, "ForgotPassword, GetUserByEmail: %v", err)
+			}
+			return
+		}
+		if err := u.sendPasswordResetEmail(ctx, user); err != nil {
+			u.logger.Errorf(ctx, "Failed to send password reset email to user %d: %v", user.ID, err)
+		}
+	})
+	return nil
+}
+
+// sendInBackground runs send after the request returned, on a context that outlives it
+func (u *usecase) sendInBackground(ctx context.Context, send func(ctx context.Context)) {
+	ctx = context.WithoutCancel(ctx)
+	u.mails.Add(1)
+	go func() {
+		defer u.mails.Done()
+		send(ctx)
+	}()
+}
+
+func (u *usecase) sendPasswordResetEmail(ctx context.Context, user *models.User) error {
+	ttl := time.Duration(u.cfg.Auth.PasswordResetTTL) * time.Second
+	if ttl <= 0 {
+		ttl = defaultPasswordResetTTL
+	}
+	token, tokenHash, err := utils.GeneratePasswordResetToken()
+	if err != nil {
+		return err
+	}
+	link, err := tokenLink(u.cfg.Auth.PasswordResetURL, token)
+	if err != nil {
+		return err
+	}
+
+	resetToken := &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := u.repo.CreatePasswordResetToken(ctx, resetToken); err != nil {
+		return err
+	}
+
+	return u.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nOpen the link below to choose a new password. It can be used once and expires at %s.\n\n%s\n\nIf you did not ask for a password reset, you can ignore this email.\n",
+			user.Username,
+			resetToken.ExpiresAt.UTC().Format(time.RFC1123),
+			link,
+		),
+	})
+}
+
+// ResetPassword implements auth.UseCase.
+// Every refresh token of the user is revoked, logging all their devices out; access
+// tokens already issued stay valid until they expire.
+func (u *usecase) ResetPassword(ctx context.Context, token string, password string) error {
+	tokenHash := utils.HashPasswordResetToken(token)
+	resetToken, err := u.repo.GetPasswordResetToken(ctx, tokenHash)
+	if err != nil {
+		return err
+	}
+	user, err := u.repo.GetUserByID(ctx, resetToken.UserID)
+	if err != nil {
+		return err
+	}
+	if err := validatePassword(&u.cfg.Auth, password, user); err != nil {
+		return err
+	}
+
+	hashedPassword, err := utils.HashPasswordBcrypt(password)
+	if err != nil {
+		return auth.ErrFailedToHashPassword
+	}
+
+	// The token is checked again when it is spent, in case it was used meanwhile
+	userID, err := u.repo.ResetPassword(ctx, tokenHash, hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	u.logger.Infof(ctx, "Password reset, userID: %v", userID)
+	return nil
+}
+
+// ChangePassword implements auth.UseCase.
+// refreshToken identifies the session of the caller, which stays logged in while every
+// other session of the user is revoked. Access tokens already issued to other devices
+// stay valid until they expire.
+func (u *usecase) ChangePassword(ctx context.Context, refreshToken string, currentPassword string, newPassword string) error {
+	ctxUser, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	rt, err := u.repo.GetRefreshTokenByToken(ctx, refreshToken)
+	if err != nil {
+		if errors.Is(err, pkgErrors.NotFound) {
+			return auth.ErrInvalidToken
+		}
+		return err
+	}
+	if rt.UserID != ctxUser.ID || rt.Revoked {
+		return auth.ErrInvalidToken
+	}
+
+	// The user in ctx comes from the access token and carries no password hash
+	user, err := u.repo.GetUserByID(ctx, ctxUser.ID)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)); err != nil {
+		return auth.ErrIncorrectPassword
+	}
+	if err := validatePassword(&u.cfg.Auth, newPassword, user); err != nil {
+		return err
+	}
+
+	hashedPassword, err := utils.HashPasswordBcrypt(newPassword)
+	if err != nil {
+		return auth.ErrFailedToHashPassword
+	}
+	return u.repo.ChangePassword(ctx, user.ID, hashedPassword, rt.FamilyID)
+}
+
+// tokenLink adds token as the "token" query parameter of base
+func tokenLink(base, token string) (string, error) {
+	link, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
+
+// UpdateProfile implements auth.UseCase.
+// It edits the profile of the user in ctx; social links are replaced as a whole.
+func (u *usecase) UpdateProfile(ctx context.Context, update *models.UserProfileUpdate) (*models.UserProfile, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := u.repo.GetProfile(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if update.DisplayName != nil {
+		profile.DisplayName = strings.TrimSpace(*update.DisplayName)
+	}
+	if update.Bio != nil {
+		profile.Bio = strings.TrimSpace(*update.Bio)
+	}
+	if update.AvatarURL != nil {
+		profile.AvatarURL = strings.TrimSpace(*update.AvatarURL)
+	}
+	if update.SocialLinks != nil {
+		profile.SocialLinks = *update.SocialLinks
+	}
+
+	if err := validateProfile(profile); err != nil {
+		return nil, err
+	}
+	if err := u.repo.UpsertProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+func validateProfile(profile *models.UserProfile) error {
+	if utf8.RuneCountInString(profile.DisplayName) > maxDisplayNameLength {
+		return auth.ErrInvalidDisplayName
+	}
+	if utf8.RuneCountInString(profile.Bio) > maxBioLength {
+		return auth.ErrInvalidBio
+	}
+	if profile.AvatarURL != "" && !isWebURL(profile.AvatarURL) {
+		return auth.ErrInvalidAvatarURL
+	}
+	if len(profile.SocialLinks) > maxSocialLinks {
+		return auth.ErrInvalidSocialLinks
+	}
+	for network, link := range profile.SocialLinks {
+		if !socialNetworkPattern.MatchString(network) || !isWebURL(link) {
+			return auth.ErrInvalidSocialLinks
+		}
+	}
+	return nil
+}
+
+// isWebURL reports whether s is an absolute http or https URL
+func isWebURL(s string) bool {
+	if len(s) > maxURLLength {
+		return false
+	}
+	parsed, err := url.ParseRequestURI(s)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
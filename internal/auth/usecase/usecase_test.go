@@ -1 +1,795 @@
-package usecase
\ No newline at end of file
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/auth"
+	mock "github.com/ductong169z/shorten-url/internal/auth/mock"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/mailer"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
+)
+
+func withUser(user *models.User) context.Context {
+	return context.WithValue(context.Background(), utils.UserCtxKey{}, user)
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestUseCase_UpdateProfile(t *testing.T) {
+	user := &models.User{ID: 7, Username: "jane"}
+
+	tcs := map[string]struct {
+		update *models.UserProfileUpdate
+		expErr error
+		exp    *models.UserProfile
+	}{
+		"partial update keeps other fields": {
+			update: &models.UserProfileUpdate{DisplayName: strPtr("  Jane Doe ")},
+			exp: &models.UserProfile{
+				UserID:      7,
+				DisplayName: "Jane Doe",
+				Bio:         "Old bio",
+				SocialLinks: models.SocialLinks{"github": "https://github.com/jane"},
+			},
+		},
+		"social links are replaced": {
+			update: &models.UserProfileUpdate{SocialLinks: &models.SocialLinks{"mastodon": "https://example.social/@jane"}},
+			exp: &models.UserProfile{
+				UserID:      7,
+				Bio:         "Old bio",
+				SocialLinks: models.SocialLinks{"mastodon": "https://example.social/@jane"},
+			},
+		},
+		"avatar must be a web URL": {
+			update: &models.UserProfileUpdate{AvatarURL: strPtr("javascript:alert(1)")},
+			expErr: auth.ErrInvalidAvatarURL,
+		},
+		"invalid social network name": {
+			update: &models.UserProfileUpdate{SocialLinks: &models.SocialLinks{"Bad Name": "https://example.com"}},
+			expErr: auth.ErrInvalidSocialLinks,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			uc := NewUseCase(cfg, repo, nil, nil, apiLogger)
+
+			repo.EXPECT().GetProfile(gomock.Any(), user.ID).Return(&models.UserProfile{
+				UserID:      7,
+				Bio:         "Old bio",
+				SocialLinks: models.SocialLinks{"github": "https://github.com/jane"},
+			}, nil)
+			if tc.expErr == nil {
+				repo.EXPECT().UpsertProfile(gomock.Any(), tc.exp).Return(nil)
+			}
+
+			profile, err := uc.UpdateProfile(withUser(user), tc.update)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, profile)
+		})
+	}
+}
+
+func TestUseCase_RotateRefreshToken(t *testing.T) {
+	valid := &models.RefreshToken{ID: 1, UserID: 7, Token: "old", FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}
+
+	tcs := map[string]struct {
+		stored       *models.RefreshToken
+		getErr       error
+		rotateErr    error
+		expRotate    bool
+		expRevokeAll bool
+		expErr       error
+	}{
+		"rotates within the family": {
+			stored:    valid,
+			expRotate: true,
+		},
+		"unknown token": {
+			getErr: pkgErrors.NotFound,
+			expErr: auth.ErrInvalidToken,
+		},
+		"expired token": {
+			stored: &models.RefreshToken{ID: 1, UserID: 7, Token: "old", FamilyID: "family", ExpiresAt: time.Now().Add(-time.Minute)},
+			expErr: auth.ErrInvalidToken,
+		},
+		"already rotated token revokes the family": {
+			stored:       &models.RefreshToken{ID: 1, UserID: 7, Token: "old", FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), Revoked: true},
+			expRevokeAll: true,
+			expErr:       auth.ErrRefreshTokenReused,
+		},
+		"concurrent rotation revokes the family": {
+			stored:       valid,
+			expRotate:    true,
+			rotateErr:    auth.ErrRefreshTokenReused,
+			expRevokeAll: true,
+			expErr:       auth.ErrRefreshTokenReused,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			uc := NewUseCase(cfg, repo, nil, nil, apiLogger)
+
+			repo.EXPECT().GetRefreshTokenByToken(gomock.Any(), "old").Return(tc.stored, tc.getErr)
+			if tc.expRotate {
+				repo.EXPECT().RotateRefreshToken(gomock.Any(), tc.stored.ID, gomock.Any()).Return(tc.rotateErr)
+			}
+			if tc.expRevokeAll {
+				repo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), "family").Return(nil)
+			}
+
+			client := &models.SessionClient{UserAgent: "Firefox", IP: "203.0.113.7"}
+			next, err := uc.RotateRefreshToken(context.Background(), "old", client)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 7, next.UserID)
+			assert.Equal(t, "family", next.FamilyID)
+			assert.NotEqual(t, "old", next.Token)
+			assert.True(t, next.ExpiresAt.After(time.Now()))
+			assert.Equal(t, "Firefox", next.UserAgent)
+			assert.Equal(t, "203.0.113.7", next.IP)
+			assert.WithinDuration(t, time.Now(), next.LastUsedAt, time.Minute)
+		})
+	}
+}
+
+func withSession(user *models.User, token *utils.AccessToken) context.Context {
+	return context.WithValue(withUser(user), utils.AccessTokenCtxKey{}, token)
+}
+
+func TestUseCase_Logout(t *testing.T) {
+	user := &models.User{ID: 7}
+	accessToken := &utils.AccessToken{ID: "jti-1", ExpiresAt: time.Now().Add(30 * time.Minute)}
+
+	tcs := map[string]struct {
+		ctx       context.Context
+		stored    *models.RefreshToken
+		getErr    error
+		expRevoke bool
+		expDeny   bool
+		expErr    error
+	}{
+		"revokes the session and denylists the access token": {
+			ctx:       withSession(user, accessToken),
+			stored:    &models.RefreshToken{ID: 1, UserID: 7, FamilyID: "family"},
+			expRevoke: true,
+			expDeny:   true,
+		},
+		"legacy access token without jti": {
+			ctx:       withSession(user, &utils.AccessToken{ExpiresAt: time.Now().Add(time.Minute)}),
+			stored:    &models.RefreshToken{ID: 1, UserID: 7, FamilyID: "family"},
+			expRevoke: true,
+		},
+		"refresh token of another user": {
+			ctx:    withSession(user, accessToken),
+			stored: &models.RefreshToken{ID: 2, UserID: 8, FamilyID: "other"},
+			expErr: auth.ErrInvalidToken,
+		},
+		"unknown refresh token": {
+			ctx:    withSession(user, accessToken),
+			getErr: pkgErrors.NotFound,
+			expErr: auth.ErrInvalidToken,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			redisRepo := mock.NewMockRedisRepository(ctrl)
+			uc := NewUseCase(cfg, repo, redisRepo, nil, apiLogger)
+
+			repo.EXPECT().GetRefreshTokenByToken(gomock.Any(), "refresh").Return(tc.stored, tc.getErr)
+			if tc.expRevoke {
+				repo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), "family").Return(nil)
+			}
+			if tc.expDeny {
+				redisRepo.EXPECT().DenyAccessToken(gomock.Any(), "jti-1", gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, ttl time.Duration) error {
+						assert.InDelta(t, 30*time.Minute, ttl, float64(time.Minute))
+						return nil
+					},
+				)
+			}
+
+			err := uc.Logout(tc.ctx, "refresh")
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUseCase_LogoutAll(t *testing.T) {
+	cfg := &config.Config{}
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	redisRepo := mock.NewMockRedisRepository(ctrl)
+	uc := NewUseCase(cfg, repo, redisRepo, nil, apiLogger)
+
+	repo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), 7).Return(nil)
+	redisRepo.EXPECT().DenyAccessToken(gomock.Any(), "jti-1", gomock.Any()).Return(nil)
+
+	ctx := withSession(&models.User{ID: 7}, &utils.AccessToken{ID: "jti-1", ExpiresAt: time.Now().Add(time.Minute)})
+	assert.NoError(t, uc.LogoutAll(ctx))
+}
+
+func TestUseCase_RevokeSession(t *testing.T) {
+	tcs := map[string]struct {
+		stored    *models.RefreshToken
+		getErr    error
+		expRevoke bool
+		expErr    error
+	}{
+		"own session": {
+			stored:    &models.RefreshToken{ID: 3, UserID: 7, FamilyID: "family"},
+			expRevoke: true,
+		},
+		"session of another user": {
+			stored: &models.RefreshToken{ID: 3, UserID: 8, FamilyID: "family"},
+			expErr: auth.ErrSessionNotFound,
+		},
+		"unknown session": {
+			getErr: pkgErrors.NotFound,
+			expErr: auth.ErrSessionNotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			uc := NewUseCase(cfg, repo, nil, nil, apiLogger)
+
+			repo.EXPECT().GetRefreshTokenByID(gomock.Any(), 3).Return(tc.stored, tc.getErr)
+			if tc.expRevoke {
+				repo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), "family").Return(nil)
+			}
+
+			err := uc.RevokeSession(withUser(&models.User{ID: 7}), 3)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUseCase_RegisterSendsVerificationEmail(t *testing.T) {
+	cfg := &config.Config{
+		Server: config.ServerConfig{JwtSecretKey: "secret"},
+		Auth:   config.AuthConfig{EmailVerificationURL: "https://blog.example.com/verify?lang=en"},
+	}
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	redisRepo := mock.NewMockRedisRepository(ctrl)
+	mail := mailer.NewMemory()
+	uc := NewUseCase(cfg, repo, redisRepo, mail, apiLogger)
+
+	repo.EXPECT().GetUserByUsername(gomock.Any(), "jane").Return(nil, pkgErrors.NotFound)
+	repo.EXPECT().GetUserByEmail(gomock.Any(), "jane@example.com").Return(nil, pkgErrors.NotFound)
+	repo.EXPECT().Register(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, user *models.User) (*models.User, error) {
+			user.ID = 7
+			return user, nil
+		},
+	)
+	redisRepo.EXPECT().SetUserByIDCtx(gomock.Any(), "api-user:7", gomock.Any()).Return(nil)
+
+	user, err := uc.Register(context.Background(), &models.User{
+		Username:      "jane",
+		Email:         "jane@example.com",
+		EmailVerified: true,
+		Password:      "Tr0ub4dor&3",
+		Role:          models.RoleAuthor,
+	})
+	require.NoError(t, err)
+	assert.False(t, user.EmailVerified)
+	assert.Equal(t, models.DefaultUserRole, user.Role)
+
+	messages := mail.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "jane@example.com", messages[0].To)
+
+	link := emailLink(t, messages[0].Body)
+	assert.Equal(t, "blog.example.com", link.Host)
+	assert.Equal(t, "en", link.Query().Get("lang"))
+	userID, email, err := utils.ParseEmailVerificationToken(link.Query().Get("token"), "secret")
+	require.NoError(t, err)
+	assert.Equal(t, 7, userID)
+	assert.Equal(t, "jane@example.com", email)
+}
+
+// emailLink finds the link in the body of an email
+func emailLink(t *testing.T, body string) *url.URL {
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "https://") {
+			link, err := url.Parse(line)
+			require.NoError(t, err)
+			return link
+		}
+	}
+	t.Fatalf("no link in %q", body)
+	return nil
+}
+
+func TestUseCase_VerifyEmail(t *testing.T) {
+	user := &models.User{ID: 7, Email: "jane@example.com"}
+	token, _ := utils.GenerateEmailVerificationToken(user, "secret", time.Hour)
+	expired, _ := utils.GenerateEmailVerificationToken(user, "secret", -time.Minute)
+	forged, _ := utils.GenerateEmailVerificationToken(user, "other secret", time.Hour)
+
+	tcs := map[string]struct {
+		token     string
+		stored    *models.User
+		expMarked bool
+		expErr    error
+	}{
+		"valid token": {
+			token:     token,
+			stored:    &models.User{ID: 7, Email: "jane@example.com"},
+			expMarked: true,
+		},
+		"already verified": {
+			token:  token,
+			stored: &models.User{ID: 7, Email: "jane@example.com", EmailVerified: true},
+		},
+		"email changed since the token was sent": {
+			token:  token,
+			stored: &models.User{ID: 7, Email: "jane@example.org"},
+			expErr: auth.ErrInvalidVerificationToken,
+		},
+		"expired token": {
+			token:  expired,
+			expErr: auth.ErrInvalidVerificationToken,
+		},
+		"forged token": {
+			token:  forged,
+			expErr: auth.ErrInvalidVerificationToken,
+		},
+		"malformed token": {
+			token:  "not-a-token",
+			expErr: auth.ErrInvalidVerificationToken,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{Server: config.ServerConfig{JwtSecretKey: "secret"}}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			redisRepo := mock.NewMockRedisRepository(ctrl)
+			uc := NewUseCase(cfg, repo, redisRepo, nil, apiLogger)
+
+			if tc.stored != nil {
+				repo.EXPECT().GetUserByID(gomock.Any(), 7).Return(tc.stored, nil)
+			}
+			if tc.expMarked {
+				repo.EXPECT().MarkEmailVerified(gomock.Any(), 7).Return(nil)
+				redisRepo.EXPECT().SetUserByIDCtx(gomock.Any(), "api-user:7", gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, user *models.User) error {
+						assert.True(t, user.EmailVerified)
+						return nil
+					},
+				)
+			}
+
+			err := uc.VerifyEmail(context.Background(), tc.token)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUseCase_ResendVerificationEmail(t *testing.T) {
+	tcs := map[string]struct {
+		email      string
+		emailCount int
+		stored     *models.User
+		getErr     error
+		expEmail   bool
+		expErr     error
+	}{
+		"unverified email": {
+			email:      "jane@example.com",
+			emailCount: 1,
+			stored:     &models.User{ID: 7, Username: "jane", Email: "jane@example.com"},
+			expEmail:   true,
+		},
+		"verified email": {
+			email:      "jane@example.com",
+			emailCount: 1,
+			stored:     &models.User{ID: 7, Username: "jane", Email: "jane@example.com", EmailVerified: true},
+		},
+		"unknown email": {
+			email:      "nobody@example.com",
+			emailCount: 1,
+			getErr:     pkgErrors.NotFound,
+		},
+		"too many requests": {
+			email:      "jane@example.com",
+			emailCount: 4,
+			expErr:     auth.ErrTooManyMailRequests,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{JwtSecretKey: "secret"},
+				Auth:   config.AuthConfig{EmailVerificationURL: "https://blog.example.com/verify"},
+			}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			redisRepo := mock.NewMockRedisRepository(ctrl)
+			mail := mailer.NewMemory()
+			uc := NewUseCase(cfg, repo, redisRepo, mail, apiLogger)
+
+			redisRepo.EXPECT().IncrMailRequests(gomock.Any(), "verify:email:"+tc.email, time.Hour).Return(tc.emailCount, nil)
+			if tc.expErr == nil {
+				repo.EXPECT().GetUserByEmail(gomock.Any(), tc.email).Return(tc.stored, tc.getErr)
+			}
+
+			err := uc.ResendVerificationEmail(context.Background(), tc.email, nil)
+			uc.(*usecase).mails.Wait()
+
+			assert.ErrorIs(t, err, tc.expErr)
+			if tc.expEmail {
+				require.Len(t, mail.Messages(), 1)
+				assert.Equal(t, tc.email, mail.Messages()[0].To)
+			} else {
+				assert.Empty(t, mail.Messages())
+			}
+		})
+	}
+}
+
+func TestUseCase_LoginRequiresVerifiedEmail(t *testing.T) {
+	tcs := map[string]struct {
+		require  bool
+		verified bool
+		expErr   error
+	}{
+		"verified user": {
+			require:  true,
+			verified: true,
+		},
+		"unverified user": {
+			require: true,
+			expErr:  auth.ErrEmailNotVerified,
+		},
+		"unverified user when not required": {},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{Auth: config.AuthConfig{RequireVerifiedForLogin: tc.require}}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			redisRepo := mock.NewMockRedisRepository(ctrl)
+			uc := NewUseCase(cfg, repo, redisRepo, nil, apiLogger)
+
+			expectLoginAllowed(redisRepo, "jane")
+			repo.EXPECT().Login(gomock.Any(), gomock.Any()).Return(&models.User{ID: 7, EmailVerified: tc.verified}, nil)
+			if tc.expErr == nil {
+				repo.EXPECT().GetTOTP(gomock.Any(), 7).Return(nil, pkgErrors.NotFound)
+				redisRepo.EXPECT().ResetLoginFailures(gomock.Any(), "username:jane").Return(nil)
+			}
+
+			_, _, err := uc.Login(context.Background(), &models.User{Username: "jane", Password: "password"}, nil)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUseCase_ForgotPassword(t *testing.T) {
+	client := &models.SessionClient{IP: "203.0.113.7"}
+
+	tcs := map[string]struct {
+		email      string
+		emailCount int
+		ipCount    int
+		stored     *models.User
+		getErr     error
+		expEmail   bool
+		expErr     error
+	}{
+		"known email": {
+			email:      "jane@example.com",
+			emailCount: 1,
+			ipCount:    1,
+			stored:     &models.User{ID: 7, Username: "jane", Email: "jane@example.com"},
+			expEmail:   true,
+		},
+		"unknown email": {
+			email:      "nobody@example.com",
+			emailCount: 1,
+			ipCount:    1,
+			getErr:     pkgErrors.NotFound,
+		},
+		"too many requests for the email": {
+			email:      "jane@example.com",
+			emailCount: 4,
+			ipCount:    4,
+			expErr:     auth.ErrTooManyMailRequests,
+		},
+		"too many requests from the IP": {
+			email:      "jane@example.com",
+			emailCount: 1,
+			ipCount:    21,
+			expErr:     auth.ErrTooManyMailRequests,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{Auth: config.AuthConfig{PasswordResetURL: "https://blog.example.com/reset"}}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			redisRepo := mock.NewMockRedisRepository(ctrl)
+			mail := mailer.NewMemory()
+			uc := NewUseCase(cfg, repo, redisRepo, mail, apiLogger)
+
+			var stored *models.PasswordResetToken
+			redisRepo.EXPECT().IncrMailRequests(gomock.Any(), "reset:email:"+tc.email, time.Hour).Return(tc.emailCount, nil)
+			redisRepo.EXPECT().IncrMailRequests(gomock.Any(), "reset:ip:203.0.113.7", time.Hour).Return(tc.ipCount, nil)
+			if tc.expErr == nil {
+				repo.EXPECT().GetUserByEmail(gomock.Any(), tc.email).Return(tc.stored, tc.getErr)
+			}
+			if tc.expEmail {
+				repo.EXPECT().CreatePasswordResetToken(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, token *models.PasswordResetToken) error {
+						stored = token
+						return nil
+					},
+				)
+			}
+
+			err := uc.ForgotPassword(context.Background(), tc.email, client)
+			uc.(*usecase).mails.Wait()
+
+			messages := mail.Messages()
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				assert.Empty(t, messages)
+				return
+			}
+			require.NoError(t, err)
+			if !tc.expEmail {
+				assert.Empty(t, messages)
+				return
+			}
+			require.Len(t, messages, 1)
+			assert.Equal(t, tc.email, messages[0].To)
+
+			// Only the hash of the emailed token is stored
+			token := emailLink(t, messages[0].Body).Query().Get("token")
+			require.NotEmpty(t, token)
+			assert.Equal(t, 7, stored.UserID)
+			assert.Equal(t, utils.HashPasswordResetToken(token), stored.TokenHash)
+			assert.NotEqual(t, token, stored.TokenHash)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), stored.ExpiresAt, time.Minute)
+		})
+	}
+}
+
+func TestUseCase_ResetPassword(t *testing.T) {
+	tcs := map[string]struct {
+		password string
+		getErr   error
+		expReset bool
+		resetErr error
+		expErr   error
+	}{
+		"valid token": {
+			password: "new Passw0rd",
+			expReset: true,
+		},
+		"unknown, used or expired token": {
+			password: "new Passw0rd",
+			getErr:   auth.ErrInvalidResetToken,
+			expErr:   auth.ErrInvalidResetToken,
+		},
+		"token used meanwhile": {
+			password: "new Passw0rd",
+			expReset: true,
+			resetErr: auth.ErrInvalidResetToken,
+			expErr:   auth.ErrInvalidResetToken,
+		},
+		"weak password": {
+			password: "short",
+			expErr:   auth.ErrPasswordTooShort,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			uc := NewUseCase(cfg, repo, nil, nil, apiLogger)
+
+			tokenHash := utils.HashPasswordResetToken("reset-token")
+			if tc.getErr != nil {
+				repo.EXPECT().GetPasswordResetToken(gomock.Any(), tokenHash).Return(nil, tc.getErr)
+			} else {
+				repo.EXPECT().GetPasswordResetToken(gomock.Any(), tokenHash).Return(&models.PasswordResetToken{UserID: 7}, nil)
+				repo.EXPECT().GetUserByID(gomock.Any(), 7).Return(&models.User{ID: 7, Username: "jane", Email: "jane@example.com"}, nil)
+			}
+			if tc.expReset {
+				repo.EXPECT().ResetPassword(gomock.Any(), tokenHash, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, passwordHash string) (int, error) {
+						assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(tc.password)))
+						return 7, tc.resetErr
+					},
+				)
+			}
+
+			err := uc.ResetPassword(context.Background(), "reset-token", tc.password)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUseCase_ChangePassword(t *testing.T) {
+	current, err := utils.HashPasswordBcrypt("current Passw0rd")
+	require.NoError(t, err)
+	stored := &models.User{ID: 7, Username: "jane", Email: "jane@example.com", Password: current}
+
+	tcs := map[string]struct {
+		current   string
+		next      string
+		session   *models.RefreshToken
+		expChange bool
+		expErr    error
+	}{
+		"valid change": {
+			current:   "current Passw0rd",
+			next:      "new Passw0rd",
+			session:   &models.RefreshToken{UserID: 7, FamilyID: "family"},
+			expChange: true,
+		},
+		"wrong current password": {
+			current: "wrong Passw0rd",
+			next:    "new Passw0rd",
+			session: &models.RefreshToken{UserID: 7, FamilyID: "family"},
+			expErr:  auth.ErrIncorrectPassword,
+		},
+		"new password contains the username": {
+			current: "current Passw0rd",
+			next:    "Jane-2024-secret",
+			session: &models.RefreshToken{UserID: 7, FamilyID: "family"},
+			expErr:  auth.ErrPasswordContainsAccount,
+		},
+		"refresh token of another user": {
+			session: &models.RefreshToken{UserID: 8, FamilyID: "family"},
+			expErr:  auth.ErrInvalidToken,
+		},
+		"revoked refresh token": {
+			session: &models.RefreshToken{UserID: 7, FamilyID: "family", Revoked: true},
+			expErr:  auth.ErrInvalidToken,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			uc := NewUseCase(cfg, repo, nil, nil, apiLogger)
+
+			repo.EXPECT().GetRefreshTokenByToken(gomock.Any(), "refresh").Return(tc.session, nil)
+			if tc.session.UserID == 7 && !tc.session.Revoked {
+				repo.EXPECT().GetUserByID(gomock.Any(), 7).Return(stored, nil)
+			}
+			if tc.expChange {
+				repo.EXPECT().ChangePassword(gomock.Any(), 7, gomock.Any(), "family").DoAndReturn(
+					func(_ context.Context, _ int, passwordHash string, _ string) error {
+						assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(tc.next)))
+						return nil
+					},
+				)
+			}
+
+			err := uc.ChangePassword(withUser(&models.User{ID: 7}), "refresh", tc.current, tc.next)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+//go:generate mockgen -source delivery.go -destination mock/handlers_mock.go -package mock
+package author
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type Handlers interface {
+	GetByUsername(c *gin.Context)
+}
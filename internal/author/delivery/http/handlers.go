@@ -0,0 +1,50 @@
+package http
+
+import (
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/author"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/response"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Author handlers
+type handlers struct {
+	cfg     *config.Config
+	usecase author.UseCase
+	logger  logger.Logger
+}
+
+// NewHandlers Author handlers constructor
+func NewHandlers(cfg *config.Config, usecase author.UseCase, logger logger.Logger) author.Handlers {
+	return &handlers{cfg: cfg, usecase: usecase, logger: logger}
+}
+
+// GetByUsername godoc
+// @Summary      Get author page
+// @Description  Get an author's public profile with their published posts
+// @Tags         authors
+// @Produce      json
+// @Param        username  path      string  true   "Username"
+// @Param        page      query     int     false  "Page number"
+// @Param        size      query     int     false  "Page size"
+// @Param        orderBy   query     string  false  "Sort order (created_at, -created_at, published_at, -published_at, title, -title)"
+// @Success      200       {object}  AuthorResponse{posts=http.PostListResponse}
+// @Failure      400,404   {object}  response.Response
+// @Router       /authors/{username} [get]
+func (h *handlers) GetByUsername(c *gin.Context) {
+	pq, err := utils.GetPaginationFromCtx(c)
+	if err != nil {
+		response.WithMappedError(c, err, author.MapError)
+		return
+	}
+
+	a, err := h.usecase.GetByUsername(c.Request.Context(), c.Param("username"), pq)
+	if err != nil {
+		response.WithMappedError(c, err, author.MapError)
+		return
+	}
+
+	response.WithOK(c, FromAuthorModel(a))
+}
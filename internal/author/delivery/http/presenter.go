@@ -0,0 +1,39 @@
+package http
+
+import (
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	postHttp "github.com/ductong169z/shorten-url/internal/posts/delivery/http"
+)
+
+type AuthorResponse struct {
+	Username    string                    `json:"username"`
+	DisplayName string                    `json:"display_name"`
+	Bio         string                    `json:"bio"`
+	AvatarURL   string                    `json:"avatar_url"`
+	SocialLinks map[string]string         `json:"social_links"`
+	JoinedAt    string                    `json:"joined_at"`
+	Posts       postHttp.PostListResponse `json:"posts" swaggerignore:"true"`
+}
+
+func FormatTime(time time.Time) string {
+	return time.Format("2006-01-02 15:04:05")
+}
+
+func FromAuthorModel(a *models.Author) AuthorResponse {
+	links := a.Profile.SocialLinks
+	if links == nil {
+		links = models.SocialLinks{}
+	}
+
+	return AuthorResponse{
+		Username:    a.User.Username,
+		DisplayName: a.Profile.DisplayName,
+		Bio:         a.Profile.Bio,
+		AvatarURL:   a.Profile.AvatarURL,
+		SocialLinks: links,
+		JoinedAt:    FormatTime(a.User.CreatedAt),
+		Posts:       postHttp.FromPostListModel(a.Posts),
+	}
+}
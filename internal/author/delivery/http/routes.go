@@ -0,0 +1,11 @@
+package http
+
+import (
+	"github.com/ductong169z/shorten-url/internal/author"
+	"github.com/gin-gonic/gin"
+)
+
+// Map author routes
+func MapRoutes(group *gin.RouterGroup, h author.Handlers) {
+	group.GET("/:username", h.GetByUsername)
+}
@@ -0,0 +1,44 @@
+// Package author provides core error definitions and utilities for the public author pages domain.
+// It defines domain-specific error variables and error-to-HTTP status mapping for consistent error handling.
+package author
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// authorNotFound is returned when no user has the requested username.
+	authorNotFound = "author not found"
+)
+
+var (
+	// ErrAuthorNotFound indicates that the author was not found.
+	ErrAuthorNotFound = errors.New(authorNotFound)
+)
+
+// MapError maps a domain error to an HTTP status code and message.
+// It provides a unified way to translate domain errors to HTTP responses.
+func MapError(err error) (status int, message string) {
+	// Handle JSON binding/unmarshal errors as 400 Bad Request
+	switch err.(type) {
+	case *json.UnmarshalTypeError, *json.SyntaxError:
+		return http.StatusBadRequest, "Invalid request format"
+	case *strconv.NumError:
+		return http.StatusBadRequest, "Invalid parameter format"
+	}
+	if ginErr, ok := err.(*gin.Error); ok && ginErr.Type == gin.ErrorTypeBind {
+		return http.StatusBadRequest, "Invalid request format"
+	}
+
+	switch {
+	case errors.Is(err, ErrAuthorNotFound):
+		return http.StatusNotFound, authorNotFound
+	default:
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
@@ -0,0 +1,13 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package author
+
+import (
+	"context"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+type UseCase interface {
+	GetByUsername(ctx context.Context, username string, pq *utils.PaginationQuery) (*models.Author, error)
+}
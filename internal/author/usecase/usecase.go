@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/auth"
+	"github.com/ductong169z/shorten-url/internal/author"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/posts"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+
+	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
+)
+
+type usecase struct {
+	cfg      *config.Config
+	authRepo auth.Repository
+	postRepo posts.Repository
+	logger   logger.Logger
+}
+
+// Author UseCase constructor
+func NewUseCase(cfg *config.Config, authRepo auth.Repository, postRepo posts.Repository, logger logger.Logger) author.UseCase {
+	return &usecase{cfg: cfg, authRepo: authRepo, postRepo: postRepo, logger: logger}
+}
+
+// GetByUsername implements author.UseCase.
+// It returns the public profile with the author's published posts.
+func (u *usecase) GetByUsername(ctx context.Context, username string, pq *utils.PaginationQuery) (*models.Author, error) {
+	user, err := u.authRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, pkgErrors.NotFound) {
+			return nil, author.ErrAuthorNotFound
+		}
+		return nil, err
+	}
+
+	profile, err := u.authRepo.GetProfile(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &models.PostFilter{Status: models.PostStatusPublished, UserID: user.ID}
+	list, err := u.postRepo.List(ctx, filter, pq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Author{User: user, Profile: profile, Posts: list}, nil
+}
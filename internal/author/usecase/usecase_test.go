@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ductong169z/shorten-url/config"
+	authMock "github.com/ductong169z/shorten-url/internal/auth/mock"
+	"github.com/ductong169z/shorten-url/internal/author"
+	"github.com/ductong169z/shorten-url/internal/models"
+	postMock "github.com/ductong169z/shorten-url/internal/posts/mock"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
+)
+
+func TestUseCase_GetByUsername(t *testing.T) {
+	user := &models.User{ID: 7, Username: "jane"}
+	profile := &models.UserProfile{UserID: 7, DisplayName: "Jane"}
+	list := &models.PostList{TotalCount: 1, Posts: []*models.Post{{ID: 1, UserID: 7}}}
+
+	tcs := map[string]struct {
+		userErr error
+		expErr  error
+	}{
+		"author with posts": {},
+		"unknown username": {
+			userErr: pkgErrors.NotFound,
+			expErr:  author.ErrAuthorNotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authRepo := authMock.NewMockRepository(ctrl)
+			postRepo := postMock.NewMockRepository(ctrl)
+			uc := NewUseCase(cfg, authRepo, postRepo, apiLogger)
+			pq := &utils.PaginationQuery{Page: 1, Size: 10}
+
+			if tc.userErr != nil {
+				authRepo.EXPECT().GetUserByUsername(gomock.Any(), "jane").Return(nil, tc.userErr)
+			} else {
+				authRepo.EXPECT().GetUserByUsername(gomock.Any(), "jane").Return(user, nil)
+				authRepo.EXPECT().GetProfile(gomock.Any(), user.ID).Return(profile, nil)
+				postRepo.EXPECT().List(gomock.Any(), &models.PostFilter{Status: models.PostStatusPublished, UserID: user.ID}, pq).Return(list, nil)
+			}
+
+			a, err := uc.GetByUsername(context.Background(), "jane", pq)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, user, a.User)
+			assert.Equal(t, profile, a.Profile)
+			assert.Equal(t, list, a.Posts)
+		})
+	}
+}
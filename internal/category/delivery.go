@@ -0,0 +1,15 @@
+//go:generate mockgen -source delivery.go -destination mock/handlers_mock.go -package mock
+package category
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type Handlers interface {
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	GetBySlug(c *gin.Context)
+	List(c *gin.Context)
+	Delete(c *gin.Context)
+	ListPosts(c *gin.Context)
+}
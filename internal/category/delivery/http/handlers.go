@@ -0,0 +1,183 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/category"
+	postHttp "github.com/ductong169z/shorten-url/internal/posts/delivery/http"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/response"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Category handlers
+type handlers struct {
+	cfg     *config.Config
+	usecase category.UseCase
+	logger  logger.Logger
+}
+
+// NewHandlers Category handlers constructor
+func NewHandlers(cfg *config.Config, usecase category.UseCase, logger logger.Logger) category.Handlers {
+	return &handlers{cfg: cfg, usecase: usecase, logger: logger}
+}
+
+// Create godoc
+// @Summary      Create category
+// @Description  Create a new category; the slug is generated from the name when omitted (admin only)
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Param        categoryRequest  body      CategoryRequest  true  "Category payload"
+// @Success      201              {object}  CategoryResponse
+// @Failure      400,401,403,409  {object}  response.Response
+// @Router       /categories [post]
+func (h *handlers) Create(c *gin.Context) {
+	var req CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, category.MapError)
+		return
+	}
+
+	created, err := h.usecase.Create(c.Request.Context(), req.ToModel())
+	if err != nil {
+		response.WithMappedError(c, err, category.MapError)
+		return
+	}
+
+	response.WithCode(c, http.StatusCreated, FromCategoryModel(created))
+}
+
+// Update godoc
+// @Summary      Update category
+// @Description  Replace the name, slug and description of a category; an omitted slug is kept (admin only)
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Param        id               path      int              true  "Category ID"
+// @Param        categoryRequest  body      CategoryRequest  true  "Category payload"
+// @Success      200              {object}  CategoryResponse
+// @Failure      400,401,403,404,409  {object}  response.Response
+// @Router       /categories/{id} [put]
+func (h *handlers) Update(c *gin.Context) {
+	categoryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithMappedError(c, err, category.MapError)
+		return
+	}
+
+	var req CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, category.MapError)
+		return
+	}
+
+	input := req.ToModel()
+	input.ID = categoryID
+	updated, err := h.usecase.Update(c.Request.Context(), input)
+	if err != nil {
+		response.WithMappedError(c, err, category.MapError)
+		return
+	}
+
+	response.WithOK(c, FromCategoryModel(updated))
+}
+
+// GetBySlug godoc
+// @Summary      Get category by slug
+// @Description  Get a category by its slug
+// @Tags         categories
+// @Produce      json
+// @Param        slug  path      string  true  "Category slug"
+// @Success      200   {object}  CategoryResponse
+// @Failure      404   {object}  response.Response
+// @Router       /categories/{slug} [get]
+func (h *handlers) GetBySlug(c *gin.Context) {
+	found, err := h.usecase.GetBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		response.WithMappedError(c, err, category.MapError)
+		return
+	}
+
+	response.WithOK(c, FromCategoryModel(found))
+}
+
+// List godoc
+// @Summary      List categories
+// @Description  List categories ordered by name with pagination
+// @Tags         categories
+// @Produce      json
+// @Param        page  query     int  false  "Page number"
+// @Param        size  query     int  false  "Page size"
+// @Success      200   {object}  CategoryListResponse
+// @Failure      400   {object}  response.Response
+// @Router       /categories [get]
+func (h *handlers) List(c *gin.Context) {
+	pq, err := utils.GetPaginationFromCtx(c)
+	if err != nil {
+		response.WithMappedError(c, err, category.MapError)
+		return
+	}
+
+	list, err := h.usecase.List(c.Request.Context(), pq)
+	if err != nil {
+		response.WithMappedError(c, err, category.MapError)
+		return
+	}
+
+	response.WithOK(c, FromCategoryListModel(list))
+}
+
+// Delete godoc
+// @Summary      Delete category
+// @Description  Delete a category; its posts become uncategorized (admin only)
+// @Tags         categories
+// @Param        id   path  int  true  "Category ID"
+// @Success      204
+// @Failure      400,401,403,404  {object}  response.Response
+// @Router       /categories/{id} [delete]
+func (h *handlers) Delete(c *gin.Context) {
+	categoryID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithMappedError(c, err, category.MapError)
+		return
+	}
+
+	if err := h.usecase.Delete(c.Request.Context(), categoryID); err != nil {
+		response.WithMappedError(c, err, category.MapError)
+		return
+	}
+
+	response.WithNoContent(c)
+}
+
+// ListPosts godoc
+// @Summary      List posts in category
+// @Description  List published posts in a category with pagination
+// @Tags         categories
+// @Produce      json
+// @Param        slug     path      string  true   "Category slug"
+// @Param        page     query     int     false  "Page number"
+// @Param        size     query     int     false  "Page size"
+// @Param        orderBy  query     string  false  "Sort order"
+// @Success      200      {object}  http.PostListResponse
+// @Failure      400,404  {object}  response.Response
+// @Router       /categories/{slug}/posts [get]
+func (h *handlers) ListPosts(c *gin.Context) {
+	pq, err := utils.GetPaginationFromCtx(c)
+	if err != nil {
+		response.WithMappedError(c, err, category.MapError)
+		return
+	}
+
+	list, err := h.usecase.ListPosts(c.Request.Context(), c.Param("slug"), pq)
+	if err != nil {
+		response.WithMappedError(c, err, category.MapError)
+		return
+	}
+
+	response.WithOK(c, postHttp.FromPostListModel(list))
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+)
+
+type CategoryRequest struct {
+	Name        string  `json:"name" binding:"required"`
+	Slug        string  `json:"slug,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+type CategoryResponse struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	Slug        string  `json:"slug"`
+	Description *string `json:"description,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+type CategoryListResponse struct {
+	TotalCount int64              `json:"total_count"`
+	TotalPages int                `json:"total_pages"`
+	Page       int                `json:"page"`
+	Size       int                `json:"size"`
+	HasMore    bool               `json:"has_more"`
+	Categories []CategoryResponse `json:"categories"`
+}
+
+func FormatTime(time time.Time) string {
+	return time.Format("2006-01-02 15:04:05")
+}
+
+func (r *CategoryRequest) ToModel() *models.Category {
+	return &models.Category{
+		Name:        r.Name,
+		Slug:        r.Slug,
+		Description: r.Description,
+	}
+}
+
+func FromCategoryModel(c *models.Category) CategoryResponse {
+	if c == nil {
+		return CategoryResponse{}
+	}
+
+	return CategoryResponse{
+		ID:          c.ID,
+		Name:        c.Name,
+		Slug:        c.Slug,
+		Description: c.Description,
+		CreatedAt:   FormatTime(c.CreatedAt),
+		UpdatedAt:   FormatTime(c.UpdatedAt),
+	}
+}
+
+func FromCategoryListModel(list *models.CategoryList) CategoryListResponse {
+	categoryResponses := make([]CategoryResponse, len(list.Categories))
+	for i, c := range list.Categories {
+		categoryResponses[i] = FromCategoryModel(c)
+	}
+
+	return CategoryListResponse{
+		TotalCount: list.TotalCount,
+		TotalPages: list.TotalPages,
+		Page:       list.Page,
+		Size:       list.Size,
+		HasMore:    list.HasMore,
+		Categories: categoryResponses,
+	}
+}
@@ -0,0 +1,19 @@
+package http
+
+import (
+	"github.com/ductong169z/shorten-url/internal/category"
+	"github.com/ductong169z/shorten-url/internal/middleware"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Map category routes
+func MapRoutes(group *gin.RouterGroup, h category.Handlers, mw *middleware.MiddlewareManager) {
+	group.GET("", h.List)
+	group.GET("/:slug", h.GetBySlug)
+	group.GET("/:slug/posts", h.ListPosts)
+	group.Use(mw.AuthJWTMiddleware(), mw.RequirePermission(models.PermCategoriesManage))
+	group.POST("", h.Create)
+	group.PUT("/:id", h.Update)
+	group.DELETE("/:id", h.Delete)
+}
@@ -0,0 +1,62 @@
+// Package category provides core error definitions and utilities for the post categories domain.
+// It defines domain-specific error variables and error-to-HTTP status mapping for consistent error handling.
+package category
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// categoryNotFound is returned when a requested category does not exist.
+	categoryNotFound = "category not found"
+	// slugAlreadyExists is returned when another category already uses the slug.
+	slugAlreadyExists = "slug already exists"
+	// invalidName is returned when the category name is empty or too long.
+	invalidName = "invalid name"
+	// invalidSlug is returned when the slug is not URL-safe.
+	invalidSlug = "invalid slug"
+)
+
+var (
+	// ErrCategoryNotFound indicates that the category was not found.
+	ErrCategoryNotFound = errors.New(categoryNotFound)
+	// ErrSlugAlreadyExists indicates that the slug is already taken.
+	ErrSlugAlreadyExists = errors.New(slugAlreadyExists)
+	// ErrInvalidName indicates that the name is invalid.
+	ErrInvalidName = errors.New(invalidName)
+	// ErrInvalidSlug indicates that the slug is invalid.
+	ErrInvalidSlug = errors.New(invalidSlug)
+)
+
+// MapError maps a domain error to an HTTP status code and message.
+// It provides a unified way to translate domain errors to HTTP responses.
+func MapError(err error) (status int, message string) {
+	// Handle JSON binding/unmarshal errors as 400 Bad Request
+	switch err.(type) {
+	case *json.UnmarshalTypeError, *json.SyntaxError:
+		return http.StatusBadRequest, "Invalid request format"
+	case *strconv.NumError:
+		return http.StatusBadRequest, "Invalid parameter format"
+	}
+	if ginErr, ok := err.(*gin.Error); ok && ginErr.Type == gin.ErrorTypeBind {
+		return http.StatusBadRequest, "Invalid request format"
+	}
+
+	switch {
+	case errors.Is(err, ErrCategoryNotFound):
+		return http.StatusNotFound, categoryNotFound
+	case errors.Is(err, ErrSlugAlreadyExists):
+		return http.StatusConflict, slugAlreadyExists
+	case errors.Is(err, ErrInvalidName):
+		return http.StatusBadRequest, invalidName
+	case errors.Is(err, ErrInvalidSlug):
+		return http.StatusBadRequest, invalidSlug
+	default:
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: delivery.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHandlers is a mock of Handlers interface.
+type MockHandlers struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlersMockRecorder
+}
+
+// MockHandlersMockRecorder is the mock recorder for MockHandlers.
+type MockHandlersMockRecorder struct {
+	mock *MockHandlers
+}
+
+// NewMockHandlers creates a new mock instance.
+func NewMockHandlers(ctrl *gomock.Controller) *MockHandlers {
+	mock := &MockHandlers{ctrl: ctrl}
+	mock.recorder = &MockHandlersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandlers) EXPECT() *MockHandlersMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockHandlers) Create(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Create", c)
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHandlersMockRecorder) Create(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHandlers)(nil).Create), c)
+}
+
+// Delete mocks base method.
+func (m *MockHandlers) Delete(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Delete", c)
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockHandlersMockRecorder) Delete(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHandlers)(nil).Delete), c)
+}
+
+// GetBySlug mocks base method.
+func (m *MockHandlers) GetBySlug(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetBySlug", c)
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockHandlersMockRecorder) GetBySlug(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockHandlers)(nil).GetBySlug), c)
+}
+
+// List mocks base method.
+func (m *MockHandlers) List(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "List", c)
+}
+
+// List indicates an expected call of List.
+func (mr *MockHandlersMockRecorder) List(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHandlers)(nil).List), c)
+}
+
+// ListPosts mocks base method.
+func (m *MockHandlers) ListPosts(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListPosts", c)
+}
+
+// ListPosts indicates an expected call of ListPosts.
+func (mr *MockHandlersMockRecorder) ListPosts(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPosts", reflect.TypeOf((*MockHandlers)(nil).ListPosts), c)
+}
+
+// Update mocks base method.
+func (m *MockHandlers) Update(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Update", c)
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockHandlersMockRecorder) Update(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockHandlers)(nil).Update), c)
+}
@@ -0,0 +1,19 @@
+//go:generate mockgen -source mysql.go -destination mock/mysql_repository_mock.go -package mock
+package category
+
+import (
+	"context"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+type Repository interface {
+	Create(ctx context.Context, category *models.Category) (*models.Category, error)
+	Update(ctx context.Context, category *models.Category) (*models.Category, error)
+	GetByID(ctx context.Context, categoryID int) (*models.Category, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Category, error)
+	List(ctx context.Context, pq *utils.PaginationQuery) (*models.CategoryList, error)
+	Delete(ctx context.Context, categoryID int) error
+	IsSlugExist(ctx context.Context, slug string) (bool, error)
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ductong169z/shorten-url/internal/category"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// Category Repository
+type repo struct {
+	db *gorm.DB
+}
+
+// Category repository constructor
+func NewRepository(db *gorm.DB) category.Repository {
+	return &repo{db: db}
+}
+
+// Create implements category.Repository.
+func (r *repo) Create(ctx context.Context, c *models.Category) (*models.Category, error) {
+	if err := r.db.WithContext(ctx).Create(c).Error; err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Update implements category.Repository.
+func (r *repo) Update(ctx context.Context, c *models.Category) (*models.Category, error) {
+	if err := r.db.WithContext(ctx).Save(c).Error; err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetByID implements category.Repository.
+func (r *repo) GetByID(ctx context.Context, categoryID int) (*models.Category, error) {
+	var c models.Category
+	if err := r.db.WithContext(ctx).Where("id = ?", categoryID).First(&c).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, category.ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+// GetBySlug implements category.Repository.
+func (r *repo) GetBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	var c models.Category
+	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&c).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, category.ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+// List implements category.Repository.
+func (r *repo) List(ctx context.Context, pq *utils.PaginationQuery) (*models.CategoryList, error) {
+	var totalCount int64
+	if err := r.db.WithContext(ctx).Model(&models.Category{}).Count(&totalCount).Error; err != nil {
+		return nil, err
+	}
+
+	list := &models.CategoryList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Categories: make([]*models.Category, 0),
+	}
+	if totalCount == 0 {
+		return list, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Order("name ASC").
+		Offset(pq.GetOffset()).
+		Limit(pq.GetLimit()).
+		Find(&list.Categories).Error; err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Delete implements category.Repository.
+func (r *repo) Delete(ctx context.Context, categoryID int) error {
+	result := r.db.WithContext(ctx).Where("id = ?", categoryID).Delete(&models.Category{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return category.ErrCategoryNotFound
+	}
+	return nil
+}
+
+// IsSlugExist implements category.Repository.
+func (r *repo) IsSlugExist(ctx context.Context, slug string) (bool, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.Category{}).
+		Where("slug = ?", slug).
+		Count(&count).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
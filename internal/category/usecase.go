@@ -0,0 +1,18 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package category
+
+import (
+	"context"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+type UseCase interface {
+	Create(ctx context.Context, category *models.Category) (*models.Category, error)
+	Update(ctx context.Context, category *models.Category) (*models.Category, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Category, error)
+	List(ctx context.Context, pq *utils.PaginationQuery) (*models.CategoryList, error)
+	Delete(ctx context.Context, categoryID int) error
+	ListPosts(ctx context.Context, slug string, pq *utils.PaginationQuery) (*models.PostList, error)
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/category"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/posts"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/slug"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+const (
+	maxNameLength = 100
+	maxSlugLength = 120
+)
+
+type usecase struct {
+	cfg       *config.Config
+	repo      category.Repository
+	postRepo  posts.Repository
+	logger    logger.Logger
+	observers []posts.Observer
+}
+
+// Category UseCase constructor; observers are notified as category names and URLs appear in feeds and sitemaps
+func NewUseCase(cfg *config.Config, repo category.Repository, postRepo posts.Repository, logger logger.Logger, observers ...posts.Observer) category.UseCase {
+	return &usecase{cfg: cfg, repo: repo, postRepo: postRepo, logger: logger, observers: observers}
+}
+
+// Create implements category.UseCase.
+// Without a slug one is generated from the name.
+func (u *usecase) Create(ctx context.Context, c *models.Category) (*models.Category, error) {
+	generated := c.Slug == ""
+	if generated {
+		c.Slug = slug.Truncate(slug.MakeOr(c.Name, "category"), maxSlugLength)
+	}
+
+	if err := validateCategory(c); err != nil {
+		return nil, err
+	}
+
+	if generated {
+		unique, err := slug.Unique(c.Slug, maxSlugLength, func(s string) (bool, error) {
+			return u.repo.IsSlugExist(ctx, s)
+		})
+		if err != nil {
+			return nil, err
+		}
+		c.Slug = unique
+	} else {
+		exist, err := u.repo.IsSlugExist(ctx, c.Slug)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			return nil, category.ErrSlugAlreadyExists
+		}
+	}
+
+	created, err := u.repo.Create(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	u.notify(ctx)
+	return created, nil
+}
+
+// Update implements category.UseCase.
+// Without a slug the current one is kept.
+func (u *usecase) Update(ctx context.Context, c *models.Category) (*models.Category, error) {
+	existing, err := u.repo.GetByID(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Slug == "" {
+		c.Slug = existing.Slug
+	}
+	if err := validateCategory(c); err != nil {
+		return nil, err
+	}
+
+	if c.Slug != existing.Slug {
+		exist, err := u.repo.IsSlugExist(ctx, c.Slug)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			return nil, category.ErrSlugAlreadyExists
+		}
+	}
+
+	existing.Name = c.Name
+	existing.Slug = c.Slug
+	existing.Description = c.Description
+
+	updated, err := u.repo.Update(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+	u.notify(ctx)
+	return updated, nil
+}
+
+// GetBySlug implements category.UseCase.
+func (u *usecase) GetBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	return u.repo.GetBySlug(ctx, slug)
+}
+
+// List implements category.UseCase.
+func (u *usecase) List(ctx context.Context, pq *utils.PaginationQuery) (*models.CategoryList, error) {
+	return u.repo.List(ctx, pq)
+}
+
+// Delete implements category.UseCase.
+func (u *usecase) Delete(ctx context.Context, categoryID int) error {
+	if err := u.repo.Delete(ctx, categoryID); err != nil {
+		return err
+	}
+	u.notify(ctx)
+	return nil
+}
+
+// ListPosts implements category.UseCase.
+func (u *usecase) ListPosts(ctx context.Context, slug string, pq *utils.PaginationQuery) (*models.PostList, error) {
+	c, err := u.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &models.PostFilter{
+		Status:     models.PostStatusPublished,
+		CategoryID: c.ID,
+	}
+	return u.postRepo.List(ctx, filter, pq)
+}
+
+func (u *usecase) notify(ctx context.Context) {
+	for _, o := range u.observers {
+		o.PostsChanged(ctx)
+	}
+}
+
+func validateCategory(c *models.Category) error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" || utf8.RuneCountInString(c.Name) > maxNameLength {
+		return category.ErrInvalidName
+	}
+	if !slug.IsValid(c.Slug, maxSlugLength) {
+		return category.ErrInvalidSlug
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/category"
+	mock "github.com/ductong169z/shorten-url/internal/category/mock"
+	"github.com/ductong169z/shorten-url/internal/models"
+	postMock "github.com/ductong169z/shorten-url/internal/posts/mock"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseCase_Create(t *testing.T) {
+	tcs := map[string]struct {
+		input     *models.Category
+		taken     map[string]bool
+		expChecks []string
+		expSlug   string
+		expErr    error
+	}{
+		"slug generated from the name": {
+			input:     &models.Category{Name: "  Go Tips & Tricks "},
+			expChecks: []string{"go-tips-and-tricks"},
+			expSlug:   "go-tips-and-tricks",
+		},
+		"generated slug gets a suffix when taken": {
+			input:     &models.Category{Name: "News"},
+			taken:     map[string]bool{"news": true},
+			expChecks: []string{"news", "news-2"},
+			expSlug:   "news-2",
+		},
+		"explicit slug": {
+			input:     &models.Category{Name: "News", Slug: "latest"},
+			expChecks: []string{"latest"},
+			expSlug:   "latest",
+		},
+		"explicit slug already exists": {
+			input:     &models.Category{Name: "News", Slug: "latest"},
+			taken:     map[string]bool{"latest": true},
+			expChecks: []string{"latest"},
+			expErr:    category.ErrSlugAlreadyExists,
+		},
+		"invalid slug": {
+			input:  &models.Category{Name: "News", Slug: "Latest News"},
+			expErr: category.ErrInvalidSlug,
+		},
+		"slug too long": {
+			input:  &models.Category{Name: "News", Slug: strings.Repeat("a", maxSlugLength+1)},
+			expErr: category.ErrInvalidSlug,
+		},
+		"empty name": {
+			input:  &models.Category{Name: "   ", Slug: "news"},
+			expErr: category.ErrInvalidName,
+		},
+		"name too long": {
+			input:  &models.Category{Name: strings.Repeat("a", maxNameLength+1), Slug: "news"},
+			expErr: category.ErrInvalidName,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			observer := postMock.NewMockObserver(ctrl)
+			uc := NewUseCase(cfg, repo, nil, apiLogger, observer)
+
+			for _, s := range tc.expChecks {
+				repo.EXPECT().IsSlugExist(gomock.Any(), s).Return(tc.taken[s], nil)
+			}
+			if tc.expErr == nil {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, c *models.Category) (*models.Category, error) {
+						c.ID = 5
+						return c, nil
+					},
+				)
+				observer.EXPECT().PostsChanged(gomock.Any())
+			}
+
+			created, err := uc.Create(context.Background(), tc.input)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 5, created.ID)
+			assert.Equal(t, tc.expSlug, created.Slug)
+		})
+	}
+}
+
+func TestUseCase_CreateNonLatinName(t *testing.T) {
+	cfg := &config.Config{}
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	observer := postMock.NewMockObserver(ctrl)
+	uc := NewUseCase(cfg, repo, nil, apiLogger, observer)
+
+	repo.EXPECT().IsSlugExist(gomock.Any(), gomock.Any()).Return(false, nil)
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, c *models.Category) (*models.Category, error) { return c, nil },
+	)
+	observer.EXPECT().PostsChanged(gomock.Any())
+
+	created, err := uc.Create(context.Background(), &models.Category{Name: "日本語"})
+	require.NoError(t, err)
+	assert.Equal(t, "日本語", created.Name)
+	assert.Regexp(t, `^category-[0-9]+$`, created.Slug)
+}
+
+func TestUseCase_Update(t *testing.T) {
+	existing := func() *models.Category {
+		return &models.Category{ID: 5, Name: "News", Slug: "news", Description: strPtr("old")}
+	}
+
+	tcs := map[string]struct {
+		input    *models.Category
+		getErr   error
+		expCheck string
+		taken    bool
+		exp      *models.Category
+		expErr   error
+	}{
+		"keeps the slug when none is given": {
+			input: &models.Category{ID: 5, Name: "Latest News", Description: strPtr("new")},
+			exp:   &models.Category{ID: 5, Name: "Latest News", Slug: "news", Description: strPtr("new")},
+		},
+		"unchanged slug is not checked": {
+			input: &models.Category{ID: 5, Name: "News", Slug: "news"},
+			exp:   &models.Category{ID: 5, Name: "News", Slug: "news"},
+		},
+		"new slug": {
+			input:    &models.Category{ID: 5, Name: "News", Slug: "latest"},
+			expCheck: "latest",
+			exp:      &models.Category{ID: 5, Name: "News", Slug: "latest"},
+		},
+		"new slug already exists": {
+			input:    &models.Category{ID: 5, Name: "News", Slug: "latest"},
+			expCheck: "latest",
+			taken:    true,
+			expErr:   category.ErrSlugAlreadyExists,
+		},
+		"invalid slug": {
+			input:  &models.Category{ID: 5, Name: "News", Slug: "-news"},
+			expErr: category.ErrInvalidSlug,
+		},
+		"not found": {
+			input:  &models.Category{ID: 5, Name: "News"},
+			getErr: category.ErrCategoryNotFound,
+			expErr: category.ErrCategoryNotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			observer := postMock.NewMockObserver(ctrl)
+			uc := NewUseCase(cfg, repo, nil, apiLogger, observer)
+
+			if tc.getErr != nil {
+				repo.EXPECT().GetByID(gomock.Any(), 5).Return(nil, tc.getErr)
+			} else {
+				repo.EXPECT().GetByID(gomock.Any(), 5).Return(existing(), nil)
+			}
+			if tc.expCheck != "" {
+				repo.EXPECT().IsSlugExist(gomock.Any(), tc.expCheck).Return(tc.taken, nil)
+			}
+			if tc.expErr == nil {
+				repo.EXPECT().Update(gomock.Any(), tc.exp).Return(tc.exp, nil)
+				observer.EXPECT().PostsChanged(gomock.Any())
+			}
+
+			updated, err := uc.Update(context.Background(), tc.input)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, updated)
+		})
+	}
+}
+
+func TestUseCase_Delete(t *testing.T) {
+	tcs := map[string]struct {
+		deleteErr error
+	}{
+		"success": {},
+		"not found": {
+			deleteErr: category.ErrCategoryNotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			observer := postMock.NewMockObserver(ctrl)
+			uc := NewUseCase(cfg, repo, nil, apiLogger, observer)
+
+			repo.EXPECT().Delete(gomock.Any(), 5).Return(tc.deleteErr)
+			if tc.deleteErr == nil {
+				observer.EXPECT().PostsChanged(gomock.Any())
+			}
+
+			err := uc.Delete(context.Background(), 5)
+			assert.ErrorIs(t, err, tc.deleteErr)
+		})
+	}
+}
+
+func TestUseCase_ListPosts(t *testing.T) {
+	cfg := &config.Config{}
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock.NewMockRepository(ctrl)
+	postRepo := postMock.NewMockRepository(ctrl)
+	uc := NewUseCase(cfg, repo, postRepo, apiLogger)
+
+	repo.EXPECT().GetBySlug(gomock.Any(), "missing").Return(nil, category.ErrCategoryNotFound)
+	_, err := uc.ListPosts(context.Background(), "missing", nil)
+	assert.ErrorIs(t, err, category.ErrCategoryNotFound)
+
+	list := &models.PostList{}
+	repo.EXPECT().GetBySlug(gomock.Any(), "news").Return(&models.Category{ID: 5, Slug: "news"}, nil)
+	postRepo.EXPECT().List(gomock.Any(), &models.PostFilter{Status: models.PostStatusPublished, CategoryID: 5}, nil).Return(list, nil)
+	got, err := uc.ListPosts(context.Background(), "news", nil)
+	require.NoError(t, err)
+	assert.Same(t, list, got)
+}
+
+func strPtr(s string) *string {
+	return &s
+}
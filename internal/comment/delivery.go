@@ -0,0 +1,15 @@
+//go:generate mockgen -source delivery.go -destination mock/handlers_mock.go -package mock
+package comment
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type Handlers interface {
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	ListTree(c *gin.Context)
+	ListPending(c *gin.Context)
+	Moderate(c *gin.Context)
+}
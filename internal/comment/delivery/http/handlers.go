@@ -0,0 +1,210 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/comment"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/response"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Comment handlers
+type handlers struct {
+	cfg     *config.Config
+	usecase comment.UseCase
+	logger  logger.Logger
+}
+
+// NewHandlers Comment handlers constructor
+func NewHandlers(cfg *config.Config, usecase comment.UseCase, logger logger.Logger) comment.Handlers {
+	return &handlers{cfg: cfg, usecase: usecase, logger: logger}
+}
+
+// Create godoc
+// @Summary      Create comment
+// @Description  Comment on a published post or reply to a comment; guests must provide author_name
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id              path      int             true  "Post ID"
+// @Param        commentRequest  body      CommentRequest  true  "Comment payload"
+// @Success      201             {object}  CommentResponse
+// @Failure      400,401,404     {object}  response.Response
+// @Router       /posts/{id}/comments [post]
+func (h *handlers) Create(c *gin.Context) {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithMappedError(c, err, comment.MapError)
+		return
+	}
+
+	var req CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, comment.MapError)
+		return
+	}
+
+	created, err := h.usecase.Create(c.Request.Context(), postID, req.ToModel())
+	if err != nil {
+		response.WithMappedError(c, err, comment.MapError)
+		return
+	}
+
+	response.WithCode(c, http.StatusCreated, FromCommentModel(created))
+}
+
+// Update godoc
+// @Summary      Update comment
+// @Description  Edit the content of a comment (author or admin)
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id                    path      int                   true  "Comment ID"
+// @Param        commentUpdateRequest  body      CommentUpdateRequest  true  "Comment payload"
+// @Success      200                   {object}  CommentResponse
+// @Failure      400,401,403,404       {object}  response.Response
+// @Router       /comments/{id} [put]
+func (h *handlers) Update(c *gin.Context) {
+	commentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithMappedError(c, err, comment.MapError)
+		return
+	}
+
+	var req CommentUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, comment.MapError)
+		return
+	}
+
+	updated, err := h.usecase.Update(c.Request.Context(), &models.Comment{ID: commentID, Content: req.Content})
+	if err != nil {
+		response.WithMappedError(c, err, comment.MapError)
+		return
+	}
+
+	response.WithOK(c, FromCommentModel(updated))
+}
+
+// Delete godoc
+// @Summary      Delete comment
+// @Description  Delete a comment and its replies (author or admin)
+// @Tags         comments
+// @Param        id   path  int  true  "Comment ID"
+// @Success      204
+// @Failure      400,401,403,404  {object}  response.Response
+// @Router       /comments/{id} [delete]
+func (h *handlers) Delete(c *gin.Context) {
+	commentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithMappedError(c, err, comment.MapError)
+		return
+	}
+
+	if err := h.usecase.Delete(c.Request.Context(), commentID); err != nil {
+		response.WithMappedError(c, err, comment.MapError)
+		return
+	}
+
+	response.WithNoContent(c)
+}
+
+// ListTree godoc
+// @Summary      List post comments
+// @Description  List the comment tree of a published post; top-level comments are paginated
+// @Tags         comments
+// @Produce      json
+// @Param        slug     path      string  true   "Post slug"
+// @Param        depth    query     int     false  "Maximum reply depth"
+// @Param        page     query     int     false  "Page number"
+// @Param        size     query     int     false  "Page size"
+// @Success      200      {object}  CommentListResponse
+// @Failure      400,404  {object}  response.Response
+// @Router       /posts/{slug}/comments [get]
+func (h *handlers) ListTree(c *gin.Context) {
+	pq, err := utils.GetPaginationFromCtx(c)
+	if err != nil {
+		response.WithMappedError(c, err, comment.MapError)
+		return
+	}
+
+	depth := 0
+	if d := c.Query("depth"); d != "" {
+		depth, err = strconv.Atoi(d)
+		if err != nil {
+			response.WithMappedError(c, err, comment.MapError)
+			return
+		}
+	}
+
+	list, err := h.usecase.ListTree(c.Request.Context(), c.Param("slug"), depth, pq)
+	if err != nil {
+		response.WithMappedError(c, err, comment.MapError)
+		return
+	}
+
+	response.WithOK(c, FromCommentListModel(list))
+}
+
+// ListPending godoc
+// @Summary      List pending comments
+// @Description  List comments waiting for moderation, oldest first (admin only)
+// @Tags         comments
+// @Produce      json
+// @Param        page         query     int  false  "Page number"
+// @Param        size         query     int  false  "Page size"
+// @Success      200          {object}  CommentListResponse
+// @Failure      400,401,403  {object}  response.Response
+// @Router       /comments/pending [get]
+func (h *handlers) ListPending(c *gin.Context) {
+	pq, err := utils.GetPaginationFromCtx(c)
+	if err != nil {
+		response.WithMappedError(c, err, comment.MapError)
+		return
+	}
+
+	list, err := h.usecase.ListPending(c.Request.Context(), pq)
+	if err != nil {
+		response.WithMappedError(c, err, comment.MapError)
+		return
+	}
+
+	response.WithOK(c, FromCommentListModel(list))
+}
+
+// Moderate godoc
+// @Summary      Moderate comments
+// @Description  Set the status of several comments to approved, rejected or spam (admin only)
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        moderationRequest  body      ModerationRequest  true  "Comment IDs and status"
+// @Success      200                {object}  ModerationResponse
+// @Failure      400,401,403        {object}  response.Response
+// @Router       /comments/moderate [post]
+func (h *handlers) Moderate(c *gin.Context) {
+	var req ModerationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, comment.MapError)
+		return
+	}
+
+	status, err := models.ParseCommentStatus(req.Status)
+	if err != nil {
+		response.WithMappedError(c, comment.ErrInvalidModeration, comment.MapError)
+		return
+	}
+
+	updated, err := h.usecase.Moderate(c.Request.Context(), req.IDs, status)
+	if err != nil {
+		response.WithMappedError(c, err, comment.MapError)
+		return
+	}
+
+	response.WithOK(c, ModerationResponse{Updated: updated})
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+)
+
+type CommentRequest struct {
+	Content     string  `json:"content" binding:"required"`
+	ParentID    *int    `json:"parent_id,omitempty"`
+	AuthorName  *string `json:"author_name,omitempty"`
+	AuthorEmail *string `json:"author_email,omitempty"`
+}
+
+type CommentUpdateRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
+type ModerationRequest struct {
+	IDs    []int  `json:"ids" binding:"required"`
+	Status string `json:"status" binding:"required"`
+}
+
+type ModerationResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+type CommentResponse struct {
+	ID         int               `json:"id"`
+	PostID     int               `json:"post_id"`
+	ParentID   *int              `json:"parent_id,omitempty"`
+	UserID     *int              `json:"user_id,omitempty"`
+	AuthorName string            `json:"author_name"`
+	Content    string            `json:"content"`
+	Status     string            `json:"status"`
+	CreatedAt  string            `json:"created_at"`
+	UpdatedAt  string            `json:"updated_at"`
+	Replies    []CommentResponse `json:"replies"`
+}
+
+type CommentListResponse struct {
+	TotalCount int64             `json:"total_count"`
+	TotalPages int               `json:"total_pages"`
+	Page       int               `json:"page"`
+	Size       int               `json:"size"`
+	HasMore    bool              `json:"has_more"`
+	Comments   []CommentResponse `json:"comments"`
+}
+
+func FormatTime(time time.Time) string {
+	return time.Format("2006-01-02 15:04:05")
+}
+
+func (r *CommentRequest) ToModel() *models.Comment {
+	return &models.Comment{
+		Content:     r.Content,
+		ParentID:    r.ParentID,
+		AuthorName:  r.AuthorName,
+		AuthorEmail: r.AuthorEmail,
+	}
+}
+
+// FromCommentModel converts a comment and its loaded replies; guest emails are never exposed
+func FromCommentModel(c *models.Comment) CommentResponse {
+	if c == nil {
+		return CommentResponse{}
+	}
+
+	authorName := c.Username
+	if c.IsGuest() && c.AuthorName != nil {
+		authorName = *c.AuthorName
+	}
+
+	return CommentResponse{
+		ID:         c.ID,
+		PostID:     c.PostID,
+		ParentID:   c.ParentID,
+		UserID:     c.UserID,
+		AuthorName: authorName,
+		Content:    c.Content,
+		Status:     c.Status.String(),
+		CreatedAt:  FormatTime(c.CreatedAt),
+		UpdatedAt:  FormatTime(c.UpdatedAt),
+		Replies:    FromCommentModelList(c.Replies),
+	}
+}
+
+func FromCommentModelList(comments []*models.Comment) []CommentResponse {
+	commentResponses := make([]CommentResponse, len(comments))
+	for i, c := range comments {
+		commentResponses[i] = FromCommentModel(c)
+	}
+	return commentResponses
+}
+
+func FromCommentListModel(list *models.CommentList) CommentListResponse {
+	return CommentListResponse{
+		TotalCount: list.TotalCount,
+		TotalPages: list.TotalPages,
+		Page:       list.Page,
+		Size:       list.Size,
+		HasMore:    list.HasMore,
+		Comments:   FromCommentModelList(list.Comments),
+	}
+}
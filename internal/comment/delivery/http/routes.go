@@ -0,0 +1,20 @@
+package http
+
+import (
+	"github.com/ductong169z/shorten-url/internal/comment"
+	"github.com/ductong169z/shorten-url/internal/middleware"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Map comment routes; postGroup must be a /posts group without middlewares
+func MapRoutes(group *gin.RouterGroup, postGroup *gin.RouterGroup, h comment.Handlers, mw *middleware.MiddlewareManager) {
+	postGroup.GET("/:slug/comments", h.ListTree)
+	postGroup.POST("/:id/comments", mw.OptionalAuthJWTMiddleware(), h.Create)
+
+	group.Use(mw.AuthJWTMiddleware())
+	group.PUT("/:id", h.Update)
+	group.DELETE("/:id", h.Delete)
+	group.GET("/pending", mw.RequirePermission(models.PermCommentsModerate), h.ListPending)
+	group.POST("/moderate", mw.RequirePermission(models.PermCommentsModerate), h.Moderate)
+}
@@ -0,0 +1,71 @@
+// Package comment provides core error definitions and utilities for the post comments domain.
+// It defines domain-specific error variables and error-to-HTTP status mapping for consistent error handling.
+package comment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ductong169z/shorten-url/internal/posts"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// commentNotFound is returned when a requested comment does not exist.
+	commentNotFound = "comment not found"
+	// invalidContent is returned when the comment content is empty or too long.
+	invalidContent = "invalid content"
+	// invalidAuthor is returned when a guest comment has no valid author name or email.
+	invalidAuthor = "invalid author"
+	// invalidParent is returned when the parent comment belongs to another post.
+	invalidParent = "invalid parent comment"
+	// invalidModeration is returned when a moderation request has no comments, too many or a bad status.
+	invalidModeration = "invalid moderation request"
+)
+
+var (
+	// ErrCommentNotFound indicates that the comment was not found.
+	ErrCommentNotFound = errors.New(commentNotFound)
+	// ErrInvalidContent indicates that the content is invalid.
+	ErrInvalidContent = errors.New(invalidContent)
+	// ErrInvalidAuthor indicates that the guest author details are invalid.
+	ErrInvalidAuthor = errors.New(invalidAuthor)
+	// ErrInvalidParent indicates that the parent comment is invalid.
+	ErrInvalidParent = errors.New(invalidParent)
+	// ErrInvalidModeration indicates that the moderation request is invalid.
+	ErrInvalidModeration = errors.New(invalidModeration)
+)
+
+// MapError maps a domain error to an HTTP status code and message.
+// It provides a unified way to translate domain errors to HTTP responses.
+func MapError(err error) (status int, message string) {
+	// Handle JSON binding/unmarshal errors as 400 Bad Request
+	switch err.(type) {
+	case *json.UnmarshalTypeError, *json.SyntaxError:
+		return http.StatusBadRequest, "Invalid request format"
+	case *strconv.NumError:
+		return http.StatusBadRequest, "Invalid parameter format"
+	}
+	if ginErr, ok := err.(*gin.Error); ok && ginErr.Type == gin.ErrorTypeBind {
+		return http.StatusBadRequest, "Invalid request format"
+	}
+
+	switch {
+	case errors.Is(err, ErrCommentNotFound):
+		return http.StatusNotFound, commentNotFound
+	case errors.Is(err, posts.ErrPostNotFound):
+		return http.StatusNotFound, posts.ErrPostNotFound.Error()
+	case errors.Is(err, ErrInvalidContent):
+		return http.StatusBadRequest, invalidContent
+	case errors.Is(err, ErrInvalidAuthor):
+		return http.StatusBadRequest, invalidAuthor
+	case errors.Is(err, ErrInvalidParent):
+		return http.StatusBadRequest, invalidParent
+	case errors.Is(err, ErrInvalidModeration):
+		return http.StatusBadRequest, invalidModeration
+	default:
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mysql.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ductong169z/shorten-url/internal/models"
+	utils "github.com/ductong169z/shorten-url/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, comment)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, comment)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, commentID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, commentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, commentID)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, commentID int) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, commentID)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, commentID)
+}
+
+// HasApproved mocks base method.
+func (m *MockRepository) HasApproved(ctx context.Context, userID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasApproved", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasApproved indicates an expected call of HasApproved.
+func (mr *MockRepositoryMockRecorder) HasApproved(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasApproved", reflect.TypeOf((*MockRepository)(nil).HasApproved), ctx, userID)
+}
+
+// ListByParentIDs mocks base method.
+func (m *MockRepository) ListByParentIDs(ctx context.Context, parentIDs []int, status models.CommentStatus) ([]*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByParentIDs", ctx, parentIDs, status)
+	ret0, _ := ret[0].([]*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByParentIDs indicates an expected call of ListByParentIDs.
+func (mr *MockRepositoryMockRecorder) ListByParentIDs(ctx, parentIDs, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParentIDs", reflect.TypeOf((*MockRepository)(nil).ListByParentIDs), ctx, parentIDs, status)
+}
+
+// ListByStatus mocks base method.
+func (m *MockRepository) ListByStatus(ctx context.Context, status models.CommentStatus, pq *utils.PaginationQuery) (*models.CommentList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByStatus", ctx, status, pq)
+	ret0, _ := ret[0].(*models.CommentList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByStatus indicates an expected call of ListByStatus.
+func (mr *MockRepositoryMockRecorder) ListByStatus(ctx, status, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStatus", reflect.TypeOf((*MockRepository)(nil).ListByStatus), ctx, status, pq)
+}
+
+// ListRoots mocks base method.
+func (m *MockRepository) ListRoots(ctx context.Context, postID int, status models.CommentStatus, pq *utils.PaginationQuery) (*models.CommentList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoots", ctx, postID, status, pq)
+	ret0, _ := ret[0].(*models.CommentList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoots indicates an expected call of ListRoots.
+func (mr *MockRepositoryMockRecorder) ListRoots(ctx, postID, status, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoots", reflect.TypeOf((*MockRepository)(nil).ListRoots), ctx, postID, status, pq)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, comment)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, comment)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockRepository) UpdateStatus(ctx context.Context, commentIDs []int, status models.CommentStatus) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, commentIDs, status)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockRepositoryMockRecorder) UpdateStatus(ctx, commentIDs, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockRepository)(nil).UpdateStatus), ctx, commentIDs, status)
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ductong169z/shorten-url/internal/models"
+	utils "github.com/ductong169z/shorten-url/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUseCase) Create(ctx context.Context, postID int, comment *models.Comment) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, postID, comment)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUseCaseMockRecorder) Create(ctx, postID, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUseCase)(nil).Create), ctx, postID, comment)
+}
+
+// Delete mocks base method.
+func (m *MockUseCase) Delete(ctx context.Context, commentID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, commentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUseCaseMockRecorder) Delete(ctx, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUseCase)(nil).Delete), ctx, commentID)
+}
+
+// ListPending mocks base method.
+func (m *MockUseCase) ListPending(ctx context.Context, pq *utils.PaginationQuery) (*models.CommentList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, pq)
+	ret0, _ := ret[0].(*models.CommentList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockUseCaseMockRecorder) ListPending(ctx, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockUseCase)(nil).ListPending), ctx, pq)
+}
+
+// ListTree mocks base method.
+func (m *MockUseCase) ListTree(ctx context.Context, postSlug string, depth int, pq *utils.PaginationQuery) (*models.CommentList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTree", ctx, postSlug, depth, pq)
+	ret0, _ := ret[0].(*models.CommentList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTree indicates an expected call of ListTree.
+func (mr *MockUseCaseMockRecorder) ListTree(ctx, postSlug, depth, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTree", reflect.TypeOf((*MockUseCase)(nil).ListTree), ctx, postSlug, depth, pq)
+}
+
+// Moderate mocks base method.
+func (m *MockUseCase) Moderate(ctx context.Context, commentIDs []int, status models.CommentStatus) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Moderate", ctx, commentIDs, status)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Moderate indicates an expected call of Moderate.
+func (mr *MockUseCaseMockRecorder) Moderate(ctx, commentIDs, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Moderate", reflect.TypeOf((*MockUseCase)(nil).Moderate), ctx, commentIDs, status)
+}
+
+// Update mocks base method.
+func (m *MockUseCase) Update(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, comment)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUseCaseMockRecorder) Update(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUseCase)(nil).Update), ctx, comment)
+}
@@ -0,0 +1,21 @@
+//go:generate mockgen -source mysql.go -destination mock/mysql_repository_mock.go -package mock
+package comment
+
+import (
+	"context"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+type Repository interface {
+	Create(ctx context.Context, comment *models.Comment) (*models.Comment, error)
+	Update(ctx context.Context, comment *models.Comment) (*models.Comment, error)
+	GetByID(ctx context.Context, commentID int) (*models.Comment, error)
+	ListRoots(ctx context.Context, postID int, status models.CommentStatus, pq *utils.PaginationQuery) (*models.CommentList, error)
+	ListByParentIDs(ctx context.Context, parentIDs []int, status models.CommentStatus) ([]*models.Comment, error)
+	ListByStatus(ctx context.Context, status models.CommentStatus, pq *utils.PaginationQuery) (*models.CommentList, error)
+	UpdateStatus(ctx context.Context, commentIDs []int, status models.CommentStatus) (int64, error)
+	HasApproved(ctx context.Context, userID int) (bool, error)
+	Delete(ctx context.Context, commentID int) error
+}
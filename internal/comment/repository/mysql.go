@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ductong169z/shorten-url/internal/comment"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// Comment Repository
+type repo struct {
+	db *gorm.DB
+}
+
+// Comment repository constructor
+func NewRepository(db *gorm.DB) comment.Repository {
+	return &repo{db: db}
+}
+
+// withAuthor selects comments together with the username of registered authors
+func withAuthor(db *gorm.DB) *gorm.DB {
+	return db.Select("comments.*, users.username AS username").
+		Joins("LEFT JOIN users ON users.id = comments.user_id")
+}
+
+// Create implements comment.Repository.
+func (r *repo) Create(ctx context.Context, c *models.Comment) (*models.Comment, error) {
+	if err := r.db.WithContext(ctx).Create(c).Error; err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Update implements comment.Repository.
+func (r *repo) Update(ctx context.Context, c *models.Comment) (*models.Comment, error) {
+	if err := r.db.WithContext(ctx).Save(c).Error; err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetByID implements comment.Repository.
+func (r *repo) GetByID(ctx context.Context, commentID int) (*models.Comment, error) {
+	var c models.Comment
+	if err := r.db.WithContext(ctx).Scopes(withAuthor).Where("comments.id = ?", commentID).First(&c).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, comment.ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+// ListRoots implements comment.Repository.
+func (r *repo) ListRoots(ctx context.Context, postID int, status models.CommentStatus, pq *utils.PaginationQuery) (*models.CommentList, error) {
+	var totalCount int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.Comment{}).
+		Where("post_id = ? AND parent_id IS NULL AND status = ?", postID, status).
+		Count(&totalCount).Error; err != nil {
+		return nil, err
+	}
+
+	list := &models.CommentList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Comments:   make([]*models.Comment, 0),
+	}
+	if totalCount == 0 {
+		return list, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Scopes(withAuthor).
+		Where("comments.post_id = ? AND comments.parent_id IS NULL AND comments.status = ?", postID, status).
+		Order("comments.created_at ASC, comments.id ASC").
+		Offset(pq.GetOffset()).
+		Limit(pq.GetLimit()).
+		Find(&list.Comments).Error; err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// ListByParentIDs implements comment.Repository.
+func (r *repo) ListByParentIDs(ctx context.Context, parentIDs []int, status models.CommentStatus) ([]*models.Comment, error) {
+	comments := make([]*models.Comment, 0)
+	if len(parentIDs) == 0 {
+		return comments, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Scopes(withAuthor).
+		Where("comments.parent_id IN ? AND comments.status = ?", parentIDs, status).
+		Order("comments.created_at ASC, comments.id ASC").
+		Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// ListByStatus implements comment.Repository.
+// Oldest comments come first so the moderation queue is worked in order.
+func (r *repo) ListByStatus(ctx context.Context, status models.CommentStatus, pq *utils.PaginationQuery) (*models.CommentList, error) {
+	var totalCount int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.Comment{}).
+		Where("status = ?", status).
+		Count(&totalCount).Error; err != nil {
+		return nil, err
+	}
+
+	list := &models.CommentList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Comments:   make([]*models.Comment, 0),
+	}
+	if totalCount == 0 {
+		return list, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Scopes(withAuthor).
+		Where("comments.status = ?", status).
+		Order("comments.created_at ASC, comments.id ASC").
+		Offset(pq.GetOffset()).
+		Limit(pq.GetLimit()).
+		Find(&list.Comments).Error; err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// UpdateStatus implements comment.Repository.
+func (r *repo) UpdateStatus(ctx context.Context, commentIDs []int, status models.CommentStatus) (int64, error) {
+	if len(commentIDs) == 0 {
+		return 0, nil
+	}
+	result := r.db.WithContext(ctx).
+		Model(&models.Comment{}).
+		Where("id IN ?", commentIDs).
+		Update("status", status)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// HasApproved implements comment.Repository.
+func (r *repo) HasApproved(ctx context.Context, userID int) (bool, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.Comment{}).
+		Where("user_id = ? AND status = ?", userID, models.CommentStatusApproved).
+		Limit(1).
+		Count(&count).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// Delete implements comment.Repository.
+// Replies are removed by the fk_comments_parent_id cascade.
+func (r *repo) Delete(ctx context.Context, commentID int) error {
+	result := r.db.WithContext(ctx).Where("id = ?", commentID).Delete(&models.Comment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return comment.ErrCommentNotFound
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package comment
+
+import (
+	"context"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+type UseCase interface {
+	Create(ctx context.Context, postID int, comment *models.Comment) (*models.Comment, error)
+	Update(ctx context.Context, comment *models.Comment) (*models.Comment, error)
+	Delete(ctx context.Context, commentID int) error
+	ListTree(ctx context.Context, postSlug string, depth int, pq *utils.PaginationQuery) (*models.CommentList, error)
+	ListPending(ctx context.Context, pq *utils.PaginationQuery) (*models.CommentList, error)
+	Moderate(ctx context.Context, commentIDs []int, status models.CommentStatus) (int64, error)
+}
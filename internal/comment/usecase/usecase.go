@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/comment"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/posts"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+const (
+	maxContentLength     = 5000
+	maxAuthorNameLength  = 100
+	maxAuthorEmailLength = 255
+	defaultTreeDepth     = 3
+	maxTreeDepth         = 10
+	maxModerationBatch   = 100
+)
+
+type usecase struct {
+	cfg      *config.Config
+	repo     comment.Repository
+	postRepo posts.Repository
+	logger   logger.Logger
+}
+
+// Comment UseCase constructor
+func NewUseCase(cfg *config.Config, repo comment.Repository, postRepo posts.Repository, logger logger.Logger) comment.UseCase {
+	return &usecase{cfg: cfg, repo: repo, postRepo: postRepo, logger: logger}
+}
+
+// Create implements comment.UseCase.
+// Logged-in users comment as themselves, guests must give an author name.
+// New comments wait for moderation unless the author qualifies for auto-approval.
+func (u *usecase) Create(ctx context.Context, postID int, c *models.Comment) (*models.Comment, error) {
+	post, err := u.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.Status != models.PostStatusPublished {
+		return nil, posts.ErrPostNotFound
+	}
+
+	c.Content = strings.TrimSpace(c.Content)
+	if err := validateContent(c.Content); err != nil {
+		return nil, err
+	}
+
+	if user, err := utils.GetUserFromCtx(ctx); err == nil {
+		c.UserID = &user.ID
+		c.AuthorName = nil
+		c.AuthorEmail = nil
+		c.Status, err = u.initialStatus(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		c.UserID = nil
+		if err := validateGuest(c); err != nil {
+			return nil, err
+		}
+		c.Status = models.CommentStatusPending
+	}
+
+	if c.ParentID != nil {
+		parent, err := u.repo.GetByID(ctx, *c.ParentID)
+		if err != nil {
+			if errors.Is(err, comment.ErrCommentNotFound) {
+				return nil, comment.ErrInvalidParent
+			}
+			return nil, err
+		}
+		if parent.PostID != post.ID || parent.Status != models.CommentStatusApproved {
+			return nil, comment.ErrInvalidParent
+		}
+	}
+
+	c.ID = 0
+	c.PostID = post.ID
+	return u.repo.Create(ctx, c)
+}
+
+// Update implements comment.UseCase.
+// An edited comment goes back to the moderation queue unless its editor is auto-approved.
+func (u *usecase) Update(ctx context.Context, c *models.Comment) (*models.Comment, error) {
+	content := strings.TrimSpace(c.Content)
+	if err := validateContent(content); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.repo.GetByID(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateIsOwner(ctx, utils.OwnerID(existing.UserID), models.PermCommentsModerate, u.logger); err != nil {
+		return nil, err
+	}
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !user.Role.Can(models.PermCommentsModerate) {
+		existing.Status, err = u.initialStatus(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	existing.Content = content
+	return u.repo.Update(ctx, existing)
+}
+
+// Delete implements comment.UseCase.
+func (u *usecase) Delete(ctx context.Context, commentID int) error {
+	existing, err := u.repo.GetByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if err := utils.ValidateIsOwner(ctx, utils.OwnerID(existing.UserID), models.PermCommentsModerate, u.logger); err != nil {
+		return err
+	}
+
+	return u.repo.Delete(ctx, commentID)
+}
+
+// ListTree implements comment.UseCase.
+// Top-level comments are paginated and replies are loaded level by level down to depth.
+func (u *usecase) ListTree(ctx context.Context, postSlug string, depth int, pq *utils.PaginationQuery) (*models.CommentList, error) {
+	if depth <= 0 {
+		depth = defaultTreeDepth
+	}
+	if depth > maxTreeDepth {
+		depth = maxTreeDepth
+	}
+
+	post, err := u.postRepo.GetBySlug(ctx, postSlug)
+	if err != nil {
+		return nil, err
+	}
+	if post.Status != models.PostStatusPublished {
+		return nil, posts.ErrPostNotFound
+	}
+
+	list, err := u.repo.ListRoots(ctx, post.ID, models.CommentStatusApproved, pq)
+	if err != nil {
+		return nil, err
+	}
+
+	level := list.Comments
+	for d := 1; d < depth && len(level) > 0; d++ {
+		byID := make(map[int]*models.Comment, len(level))
+		parentIDs := make([]int, len(level))
+		for i, c := range level {
+			byID[c.ID] = c
+			parentIDs[i] = c.ID
+		}
+
+		children, err := u.repo.ListByParentIDs(ctx, parentIDs, models.CommentStatusApproved)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			parent := byID[*child.ParentID]
+			parent.Replies = append(parent.Replies, child)
+		}
+		level = children
+	}
+
+	return list, nil
+}
+
+// ListPending implements comment.UseCase.
+func (u *usecase) ListPending(ctx context.Context, pq *utils.PaginationQuery) (*models.CommentList, error) {
+	return u.repo.ListByStatus(ctx, models.CommentStatusPending, pq)
+}
+
+// Moderate implements comment.UseCase.
+// It returns the number of comments whose status changed.
+func (u *usecase) Moderate(ctx context.Context, commentIDs []int, status models.CommentStatus) (int64, error) {
+	if !status.IsModerated() {
+		return 0, comment.ErrInvalidModeration
+	}
+
+	commentIDs = uniqueIDs(commentIDs)
+	if len(commentIDs) == 0 || len(commentIDs) > maxModerationBatch {
+		return 0, comment.ErrInvalidModeration
+	}
+
+	return u.repo.UpdateStatus(ctx, commentIDs, status)
+}
+
+// initialStatus decides whether a comment by user is published right away or queued
+func (u *usecase) initialStatus(ctx context.Context, user *models.User) (models.CommentStatus, error) {
+	if user.Role.Can(models.PermCommentsModerate) && u.cfg.Comment.AutoApproveAdmins {
+		return models.CommentStatusApproved, nil
+	}
+	if u.cfg.Comment.AutoApproveKnownUsers {
+		known, err := u.repo.HasApproved(ctx, user.ID)
+		if err != nil {
+			return "", err
+		}
+		if known {
+			return models.CommentStatusApproved, nil
+		}
+	}
+	return models.CommentStatusPending, nil
+}
+
+func validateContent(content string) error {
+	if content == "" || utf8.RuneCountInString(content) > maxContentLength {
+		return comment.ErrInvalidContent
+	}
+	return nil
+}
+
+func validateGuest(c *models.Comment) error {
+	if c.AuthorName == nil {
+		return comment.ErrInvalidAuthor
+	}
+	name := strings.TrimSpace(*c.AuthorName)
+	if name == "" || utf8.RuneCountInString(name) > maxAuthorNameLength {
+		return comment.ErrInvalidAuthor
+	}
+	c.AuthorName = &name
+
+	if c.AuthorEmail != nil {
+		email := strings.TrimSpace(*c.AuthorEmail)
+		if email == "" {
+			c.AuthorEmail = nil
+			return nil
+		}
+		if len(email) > maxAuthorEmailLength {
+			return comment.ErrInvalidAuthor
+		}
+		if _, err := mail.ParseAddress(email); err != nil {
+			return comment.ErrInvalidAuthor
+		}
+		c.AuthorEmail = &email
+	}
+	return nil
+}
+
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]struct{}, len(ids))
+	result := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
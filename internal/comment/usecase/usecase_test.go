@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/comment"
+	mock "github.com/ductong169z/shorten-url/internal/comment/mock"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/posts"
+	postMock "github.com/ductong169z/shorten-url/internal/posts/mock"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestUseCase(t *testing.T) (*gomock.Controller, *mock.MockRepository, *postMock.MockRepository, comment.UseCase) {
+	cfg := &config.Config{
+		Comment: config.CommentConfig{AutoApproveAdmins: true, AutoApproveKnownUsers: true},
+	}
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	ctrl := gomock.NewController(t)
+
+	repo := mock.NewMockRepository(ctrl)
+	postRepo := postMock.NewMockRepository(ctrl)
+	return ctrl, repo, postRepo, NewUseCase(cfg, repo, postRepo, apiLogger)
+}
+
+func withUser(user *models.User) context.Context {
+	return context.WithValue(context.Background(), utils.UserCtxKey{}, user)
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestUseCase_Create(t *testing.T) {
+	published := &models.Post{ID: 3, Status: models.PostStatusPublished}
+	user := &models.User{ID: 7, Role: models.RoleUser}
+	admin := &models.User{ID: 1, Role: models.RoleAdmin}
+
+	tcs := map[string]struct {
+		ctx       context.Context
+		post      *models.Post
+		input     models.Comment
+		parent    *models.Comment
+		checkUser bool
+		known     bool
+		expCreate bool
+		expStatus models.CommentStatus
+		expErr    error
+	}{
+		"new user comment is pending and drops guest fields": {
+			ctx:       withUser(user),
+			post:      published,
+			input:     models.Comment{Content: "nice", AuthorName: strPtr("someone")},
+			checkUser: true,
+			expCreate: true,
+			expStatus: models.CommentStatusPending,
+		},
+		"known user comment is approved": {
+			ctx:       withUser(user),
+			post:      published,
+			input:     models.Comment{Content: "nice"},
+			checkUser: true,
+			known:     true,
+			expCreate: true,
+			expStatus: models.CommentStatusApproved,
+		},
+		"admin comment is approved": {
+			ctx:       withUser(admin),
+			post:      published,
+			input:     models.Comment{Content: "nice"},
+			expCreate: true,
+			expStatus: models.CommentStatusApproved,
+		},
+		"guest comment is pending": {
+			ctx:       context.Background(),
+			post:      published,
+			input:     models.Comment{Content: "nice", AuthorName: strPtr("Guest"), AuthorEmail: strPtr("guest@example.com"), Status: models.CommentStatusApproved},
+			expCreate: true,
+			expStatus: models.CommentStatusPending,
+		},
+		"guest without name": {
+			ctx:    context.Background(),
+			post:   published,
+			input:  models.Comment{Content: "nice"},
+			expErr: comment.ErrInvalidAuthor,
+		},
+		"guest with invalid email": {
+			ctx:    context.Background(),
+			post:   published,
+			input:  models.Comment{Content: "nice", AuthorName: strPtr("Guest"), AuthorEmail: strPtr("nope")},
+			expErr: comment.ErrInvalidAuthor,
+		},
+		"empty content": {
+			ctx:    withUser(user),
+			post:   published,
+			input:  models.Comment{Content: "   "},
+			expErr: comment.ErrInvalidContent,
+		},
+		"draft post": {
+			ctx:    withUser(user),
+			post:   &models.Post{ID: 3, Status: models.PostStatusDraft},
+			input:  models.Comment{Content: "nice"},
+			expErr: posts.ErrPostNotFound,
+		},
+		"parent on another post": {
+			ctx:       withUser(user),
+			post:      published,
+			input:     models.Comment{Content: "nice", ParentID: intPtr(10)},
+			parent:    &models.Comment{ID: 10, PostID: 4, Status: models.CommentStatusApproved},
+			checkUser: true,
+			expErr:    comment.ErrInvalidParent,
+		},
+		"parent not approved": {
+			ctx:       withUser(user),
+			post:      published,
+			input:     models.Comment{Content: "nice", ParentID: intPtr(10)},
+			parent:    &models.Comment{ID: 10, PostID: 3, Status: models.CommentStatusPending},
+			checkUser: true,
+			expErr:    comment.ErrInvalidParent,
+		},
+		"reply": {
+			ctx:       withUser(user),
+			post:      published,
+			input:     models.Comment{Content: "nice", ParentID: intPtr(10)},
+			parent:    &models.Comment{ID: 10, PostID: 3, Status: models.CommentStatusApproved},
+			checkUser: true,
+			expCreate: true,
+			expStatus: models.CommentStatusPending,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ctrl, repo, postRepo, uc := newTestUseCase(t)
+			defer ctrl.Finish()
+
+			postRepo.EXPECT().GetByID(gomock.Any(), tc.post.ID).Return(tc.post, nil)
+			if tc.checkUser {
+				repo.EXPECT().HasApproved(gomock.Any(), user.ID).Return(tc.known, nil)
+			}
+			if tc.parent != nil {
+				repo.EXPECT().GetByID(gomock.Any(), tc.parent.ID).Return(tc.parent, nil)
+			}
+			if tc.expCreate {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, c *models.Comment) (*models.Comment, error) {
+					return c, nil
+				})
+			}
+
+			input := tc.input
+			created, err := uc.Create(tc.ctx, tc.post.ID, &input)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.post.ID, created.PostID)
+			assert.Equal(t, tc.expStatus, created.Status)
+			if created.IsGuest() {
+				assert.NotNil(t, created.AuthorName)
+			} else {
+				assert.Nil(t, created.AuthorName)
+				assert.Nil(t, created.AuthorEmail)
+			}
+		})
+	}
+}
+
+func TestUseCase_ListTree(t *testing.T) {
+	ctrl, repo, postRepo, uc := newTestUseCase(t)
+	defer ctrl.Finish()
+
+	post := &models.Post{ID: 3, Slug: "hello", Status: models.PostStatusPublished}
+	pq := &utils.PaginationQuery{}
+	root := &models.Comment{ID: 1, PostID: 3}
+	child := &models.Comment{ID: 2, PostID: 3, ParentID: intPtr(1)}
+
+	postRepo.EXPECT().GetBySlug(gomock.Any(), post.Slug).Return(post, nil)
+	repo.EXPECT().ListRoots(gomock.Any(), post.ID, models.CommentStatusApproved, pq).Return(&models.CommentList{TotalCount: 1, Comments: []*models.Comment{root}}, nil)
+	repo.EXPECT().ListByParentIDs(gomock.Any(), []int{1}, models.CommentStatusApproved).Return([]*models.Comment{child}, nil)
+
+	// depth 2 stops before loading the replies of child
+	list, err := uc.ListTree(context.Background(), post.Slug, 2, pq)
+	assert.NoError(t, err)
+	assert.Len(t, list.Comments, 1)
+	assert.Equal(t, []*models.Comment{child}, list.Comments[0].Replies)
+	assert.Empty(t, child.Replies)
+}
+
+func TestUseCase_Moderate(t *testing.T) {
+	tcs := map[string]struct {
+		ids       []int
+		status    models.CommentStatus
+		expUpdate []int
+		expErr    error
+	}{
+		"approve with duplicates removed": {
+			ids:       []int{1, 2, 1},
+			status:    models.CommentStatusApproved,
+			expUpdate: []int{1, 2},
+		},
+		"mark as spam": {
+			ids:       []int{3},
+			status:    models.CommentStatusSpam,
+			expUpdate: []int{3},
+		},
+		"back to pending": {
+			ids:    []int{1},
+			status: models.CommentStatusPending,
+			expErr: comment.ErrInvalidModeration,
+		},
+		"no ids": {
+			status: models.CommentStatusRejected,
+			expErr: comment.ErrInvalidModeration,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ctrl, repo, _, uc := newTestUseCase(t)
+			defer ctrl.Finish()
+
+			if tc.expUpdate != nil {
+				repo.EXPECT().UpdateStatus(gomock.Any(), tc.expUpdate, tc.status).Return(int64(len(tc.expUpdate)), nil)
+			}
+
+			updated, err := uc.Moderate(context.Background(), tc.ids, tc.status)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tc.expUpdate)), updated)
+		})
+	}
+}
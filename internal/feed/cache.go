@@ -0,0 +1,15 @@
+//go:generate mockgen -source cache.go -destination mock/cache_mock.go -package mock
+package feed
+
+import (
+	"context"
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+)
+
+type Cache interface {
+	GetFeed(ctx context.Context, key string) (*models.Feed, error)
+	SetFeed(ctx context.Context, key string, feed *models.Feed, ttl time.Duration) error
+	Invalidate(ctx context.Context) error
+}
@@ -0,0 +1,15 @@
+//go:generate mockgen -source delivery.go -destination mock/handlers_mock.go -package mock
+package feed
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type Handlers interface {
+	RSS(c *gin.Context)
+	Atom(c *gin.Context)
+	CategoryRSS(c *gin.Context)
+	CategoryAtom(c *gin.Context)
+	TagRSS(c *gin.Context)
+	TagAtom(c *gin.Context)
+}
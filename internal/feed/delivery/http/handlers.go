@@ -0,0 +1,156 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/feed"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	rssContentType  = "application/rss+xml; charset=utf-8"
+	atomContentType = "application/atom+xml; charset=utf-8"
+	cacheControl    = "public, max-age=300"
+)
+
+// Feed handlers
+type handlers struct {
+	cfg     *config.Config
+	usecase feed.UseCase
+	logger  logger.Logger
+}
+
+// NewHandlers Feed handlers constructor
+func NewHandlers(cfg *config.Config, usecase feed.UseCase, logger logger.Logger) feed.Handlers {
+	return &handlers{cfg: cfg, usecase: usecase, logger: logger}
+}
+
+// RSS godoc
+// @Summary      RSS feed
+// @Description  RSS 2.0 feed of the latest published posts
+// @Tags         feeds
+// @Produce      xml
+// @Success      200
+// @Success      304
+// @Router       /feed.xml [get]
+func (h *handlers) RSS(c *gin.Context) {
+	h.serve(c, &models.FeedQuery{Format: models.FeedFormatRSS})
+}
+
+// Atom godoc
+// @Summary      Atom feed
+// @Description  Atom feed of the latest published posts
+// @Tags         feeds
+// @Produce      xml
+// @Success      200
+// @Success      304
+// @Router       /atom.xml [get]
+func (h *handlers) Atom(c *gin.Context) {
+	h.serve(c, &models.FeedQuery{Format: models.FeedFormatAtom})
+}
+
+// CategoryRSS godoc
+// @Summary      Category RSS feed
+// @Description  RSS 2.0 feed of the latest published posts in a category
+// @Tags         feeds
+// @Produce      xml
+// @Param        slug  path  string  true  "Category slug"
+// @Success      200
+// @Success      304
+// @Failure      404  {object}  response.Response
+// @Router       /categories/{slug}/feed.xml [get]
+func (h *handlers) CategoryRSS(c *gin.Context) {
+	h.serve(c, &models.FeedQuery{Format: models.FeedFormatRSS, CategorySlug: c.Param("slug")})
+}
+
+// CategoryAtom godoc
+// @Summary      Category Atom feed
+// @Description  Atom feed of the latest published posts in a category
+// @Tags         feeds
+// @Produce      xml
+// @Param        slug  path  string  true  "Category slug"
+// @Success      200
+// @Success      304
+// @Failure      404  {object}  response.Response
+// @Router       /categories/{slug}/atom.xml [get]
+func (h *handlers) CategoryAtom(c *gin.Context) {
+	h.serve(c, &models.FeedQuery{Format: models.FeedFormatAtom, CategorySlug: c.Param("slug")})
+}
+
+// TagRSS godoc
+// @Summary      Tag RSS feed
+// @Description  RSS 2.0 feed of the latest published posts with a tag
+// @Tags         feeds
+// @Produce      xml
+// @Param        slug  path  string  true  "Tag slug"
+// @Success      200
+// @Success      304
+// @Failure      404  {object}  response.Response
+// @Router       /tags/{slug}/feed.xml [get]
+func (h *handlers) TagRSS(c *gin.Context) {
+	h.serve(c, &models.FeedQuery{Format: models.FeedFormatRSS, TagSlug: c.Param("slug")})
+}
+
+// TagAtom godoc
+// @Summary      Tag Atom feed
+// @Description  Atom feed of the latest published posts with a tag
+// @Tags         feeds
+// @Produce      xml
+// @Param        slug  path  string  true  "Tag slug"
+// @Success      200
+// @Success      304
+// @Failure      404  {object}  response.Response
+// @Router       /tags/{slug}/atom.xml [get]
+func (h *handlers) TagAtom(c *gin.Context) {
+	h.serve(c, &models.FeedQuery{Format: models.FeedFormatAtom, TagSlug: c.Param("slug")})
+}
+
+// serve writes the feed, or 304 when the client copy is still current
+func (h *handlers) serve(c *gin.Context, query *models.FeedQuery) {
+	f, err := h.usecase.Get(c.Request.Context(), query)
+	if err != nil {
+		response.WithMappedError(c, err, feed.MapError)
+		return
+	}
+
+	c.Header("ETag", f.ETag)
+	c.Header("Cache-Control", cacheControl)
+	if !f.LastModified.IsZero() {
+		c.Header("Last-Modified", f.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if notModified(c, f) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	contentType := rssContentType
+	if query.Format == models.FeedFormatAtom {
+		contentType = atomContentType
+	}
+	c.Data(http.StatusOK, contentType, f.Body)
+}
+
+// notModified applies If-None-Match, falling back to If-Modified-Since
+func notModified(c *gin.Context, f *models.Feed) bool {
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == f.ETag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+	if err != nil || f.LastModified.IsZero() {
+		return false
+	}
+	return !f.LastModified.Truncate(time.Second).After(since)
+}
@@ -0,0 +1,16 @@
+package http
+
+import (
+	"github.com/ductong169z/shorten-url/internal/feed"
+	"github.com/gin-gonic/gin"
+)
+
+// Map feed routes
+func MapRoutes(group *gin.RouterGroup, h feed.Handlers) {
+	group.GET("/feed.xml", h.RSS)
+	group.GET("/atom.xml", h.Atom)
+	group.GET("/categories/:slug/feed.xml", h.CategoryRSS)
+	group.GET("/categories/:slug/atom.xml", h.CategoryAtom)
+	group.GET("/tags/:slug/feed.xml", h.TagRSS)
+	group.GET("/tags/:slug/atom.xml", h.TagAtom)
+}
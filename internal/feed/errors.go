@@ -0,0 +1,36 @@
+// Package feed provides core error definitions and utilities for the syndication feeds domain.
+// It defines domain-specific error variables and error-to-HTTP status mapping for consistent error handling.
+package feed
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ductong169z/shorten-url/internal/category"
+	"github.com/ductong169z/shorten-url/internal/tag"
+)
+
+const (
+	// invalidFormat is returned when the feed format is neither RSS nor Atom.
+	invalidFormat = "invalid feed format"
+)
+
+var (
+	// ErrInvalidFormat indicates that the feed format is invalid.
+	ErrInvalidFormat = errors.New(invalidFormat)
+)
+
+// MapError maps a domain error to an HTTP status code and message.
+// It provides a unified way to translate domain errors to HTTP responses.
+func MapError(err error) (status int, message string) {
+	switch {
+	case errors.Is(err, ErrInvalidFormat):
+		return http.StatusBadRequest, invalidFormat
+	case errors.Is(err, category.ErrCategoryNotFound):
+		return http.StatusNotFound, category.ErrCategoryNotFound.Error()
+	case errors.Is(err, tag.ErrTagNotFound):
+		return http.StatusNotFound, tag.ErrTagNotFound.Error()
+	default:
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: delivery.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHandlers is a mock of Handlers interface.
+type MockHandlers struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlersMockRecorder
+}
+
+// MockHandlersMockRecorder is the mock recorder for MockHandlers.
+type MockHandlersMockRecorder struct {
+	mock *MockHandlers
+}
+
+// NewMockHandlers creates a new mock instance.
+func NewMockHandlers(ctrl *gomock.Controller) *MockHandlers {
+	mock := &MockHandlers{ctrl: ctrl}
+	mock.recorder = &MockHandlersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandlers) EXPECT() *MockHandlersMockRecorder {
+	return m.recorder
+}
+
+// Atom mocks base method.
+func (m *MockHandlers) Atom(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Atom", c)
+}
+
+// Atom indicates an expected call of Atom.
+func (mr *MockHandlersMockRecorder) Atom(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Atom", reflect.TypeOf((*MockHandlers)(nil).Atom), c)
+}
+
+// CategoryAtom mocks base method.
+func (m *MockHandlers) CategoryAtom(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CategoryAtom", c)
+}
+
+// CategoryAtom indicates an expected call of CategoryAtom.
+func (mr *MockHandlersMockRecorder) CategoryAtom(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CategoryAtom", reflect.TypeOf((*MockHandlers)(nil).CategoryAtom), c)
+}
+
+// CategoryRSS mocks base method.
+func (m *MockHandlers) CategoryRSS(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CategoryRSS", c)
+}
+
+// CategoryRSS indicates an expected call of CategoryRSS.
+func (mr *MockHandlersMockRecorder) CategoryRSS(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CategoryRSS", reflect.TypeOf((*MockHandlers)(nil).CategoryRSS), c)
+}
+
+// RSS mocks base method.
+func (m *MockHandlers) RSS(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RSS", c)
+}
+
+// RSS indicates an expected call of RSS.
+func (mr *MockHandlersMockRecorder) RSS(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RSS", reflect.TypeOf((*MockHandlers)(nil).RSS), c)
+}
+
+// TagAtom mocks base method.
+func (m *MockHandlers) TagAtom(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TagAtom", c)
+}
+
+// TagAtom indicates an expected call of TagAtom.
+func (mr *MockHandlersMockRecorder) TagAtom(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagAtom", reflect.TypeOf((*MockHandlers)(nil).TagAtom), c)
+}
+
+// TagRSS mocks base method.
+func (m *MockHandlers) TagRSS(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TagRSS", c)
+}
+
+// TagRSS indicates an expected call of TagRSS.
+func (mr *MockHandlersMockRecorder) TagRSS(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagRSS", reflect.TypeOf((*MockHandlers)(nil).TagRSS), c)
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/feed"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/cache/redis"
+)
+
+const (
+	feedKeyPrefix = "api-feed:"
+	generationKey = feedKeyPrefix + "generation"
+)
+
+// Feed redis repository.
+// Keys embed a generation number so every cached feed is invalidated by
+// writing a new generation; stale keys expire on their own.
+type redisRepo struct {
+	rdb redis.Client
+}
+
+// Feed redis repository constructor
+func NewRedisRepo(rdb redis.Client) feed.Cache {
+	return &redisRepo{rdb: rdb}
+}
+
+func (r *redisRepo) GetFeed(ctx context.Context, key string) (*models.Feed, error) {
+	data, err := r.rdb.Get(ctx, r.key(ctx, key))
+	if err != nil {
+		return nil, err
+	}
+	var f models.Feed
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *redisRepo) SetFeed(ctx context.Context, key string, f *models.Feed, ttl time.Duration) error {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return r.rdb.Set(ctx, r.key(ctx, key), string(data), ttl)
+}
+
+func (r *redisRepo) Invalidate(ctx context.Context) error {
+	return r.rdb.Set(ctx, generationKey, strconv.FormatInt(time.Now().UnixNano(), 10), 0)
+}
+
+func (r *redisRepo) key(ctx context.Context, key string) string {
+	generation, err := r.rdb.Get(ctx, generationKey)
+	if err != nil {
+		generation = []byte("0")
+	}
+	return feedKeyPrefix + string(generation) + ":" + key
+}
@@ -0,0 +1,13 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package feed
+
+import (
+	"context"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+)
+
+type UseCase interface {
+	Get(ctx context.Context, query *models.FeedQuery) (*models.Feed, error)
+	PostsChanged(ctx context.Context)
+}
@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/category"
+	"github.com/ductong169z/shorten-url/internal/feed"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/posts"
+	"github.com/ductong169z/shorten-url/internal/tag"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/markdown"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+const (
+	DefaultCacheTTL  = 1 * time.Hour
+	defaultFeedItems = 20
+	maxFeedItems     = 100
+)
+
+// feedFiles maps a format to the file name its feeds are served under, below the
+// page of the channel, see the routes in feed/delivery/http
+var feedFiles = map[models.FeedFormat]string{
+	models.FeedFormatRSS:  "feed.xml",
+	models.FeedFormatAtom: "atom.xml",
+}
+
+type usecase struct {
+	cfg          *config.Config
+	cache        feed.Cache
+	postRepo     posts.Repository
+	categoryRepo category.Repository
+	tagRepo      tag.Repository
+	logger       logger.Logger
+}
+
+// Feed UseCase constructor
+func NewUseCase(cfg *config.Config, cache feed.Cache, postRepo posts.Repository, categoryRepo category.Repository, tagRepo tag.Repository, logger logger.Logger) feed.UseCase {
+	return &usecase{
+		cfg:          cfg,
+		cache:        cache,
+		postRepo:     postRepo,
+		categoryRepo: categoryRepo,
+		tagRepo:      tagRepo,
+		logger:       logger,
+	}
+}
+
+// channel describes the scope of a feed
+type channel struct {
+	key         string
+	title       string
+	link        string
+	description string
+	filter      *models.PostFilter
+}
+
+// Get implements feed.UseCase.
+func (u *usecase) Get(ctx context.Context, query *models.FeedQuery) (*models.Feed, error) {
+	if !query.Format.IsValid() {
+		return nil, feed.ErrInvalidFormat
+	}
+
+	ch, err := u.channel(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	key := string(query.Format) + ":" + ch.key
+
+	cached, err := u.cache.GetFeed(ctx, key)
+	if err != nil {
+		u.logger.Debugf(ctx, "Cache miss for feed %s: %v", key, err)
+	}
+	if cached != nil {
+		return cached, nil
+	}
+
+	pq := &utils.PaginationQuery{Page: 1, Size: u.feedItems(), OrderBy: "published_at"}
+	list, err := u.postRepo.List(ctx, ch.filter, pq)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := u.build(query, ch, list.Posts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.cache.SetFeed(ctx, key, f, DefaultCacheTTL); err != nil {
+		u.logger.Errorf(ctx, "Failed to set feed %s in cache: %v", key, err)
+	}
+
+	return f, nil
+}
+
+// PostsChanged implements posts.Observer by dropping every cached feed.
+func (u *usecase) PostsChanged(ctx context.Context) {
+	if err := u.cache.Invalidate(ctx); err != nil {
+		u.logger.Errorf(ctx, "Failed to invalidate feeds: %v", err)
+	}
+}
+
+func (u *usecase) channel(ctx context.Context, query *models.FeedQuery) (*channel, error) {
+	blog := u.cfg.Blog
+	ch := &channel{
+		key:         "all",
+		title:       blog.Title,
+		link:        u.url(),
+		description: blog.Description,
+		filter:      &models.PostFilter{Status: models.PostStatusPublished},
+	}
+
+	switch {
+	case query.CategorySlug != "":
+		c, err := u.categoryRepo.GetBySlug(ctx, query.CategorySlug)
+		if err != nil {
+			return nil, err
+		}
+		ch.key = "category:" + c.Slug
+		ch.title = blog.Title + " - " + c.Name
+		ch.link = u.url("categories", c.Slug)
+		if c.Description != nil {
+			ch.description = *c.Description
+		}
+		ch.filter.CategoryID = c.ID
+	case query.TagSlug != "":
+		t, err := u.tagRepo.GetBySlug(ctx, query.TagSlug)
+		if err != nil {
+			return nil, err
+		}
+		ch.key = "tag:" + t.Slug
+		ch.title = blog.Title + " - " + t.Name
+		ch.link = u.url("tags", t.Slug)
+		ch.filter.TagID = t.ID
+	}
+	return ch, nil
+}
+
+func (u *usecase) build(query *models.FeedQuery, ch *channel, items []*models.Post) (*models.Feed, error) {
+	var lastModified time.Time
+	for _, p := range items {
+		if p.UpdatedAt.After(lastModified) {
+			lastModified = p.UpdatedAt
+		}
+		if p.PublishedAt != nil && p.PublishedAt.After(lastModified) {
+			lastModified = *p.PublishedAt
+		}
+	}
+	lastModified = lastModified.UTC().Truncate(time.Second)
+
+	// The feed links itself below the page of its channel, e.g. <blog>/categories/go/atom.xml
+	self := ch.link + "/" + feedFiles[query.Format]
+
+	var doc interface{}
+	if query.Format == models.FeedFormatAtom {
+		doc = u.atom(ch, self, items, lastModified)
+	} else {
+		doc = u.rss(ch, self, items, lastModified)
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	body = append([]byte(xml.Header), body...)
+
+	sum := sha1.Sum(body)
+	return &models.Feed{
+		Body:         body,
+		ETag:         `"` + hex.EncodeToString(sum[:]) + `"`,
+		LastModified: lastModified,
+	}, nil
+}
+
+func (u *usecase) rss(ch *channel, self string, items []*models.Post, lastModified time.Time) *rss {
+	doc := &rss{
+		Version: "2.0",
+		AtomNS:  atomNamespace,
+		Channel: rssChannel{
+			Title:       ch.title,
+			Link:        ch.link,
+			Description: ch.description,
+			AtomLink:    &rssLink{Href: self, Rel: "self", Type: "application/rss+xml"},
+			Items:       make([]rssItem, len(items)),
+		},
+	}
+	if !lastModified.IsZero() {
+		doc.Channel.LastBuildDate = formatRSSTime(lastModified)
+	}
+
+	for i, p := range items {
+		link := u.url("posts", p.Slug)
+		doc.Channel.Items[i] = rssItem{
+			Title:       p.Title,
+			Link:        link,
+			GUID:        rssGUID{IsPermaLink: true, Value: link},
+			PubDate:     formatRSSTime(publishedAt(p)),
+			Description: markdown.Render(p.Content).Excerpt,
+		}
+	}
+	return doc
+}
+
+func (u *usecase) atom(ch *channel, self string, items []*models.Post, lastModified time.Time) *atomFeed {
+	doc := &atomFeed{
+		NS:      atomNamespace,
+		ID:      ch.link,
+		Title:   ch.title,
+		Updated: formatAtomTime(lastModified),
+		Links: []atomLink{
+			{Href: ch.link, Rel: "alternate", Type: "text/html"},
+			{Href: self, Rel: "self", Type: "application/atom+xml"},
+		},
+		Author:  atomAuthor{Name: u.cfg.Blog.Title},
+		Entries: make([]atomEntry, len(items)),
+	}
+
+	for i, p := range items {
+		link := u.url("posts", p.Slug)
+		rendered := markdown.Render(p.Content)
+		doc.Entries[i] = atomEntry{
+			ID:        link,
+			Title:     p.Title,
+			Link:      atomLink{Href: link, Rel: "alternate", Type: "text/html"},
+			Published: formatAtomTime(publishedAt(p)),
+			Updated:   formatAtomTime(p.UpdatedAt),
+			Summary:   rendered.Excerpt,
+			Content:   atomContent{Type: "html", Body: rendered.HTML},
+		}
+	}
+	return doc
+}
+
+// url joins path segments onto the public blog URL
+func (u *usecase) url(segments ...string) string {
+	return strings.Join(append([]string{strings.TrimRight(u.cfg.Blog.URL, "/")}, segments...), "/")
+}
+
+func (u *usecase) feedItems() int {
+	n := u.cfg.Blog.FeedItems
+	if n <= 0 {
+		return defaultFeedItems
+	}
+	if n > maxFeedItems {
+		return maxFeedItems
+	}
+	return n
+}
+
+func publishedAt(p *models.Post) time.Time {
+	if p.PublishedAt != nil {
+		return *p.PublishedAt
+	}
+	return p.CreatedAt
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ductong169z/shorten-url/config"
+	categoryMock "github.com/ductong169z/shorten-url/internal/category/mock"
+	"github.com/ductong169z/shorten-url/internal/feed"
+	mock "github.com/ductong169z/shorten-url/internal/feed/mock"
+	"github.com/ductong169z/shorten-url/internal/models"
+	postMock "github.com/ductong169z/shorten-url/internal/posts/mock"
+	tagMock "github.com/ductong169z/shorten-url/internal/tag/mock"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUseCase_Get(t *testing.T) {
+	publishedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)
+	post := &models.Post{
+		ID:          1,
+		Title:       "Hello & welcome",
+		Slug:        "hello",
+		Content:     "# Hi\n\nFirst paragraph.",
+		Status:      models.PostStatusPublished,
+		PublishedAt: &publishedAt,
+		UpdatedAt:   updatedAt,
+	}
+	category := &models.Category{ID: 4, Name: "Go", Slug: "go"}
+
+	tcs := map[string]struct {
+		query       *models.FeedQuery
+		expFilter   *models.PostFilter
+		expKey      string
+		expContains []string
+		expErr      error
+	}{
+		"site rss": {
+			query:     &models.FeedQuery{Format: models.FeedFormatRSS},
+			expFilter: &models.PostFilter{Status: models.PostStatusPublished},
+			expKey:    "rss:all",
+			expContains: []string{
+				`<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">`,
+				`<atom:link href="https://blog.example.com/feed.xml" rel="self" type="application/rss+xml"></atom:link>`,
+				`<title>Hello &amp; welcome</title>`,
+				`<guid isPermaLink="true">https://blog.example.com/posts/hello</guid>`,
+				`<pubDate>Wed, 01 May 2024 10:00:00 +0000</pubDate>`,
+				`<description>First paragraph.</description>`,
+			},
+		},
+		"category atom": {
+			query:     &models.FeedQuery{Format: models.FeedFormatAtom, CategorySlug: "go"},
+			expFilter: &models.PostFilter{Status: models.PostStatusPublished, CategoryID: category.ID},
+			expKey:    "atom:category:go",
+			expContains: []string{
+				`<feed xmlns="http://www.w3.org/2005/Atom">`,
+				`<title>Blog - Go</title>`,
+				`<link href="https://blog.example.com/categories/go/atom.xml" rel="self" type="application/atom+xml"></link>`,
+				`<updated>2024-05-02T08:30:00Z</updated>`,
+				`<content type="html">`,
+			},
+		},
+		"invalid format": {
+			query:  &models.FeedQuery{Format: "json"},
+			expErr: feed.ErrInvalidFormat,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{Blog: config.BlogConfig{URL: "https://blog.example.com/", Title: "Blog"}}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cache := mock.NewMockCache(ctrl)
+			postRepo := postMock.NewMockRepository(ctrl)
+			categoryRepo := categoryMock.NewMockRepository(ctrl)
+			uc := NewUseCase(cfg, cache, postRepo, categoryRepo, tagMock.NewMockRepository(ctrl), apiLogger)
+
+			if tc.query.CategorySlug != "" {
+				categoryRepo.EXPECT().GetBySlug(gomock.Any(), tc.query.CategorySlug).Return(category, nil)
+			}
+			if tc.expFilter != nil {
+				cache.EXPECT().GetFeed(gomock.Any(), tc.expKey).Return(nil, errors.New("miss"))
+				postRepo.EXPECT().List(gomock.Any(), tc.expFilter, gomock.Any()).Return(&models.PostList{Posts: []*models.Post{post}}, nil)
+				cache.EXPECT().SetFeed(gomock.Any(), tc.expKey, gomock.Any(), DefaultCacheTTL).Return(nil)
+			}
+
+			f, err := uc.Get(context.Background(), tc.query)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			for _, s := range tc.expContains {
+				assert.Contains(t, string(f.Body), s)
+			}
+			assert.Equal(t, updatedAt, f.LastModified)
+			assert.NotEmpty(t, f.ETag)
+		})
+	}
+}
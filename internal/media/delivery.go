@@ -0,0 +1,13 @@
+//go:generate mockgen -source delivery.go -destination mock/handlers_mock.go -package mock
+package media
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type Handlers interface {
+	Upload(c *gin.Context)
+	GetByID(c *gin.Context)
+	List(c *gin.Context)
+	Delete(c *gin.Context)
+}
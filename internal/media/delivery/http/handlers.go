@@ -0,0 +1,167 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/media"
+	"github.com/ductong169z/shorten-url/internal/media/usecase"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/response"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is allowed on top of the file size for the other form parts
+const multipartOverhead = 1 << 20
+
+// Media handlers
+type handlers struct {
+	cfg     *config.Config
+	usecase media.UseCase
+	logger  logger.Logger
+}
+
+// NewHandlers Media handlers constructor
+func NewHandlers(cfg *config.Config, usecase media.UseCase, logger logger.Logger) media.Handlers {
+	return &handlers{cfg: cfg, usecase: usecase, logger: logger}
+}
+
+// Upload godoc
+// @Summary      Upload media
+// @Description  Upload an image (JPEG, PNG, GIF or WebP), optionally linked to a post owned by the current user
+// @Tags         media
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file     formData  file  true   "Image file"
+// @Param        post_id  formData  int   false  "Post ID"
+// @Success      201      {object}  MediaResponse
+// @Failure      400,401,403,404,413,415  {object}  response.Response
+// @Router       /media [post]
+func (h *handlers) Upload(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, usecase.MaxUploadSize(h.cfg)+multipartOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = media.ErrFileTooLarge
+		} else {
+			err = media.ErrMissingFile
+		}
+		response.WithMappedError(c, err, media.MapError)
+		return
+	}
+
+	upload := &models.MediaUpload{Filename: fileHeader.Filename, Size: fileHeader.Size}
+	if value := c.PostForm("post_id"); value != "" {
+		postID, err := strconv.Atoi(value)
+		if err != nil {
+			response.WithMappedError(c, err, media.MapError)
+			return
+		}
+		upload.PostID = &postID
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.WithMappedError(c, err, media.MapError)
+		return
+	}
+	defer file.Close()
+	upload.File = file
+
+	m, err := h.usecase.Upload(c.Request.Context(), upload)
+	if err != nil {
+		response.WithMappedError(c, err, media.MapError)
+		return
+	}
+
+	response.WithCode(c, http.StatusCreated, FromMediaModel(m))
+}
+
+// GetByID godoc
+// @Summary      Get media
+// @Description  Get an uploaded file's metadata and URLs
+// @Tags         media
+// @Produce      json
+// @Param        id   path      int  true  "Media ID"
+// @Success      200  {object}  MediaResponse
+// @Failure      400,404  {object}  response.Response
+// @Router       /media/{id} [get]
+func (h *handlers) GetByID(c *gin.Context) {
+	mediaID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithMappedError(c, err, media.MapError)
+		return
+	}
+
+	m, err := h.usecase.GetByID(c.Request.Context(), mediaID)
+	if err != nil {
+		response.WithMappedError(c, err, media.MapError)
+		return
+	}
+
+	response.WithOK(c, FromMediaModel(m))
+}
+
+// List godoc
+// @Summary      List media
+// @Description  List the media of a post owned by the current user, or the current user's uploads without post_id
+// @Tags         media
+// @Produce      json
+// @Param        post_id  query     int  false  "Post ID"
+// @Param        page     query     int  false  "Page number"
+// @Param        size     query     int  false  "Page size"
+// @Success      200      {object}  MediaListResponse
+// @Failure      400,401,403,404  {object}  response.Response
+// @Router       /media [get]
+func (h *handlers) List(c *gin.Context) {
+	pq, err := utils.GetPaginationFromCtx(c)
+	if err != nil {
+		response.WithMappedError(c, err, media.MapError)
+		return
+	}
+
+	var postID int
+	if value := c.Query("post_id"); value != "" {
+		if postID, err = strconv.Atoi(value); err != nil {
+			response.WithMappedError(c, err, media.MapError)
+			return
+		}
+	}
+
+	list, err := h.usecase.List(c.Request.Context(), postID, pq)
+	if err != nil {
+		response.WithMappedError(c, err, media.MapError)
+		return
+	}
+
+	response.WithOK(c, FromMediaListModel(list))
+}
+
+// Delete godoc
+// @Summary      Delete media
+// @Description  Delete an uploaded file and its thumbnail
+// @Tags         media
+// @Param        id   path  int  true  "Media ID"
+// @Success      204
+// @Failure      400,401,403,404  {object}  response.Response
+// @Router       /media/{id} [delete]
+func (h *handlers) Delete(c *gin.Context) {
+	mediaID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithMappedError(c, err, media.MapError)
+		return
+	}
+
+	if err := h.usecase.Delete(c.Request.Context(), mediaID); err != nil {
+		response.WithMappedError(c, err, media.MapError)
+		return
+	}
+
+	response.WithNoContent(c)
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+)
+
+type MediaResponse struct {
+	ID           int     `json:"id"`
+	UserID       int     `json:"user_id"`
+	PostID       *int    `json:"post_id,omitempty"`
+	Filename     string  `json:"filename"`
+	MimeType     string  `json:"mime_type"`
+	Size         int64   `json:"size"`
+	Width        *int    `json:"width,omitempty"`
+	Height       *int    `json:"height,omitempty"`
+	URL          string  `json:"url"`
+	ThumbnailURL *string `json:"thumbnail_url,omitempty"`
+	CreatedAt    string  `json:"created_at"`
+}
+
+type MediaListResponse struct {
+	TotalCount int64           `json:"total_count"`
+	TotalPages int             `json:"total_pages"`
+	Page       int             `json:"page"`
+	Size       int             `json:"size"`
+	HasMore    bool            `json:"has_more"`
+	Media      []MediaResponse `json:"media"`
+}
+
+func FormatTime(time time.Time) string {
+	return time.Format("2006-01-02 15:04:05")
+}
+
+func FromMediaModel(m *models.Media) MediaResponse {
+	return MediaResponse{
+		ID:           m.ID,
+		UserID:       m.UserID,
+		PostID:       m.PostID,
+		Filename:     m.Filename,
+		MimeType:     m.MimeType,
+		Size:         m.Size,
+		Width:        m.Width,
+		Height:       m.Height,
+		URL:          m.URL,
+		ThumbnailURL: m.ThumbnailURL,
+		CreatedAt:    FormatTime(m.CreatedAt),
+	}
+}
+
+func FromMediaListModel(list *models.MediaList) MediaListResponse {
+	media := make([]MediaResponse, len(list.Media))
+	for i, m := range list.Media {
+		media[i] = FromMediaModel(m)
+	}
+
+	return MediaListResponse{
+		TotalCount: list.TotalCount,
+		TotalPages: list.TotalPages,
+		Page:       list.Page,
+		Size:       list.Size,
+		HasMore:    list.HasMore,
+		Media:      media,
+	}
+}
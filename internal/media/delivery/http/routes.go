@@ -0,0 +1,17 @@
+package http
+
+import (
+	"github.com/ductong169z/shorten-url/internal/media"
+	"github.com/ductong169z/shorten-url/internal/middleware"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Map media routes
+func MapRoutes(group *gin.RouterGroup, h media.Handlers, mw *middleware.MiddlewareManager) {
+	group.GET("/:id", h.GetByID)
+	group.Use(mw.AuthJWTMiddleware())
+	group.GET("", h.List)
+	group.POST("", mw.RequirePermission(models.PermMediaUpload), h.Upload)
+	group.DELETE("/:id", h.Delete)
+}
@@ -0,0 +1,77 @@
+// Package media provides core error definitions and utilities for the media upload domain.
+// It defines domain-specific error variables and error-to-HTTP status mapping for consistent error handling.
+package media
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ductong169z/shorten-url/internal/posts"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// mediaNotFound is returned when a requested media file does not exist.
+	mediaNotFound = "media not found"
+	// missingFile is returned when the upload has no file part.
+	missingFile = "missing file"
+	// emptyFile is returned when the uploaded file has no content.
+	emptyFile = "empty file"
+	// fileTooLarge is returned when the uploaded file exceeds the size limit.
+	fileTooLarge = "file too large"
+	// unsupportedMediaType is returned when the sniffed file type is not allowed.
+	unsupportedMediaType = "unsupported media type"
+	// invalidImage is returned when an image cannot be decoded.
+	invalidImage = "invalid image"
+)
+
+var (
+	// ErrMediaNotFound indicates that the media file was not found.
+	ErrMediaNotFound = errors.New(mediaNotFound)
+	// ErrMissingFile indicates that no file was uploaded.
+	ErrMissingFile = errors.New(missingFile)
+	// ErrEmptyFile indicates that the uploaded file is empty.
+	ErrEmptyFile = errors.New(emptyFile)
+	// ErrFileTooLarge indicates that the uploaded file is too large.
+	ErrFileTooLarge = errors.New(fileTooLarge)
+	// ErrUnsupportedMediaType indicates that the file type is not allowed.
+	ErrUnsupportedMediaType = errors.New(unsupportedMediaType)
+	// ErrInvalidImage indicates that the image data is corrupt.
+	ErrInvalidImage = errors.New(invalidImage)
+)
+
+// MapError maps a domain error to an HTTP status code and message.
+// It provides a unified way to translate domain errors to HTTP responses.
+func MapError(err error) (status int, message string) {
+	// Handle JSON binding/unmarshal errors as 400 Bad Request
+	switch err.(type) {
+	case *json.UnmarshalTypeError, *json.SyntaxError:
+		return http.StatusBadRequest, "Invalid request format"
+	case *strconv.NumError:
+		return http.StatusBadRequest, "Invalid parameter format"
+	}
+	if ginErr, ok := err.(*gin.Error); ok && ginErr.Type == gin.ErrorTypeBind {
+		return http.StatusBadRequest, "Invalid request format"
+	}
+
+	switch {
+	case errors.Is(err, ErrMediaNotFound):
+		return http.StatusNotFound, mediaNotFound
+	case errors.Is(err, posts.ErrPostNotFound):
+		return http.StatusNotFound, posts.ErrPostNotFound.Error()
+	case errors.Is(err, ErrMissingFile):
+		return http.StatusBadRequest, missingFile
+	case errors.Is(err, ErrEmptyFile):
+		return http.StatusBadRequest, emptyFile
+	case errors.Is(err, ErrFileTooLarge):
+		return http.StatusRequestEntityTooLarge, fileTooLarge
+	case errors.Is(err, ErrUnsupportedMediaType):
+		return http.StatusUnsupportedMediaType, unsupportedMediaType
+	case errors.Is(err, ErrInvalidImage):
+		return http.StatusBadRequest, invalidImage
+	default:
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: delivery.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHandlers is a mock of Handlers interface.
+type MockHandlers struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlersMockRecorder
+}
+
+// MockHandlersMockRecorder is the mock recorder for MockHandlers.
+type MockHandlersMockRecorder struct {
+	mock *MockHandlers
+}
+
+// NewMockHandlers creates a new mock instance.
+func NewMockHandlers(ctrl *gomock.Controller) *MockHandlers {
+	mock := &MockHandlers{ctrl: ctrl}
+	mock.recorder = &MockHandlersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandlers) EXPECT() *MockHandlersMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockHandlers) Delete(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Delete", c)
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockHandlersMockRecorder) Delete(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHandlers)(nil).Delete), c)
+}
+
+// GetByID mocks base method.
+func (m *MockHandlers) GetByID(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetByID", c)
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockHandlersMockRecorder) GetByID(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockHandlers)(nil).GetByID), c)
+}
+
+// List mocks base method.
+func (m *MockHandlers) List(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "List", c)
+}
+
+// List indicates an expected call of List.
+func (mr *MockHandlersMockRecorder) List(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHandlers)(nil).List), c)
+}
+
+// Upload mocks base method.
+func (m *MockHandlers) Upload(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Upload", c)
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockHandlersMockRecorder) Upload(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockHandlers)(nil).Upload), c)
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source mysql.go -destination mock/mysql_repository_mock.go -package mock
+package media
+
+import (
+	"context"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+type Repository interface {
+	Create(ctx context.Context, media *models.Media) (*models.Media, error)
+	GetByID(ctx context.Context, mediaID int) (*models.Media, error)
+	List(ctx context.Context, filter *models.MediaFilter, pq *utils.PaginationQuery) (*models.MediaList, error)
+	Delete(ctx context.Context, mediaID int) error
+}
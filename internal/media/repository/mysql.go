@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ductong169z/shorten-url/internal/media"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// Media Repository
+type repo struct {
+	db *gorm.DB
+}
+
+// Media repository constructor
+func NewRepository(db *gorm.DB) media.Repository {
+	return &repo{db: db}
+}
+
+// Create implements media.Repository.
+func (r *repo) Create(ctx context.Context, m *models.Media) (*models.Media, error) {
+	if err := r.db.WithContext(ctx).Create(m).Error; err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GetByID implements media.Repository.
+func (r *repo) GetByID(ctx context.Context, mediaID int) (*models.Media, error) {
+	var m models.Media
+	if err := r.db.WithContext(ctx).Where("id = ?", mediaID).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, media.ErrMediaNotFound
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+// List implements media.Repository.
+func (r *repo) List(ctx context.Context, filter *models.MediaFilter, pq *utils.PaginationQuery) (*models.MediaList, error) {
+	query := r.db.WithContext(ctx).Model(&models.Media{})
+	if filter != nil {
+		if filter.UserID != 0 {
+			query = query.Where("user_id = ?", filter.UserID)
+		}
+		if filter.PostID != 0 {
+			query = query.Where("post_id = ?", filter.PostID)
+		}
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, err
+	}
+
+	list := &models.MediaList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Media:      make([]*models.Media, 0),
+	}
+	if totalCount == 0 {
+		return list, nil
+	}
+
+	if err := query.
+		Order("created_at DESC, id DESC").
+		Offset(pq.GetOffset()).
+		Limit(pq.GetLimit()).
+		Find(&list.Media).Error; err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Delete implements media.Repository.
+func (r *repo) Delete(ctx context.Context, mediaID int) error {
+	result := r.db.WithContext(ctx).Where("id = ?", mediaID).Delete(&models.Media{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return media.ErrMediaNotFound
+	}
+	return nil
+}
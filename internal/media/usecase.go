@@ -0,0 +1,16 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package media
+
+import (
+	"context"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+type UseCase interface {
+	Upload(ctx context.Context, upload *models.MediaUpload) (*models.Media, error)
+	GetByID(ctx context.Context, mediaID int) (*models.Media, error)
+	List(ctx context.Context, postID int, pq *utils.PaginationQuery) (*models.MediaList, error)
+	Delete(ctx context.Context, mediaID int) error
+}
@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"image"
+	_ "image/gif" // register GIF for image.Decode
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/media"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/posts"
+	"github.com/ductong169z/shorten-url/pkg/imaging"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/storage"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultMaxUploadSize  = 10 << 20
+	DefaultThumbnailWidth = 320
+	maxFilenameLength     = 255
+	// maxThumbnailPixels skips thumbnails for images too large to decode safely
+	maxThumbnailPixels = 40_000_000
+	jpegQuality        = 85
+)
+
+// allowedTypes maps the sniffed MIME types accepted for upload to their file extension
+var allowedTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+type usecase struct {
+	cfg      *config.Config
+	repo     media.Repository
+	postRepo posts.Repository
+	storage  storage.Storage
+	logger   logger.Logger
+}
+
+// Media UseCase constructor
+func NewUseCase(cfg *config.Config, repo media.Repository, postRepo posts.Repository, storage storage.Storage, logger logger.Logger) media.UseCase {
+	return &usecase{cfg: cfg, repo: repo, postRepo: postRepo, storage: storage, logger: logger}
+}
+
+// MaxUploadSize returns the configured upload limit in bytes
+func MaxUploadSize(cfg *config.Config) int64 {
+	if cfg.Media.MaxUploadSize > 0 {
+		return cfg.Media.MaxUploadSize
+	}
+	return DefaultMaxUploadSize
+}
+
+// Upload implements media.UseCase.
+// The file type is sniffed from its content rather than trusted from the
+// client. Images get their dimensions recorded and a thumbnail when wider
+// than the thumbnail width; WebP is stored as is since it cannot be decoded.
+func (u *usecase) Upload(ctx context.Context, upload *models.MediaUpload) (*models.Media, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if upload.PostID != nil {
+		post, err := u.postRepo.GetByID(ctx, *upload.PostID)
+		if err != nil {
+			return nil, err
+		}
+		if err := utils.ValidateIsOwner(ctx, post.UserID, models.PermPostsEditAny, u.logger); err != nil {
+			return nil, err
+		}
+	}
+
+	maxSize := MaxUploadSize(u.cfg)
+	if upload.Size > maxSize {
+		return nil, media.ErrFileTooLarge
+	}
+	data, err := io.ReadAll(io.LimitReader(upload.File, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, media.ErrFileTooLarge
+	}
+	if len(data) == 0 {
+		return nil, media.ErrEmptyFile
+	}
+
+	mimeType := http.DetectContentType(data)
+	ext, ok := allowedTypes[mimeType]
+	if !ok {
+		return nil, media.ErrUnsupportedMediaType
+	}
+
+	m := &models.Media{
+		UserID:     user.ID,
+		PostID:     upload.PostID,
+		Filename:   cleanFilename(upload.Filename, ext),
+		MimeType:   mimeType,
+		Size:       int64(len(data)),
+		StorageKey: time.Now().UTC().Format("2006/01") + "/" + uuid.NewString(),
+	}
+
+	var thumbnail []byte
+	var thumbnailExt string
+	if mimeType != "image/webp" {
+		imgCfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return nil, media.ErrInvalidImage
+		}
+		m.Width, m.Height = &imgCfg.Width, &imgCfg.Height
+
+		if imgCfg.Width > u.thumbnailWidth() && imgCfg.Width*imgCfg.Height <= maxThumbnailPixels {
+			if thumbnail, thumbnailExt, err = u.thumbnail(data, mimeType); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	base := m.StorageKey
+	m.StorageKey = base + ext
+	if err := u.storage.Put(ctx, m.StorageKey, bytes.NewReader(data), m.Size, mimeType); err != nil {
+		return nil, err
+	}
+	stored := []string{m.StorageKey}
+
+	if thumbnail != nil {
+		key := base + "_thumb" + thumbnailExt
+		if err := u.storage.Put(ctx, key, bytes.NewReader(thumbnail), int64(len(thumbnail)), thumbnailType(thumbnailExt)); err != nil {
+			u.removeObjects(ctx, stored)
+			return nil, err
+		}
+		m.ThumbnailKey = &key
+		stored = append(stored, key)
+	}
+
+	created, err := u.repo.Create(ctx, m)
+	if err != nil {
+		u.removeObjects(ctx, stored)
+		return nil, err
+	}
+
+	return u.withURLs(created), nil
+}
+
+// GetByID implements media.UseCase.
+func (u *usecase) GetByID(ctx context.Context, mediaID int) (*models.Media, error) {
+	m, err := u.repo.GetByID(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return u.withURLs(m), nil
+}
+
+// List implements media.UseCase.
+// With a post ID it lists the media of a post the current user may edit,
+// otherwise the current user's own uploads.
+func (u *usecase) List(ctx context.Context, postID int, pq *utils.PaginationQuery) (*models.MediaList, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &models.MediaFilter{UserID: user.ID}
+	if postID != 0 {
+		post, err := u.postRepo.GetByID(ctx, postID)
+		if err != nil {
+			return nil, err
+		}
+		if err := utils.ValidateIsOwner(ctx, post.UserID, models.PermPostsEditAny, u.logger); err != nil {
+			return nil, err
+		}
+		filter = &models.MediaFilter{PostID: postID}
+	}
+
+	list, err := u.repo.List(ctx, filter, pq)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range list.Media {
+		u.withURLs(m)
+	}
+	return list, nil
+}
+
+// Delete implements media.UseCase.
+// Stored files are removed after the row; failures there are only logged.
+func (u *usecase) Delete(ctx context.Context, mediaID int) error {
+	m, err := u.repo.GetByID(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+	if err := utils.ValidateIsOwner(ctx, m.UserID, models.PermMediaManageAny, u.logger); err != nil {
+		return err
+	}
+
+	if err := u.repo.Delete(ctx, mediaID); err != nil {
+		return err
+	}
+
+	keys := []string{m.StorageKey}
+	if m.ThumbnailKey != nil {
+		keys = append(keys, *m.ThumbnailKey)
+	}
+	u.removeObjects(ctx, keys)
+
+	return nil
+}
+
+// thumbnail encodes a downscaled copy, as PNG for formats that may be transparent
+func (u *usecase) thumbnail(data []byte, mimeType string) ([]byte, string, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", media.ErrInvalidImage
+	}
+	thumb := imaging.Thumbnail(img, u.thumbnailWidth())
+
+	var buf bytes.Buffer
+	if mimeType == "image/jpeg" {
+		err = jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: jpegQuality})
+		return buf.Bytes(), ".jpg", err
+	}
+	err = png.Encode(&buf, thumb)
+	return buf.Bytes(), ".png", err
+}
+
+func (u *usecase) thumbnailWidth() int {
+	if u.cfg.Media.ThumbnailWidth > 0 {
+		return u.cfg.Media.ThumbnailWidth
+	}
+	return DefaultThumbnailWidth
+}
+
+func (u *usecase) withURLs(m *models.Media) *models.Media {
+	m.URL = u.storage.URL(m.StorageKey)
+	if m.ThumbnailKey != nil {
+		url := u.storage.URL(*m.ThumbnailKey)
+		m.ThumbnailURL = &url
+	}
+	return m
+}
+
+func (u *usecase) removeObjects(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := u.storage.Delete(ctx, key); err != nil {
+			u.logger.Errorf(ctx, "Failed to delete media object %s: %v", key, err)
+		}
+	}
+}
+
+// cleanFilename keeps the base name of the client file name, falling back to
+// "upload" plus the sniffed extension
+func cleanFilename(name, ext string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "upload" + ext
+	}
+	for utf8.RuneCountInString(name) > maxFilenameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
+
+func thumbnailType(ext string) string {
+	if ext == ".jpg" {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
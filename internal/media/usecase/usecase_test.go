@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/media"
+	mock "github.com/ductong169z/shorten-url/internal/media/mock"
+	"github.com/ductong169z/shorten-url/internal/models"
+	postMock "github.com/ductong169z/shorten-url/internal/posts/mock"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/storage"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
+)
+
+func withUser(user *models.User) context.Context {
+	return context.WithValue(context.Background(), utils.UserCtxKey{}, user)
+}
+
+func pngImage(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestUseCase_Upload(t *testing.T) {
+	author := &models.User{ID: 7, Role: models.RoleUser}
+	postID := 3
+	large := pngImage(t, 200, 100)
+	small := pngImage(t, 40, 20)
+
+	tcs := map[string]struct {
+		data         []byte
+		filename     string
+		postID       *int
+		postOwner    int
+		maxSize      int64
+		expErr       error
+		expThumbnail bool
+		expWidth     int
+	}{
+		"image with thumbnail": {
+			data:         large,
+			filename:     "../../photo.png",
+			postID:       &postID,
+			postOwner:    author.ID,
+			expThumbnail: true,
+			expWidth:     200,
+		},
+		"small image without thumbnail": {
+			data:     small,
+			filename: "small.png",
+			expWidth: 40,
+		},
+		"sniffed type is not an image": {
+			data:     []byte("<html><body>not an image</body></html>"),
+			filename: "photo.png",
+			expErr:   media.ErrUnsupportedMediaType,
+		},
+		"too large": {
+			data:     large,
+			filename: "photo.png",
+			maxSize:  100,
+			expErr:   media.ErrFileTooLarge,
+		},
+		"empty": {
+			filename: "photo.png",
+			expErr:   media.ErrEmptyFile,
+		},
+		"post of another user": {
+			data:      small,
+			filename:  "small.png",
+			postID:    &postID,
+			postOwner: 8,
+			expErr:    pkgErrors.Forbidden,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{Media: config.MediaConfig{MaxUploadSize: tc.maxSize, ThumbnailWidth: 100}}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			dir := t.TempDir()
+			repo := mock.NewMockRepository(ctrl)
+			postRepo := postMock.NewMockRepository(ctrl)
+			uc := NewUseCase(cfg, repo, postRepo, storage.NewLocal(dir, "http://localhost/media"), apiLogger)
+
+			if tc.postID != nil {
+				postRepo.EXPECT().GetByID(gomock.Any(), *tc.postID).Return(&models.Post{ID: *tc.postID, UserID: tc.postOwner}, nil)
+			}
+			if tc.expErr == nil {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, m *models.Media) (*models.Media, error) { return m, nil },
+				)
+			}
+
+			m, err := uc.Upload(withUser(author), &models.MediaUpload{
+				Filename: tc.filename,
+				Size:     int64(len(tc.data)),
+				File:     bytes.NewReader(tc.data),
+				PostID:   tc.postID,
+			})
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, "image/png", m.MimeType)
+			assert.Equal(t, filepath.Base(tc.filename), m.Filename)
+			assert.Equal(t, tc.expWidth, *m.Width)
+			assert.True(t, strings.HasSuffix(m.StorageKey, ".png"))
+			assert.Equal(t, "http://localhost/media/"+m.StorageKey, m.URL)
+
+			stored, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(m.StorageKey)))
+			require.NoError(t, err)
+			assert.Equal(t, tc.data, stored)
+
+			if !tc.expThumbnail {
+				assert.Nil(t, m.ThumbnailKey)
+				return
+			}
+			thumb, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(*m.ThumbnailKey)))
+			require.NoError(t, err)
+			thumbCfg, err := png.DecodeConfig(bytes.NewReader(thumb))
+			require.NoError(t, err)
+			assert.Equal(t, 100, thumbCfg.Width)
+			assert.Equal(t, 50, thumbCfg.Height)
+		})
+	}
+}
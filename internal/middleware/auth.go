@@ -2,10 +2,9 @@ package middleware
 
 import (
 	"context"
-	"fmt"
 	"net/http"
+	"time"
 
-	"github.com/ductong169z/shorten-url/config"
 	"github.com/ductong169z/shorten-url/internal/models"
 	"github.com/ductong169z/shorten-url/pkg/errors"
 	"github.com/ductong169z/shorten-url/pkg/utils"
@@ -20,7 +19,7 @@ func (mw *MiddlewareManager) AuthJWTMiddleware() gin.HandlerFunc {
 		tokenString := c.GetHeader("Authorization")
 		ctx := c.Request.Context()
 		mw.logger.Infof(ctx, "auth middleware header %s", tokenString)
-		if err := mw.validateJWTToken(tokenString, c, mw.cfg); err != nil {
+		if err := mw.validateJWTToken(tokenString, c); err != nil {
 			mw.logger.Error(ctx, "middleware validateJWTToken", zap.String("headerJWT", err.Error()))
 			c.JSON(http.StatusUnauthorized, errors.NewUnauthorizedError(errors.Unauthorized))
 			c.Abort()
@@ -29,18 +28,77 @@ func (mw *MiddlewareManager) AuthJWTMiddleware() gin.HandlerFunc {
 	}
 }
 
-func (mw *MiddlewareManager) validateJWTToken(tokenString string, c *gin.Context, cfg *config.Config) error {
+// OptionalAuthJWTMiddleware puts the user in context when an Authorization header is sent
+// and lets anonymous requests through. An invalid token is still rejected with 401.
+func (mw *MiddlewareManager) OptionalAuthJWTMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := c.GetHeader("Authorization")
+		if tokenString == "" {
+			c.Next()
+			return
+		}
+		if err := mw.validateJWTToken(tokenString, c); err != nil {
+			mw.logger.Error(c.Request.Context(), "middleware validateJWTToken", zap.String("headerJWT", err.Error()))
+			c.JSON(http.StatusUnauthorized, errors.NewUnauthorizedError(errors.Unauthorized))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequirePermission aborts with 403 unless the role of the authenticated user is granted
+// all of the given permissions. It must be registered after AuthJWTMiddleware.
+func (mw *MiddlewareManager) RequirePermission(perms ...models.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := utils.GetUserFromCtx(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, errors.NewUnauthorizedError(errors.Unauthorized))
+			c.Abort()
+			return
+		}
+		for _, perm := range perms {
+			if !user.Role.Can(perm) {
+				mw.logger.Errorf(c.Request.Context(), "RequirePermission, userID: %v, role: %v, permission: %v", user.ID, user.Role, perm)
+				c.JSON(http.StatusForbidden, errors.NewForbiddenError(errors.Forbidden))
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// RequireVerifiedEmail aborts with 403 when REQUIRE_VERIFIED_EMAIL_FOR_POSTS is set and the
+// authenticated user has not verified their email. It must be registered after AuthJWTMiddleware.
+func (mw *MiddlewareManager) RequireVerifiedEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !mw.cfg.Auth.RequireVerifiedForPosts {
+			c.Next()
+			return
+		}
+		user, err := utils.GetUserFromCtx(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, errors.NewUnauthorizedError(errors.Unauthorized))
+			c.Abort()
+			return
+		}
+		if !user.EmailVerified {
+			c.JSON(http.StatusForbidden, errors.NewForbiddenError("email not verified"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func (mw *MiddlewareManager) validateJWTToken(tokenString string, c *gin.Context) error {
 	if tokenString == "" {
 		return errors.InvalidJWTToken
 	}
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signin method %v", token.Header["alg"])
-		}
-		secret := []byte(cfg.Server.JwtSecretKey)
-		return secret, nil
-	})
+	// The key is selected by the kid header and has to match the alg of the token
+	token, err := jwt.Parse(tokenString, mw.jwtKeys.Keyfunc)
 	if err != nil {
 		return err
 	}
@@ -72,15 +130,39 @@ func (mw *MiddlewareManager) validateJWTToken(tokenString string, c *gin.Context
 		if err != nil {
 			return errors.InvalidJWTClaims
 		}
+		// Missing in tokens issued before email verification existed
+		emailVerified, _ := claims["email_verified"].(bool)
+		exp, ok := claims["exp"].(float64)
+		if !ok {
+			return errors.InvalidJWTClaims
+		}
+		// Tokens issued before the jti claim existed cannot be logged out
+		jti, _ := claims["jti"].(string)
+		if jti != "" {
+			revoked, err := mw.authUC.IsAccessTokenRevoked(c.Request.Context(), jti)
+			if err != nil {
+				return err
+			}
+			if revoked {
+				return errors.InvalidJWTToken
+			}
+		}
 
 		userData := &models.User{
-			ID:       userId,
-			Username: userName,
-			Email:    email,
-			Role:     roleStr,
+			ID:            userId,
+			Username:      userName,
+			Email:         email,
+			EmailVerified: emailVerified,
+			Role:          roleStr,
+		}
+
+		accessToken := &utils.AccessToken{
+			ID:        jti,
+			ExpiresAt: time.Unix(int64(exp), 0),
 		}
 
 		ctx := context.WithValue(c.Request.Context(), utils.UserCtxKey{}, userData)
+		ctx = context.WithValue(ctx, utils.AccessTokenCtxKey{}, accessToken)
 		c.Request = c.Request.WithContext(ctx)
 	}
 	return nil
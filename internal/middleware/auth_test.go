@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/jwtkeys"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareManager_RequirePermission(t *testing.T) {
+	tcs := map[string]struct {
+		user    *models.User
+		perms   []models.Permission
+		expCode int
+	}{
+		"anonymous": {
+			perms:   []models.Permission{models.PermPostsCreate},
+			expCode: http.StatusUnauthorized,
+		},
+		"granted": {
+			user:    &models.User{ID: 1, Role: models.RoleAuthor},
+			perms:   []models.Permission{models.PermPostsCreate},
+			expCode: http.StatusOK,
+		},
+		"not granted": {
+			user:    &models.User{ID: 1, Role: models.RoleReader},
+			perms:   []models.Permission{models.PermPostsCreate},
+			expCode: http.StatusForbidden,
+		},
+		"all permissions granted": {
+			user:    &models.User{ID: 1, Role: models.RoleEditor},
+			perms:   []models.Permission{models.PermPostsEditAny, models.PermCategoriesManage},
+			expCode: http.StatusOK,
+		},
+		"one permission missing": {
+			user:    &models.User{ID: 1, Role: models.RoleEditor},
+			perms:   []models.Permission{models.PermPostsEditAny, models.PermUsersManage},
+			expCode: http.StatusForbidden,
+		},
+		"admin": {
+			user:    &models.User{ID: 1, Role: models.RoleAdmin},
+			perms:   []models.Permission{models.PermUsersManage},
+			expCode: http.StatusOK,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			mw := NewMiddlewareManager(cfg, nil, nil, jwtkeys.NewHMAC("secret"), apiLogger)
+
+			router := gin.New()
+			router.GET("/", func(c *gin.Context) {
+				if tc.user != nil {
+					ctx := context.WithValue(c.Request.Context(), utils.UserCtxKey{}, tc.user)
+					c.Request = c.Request.WithContext(ctx)
+				}
+			}, mw.RequirePermission(tc.perms...), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Equal(t, tc.expCode, w.Code)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/auth"
+	"github.com/ductong169z/shorten-url/pkg/jwtkeys"
 	"github.com/ductong169z/shorten-url/pkg/logger"
 )
 
@@ -9,10 +11,12 @@ import (
 type MiddlewareManager struct {
 	cfg     *config.Config
 	origins []string
+	authUC  auth.UseCase
+	jwtKeys *jwtkeys.KeySet
 	logger  logger.Logger
 }
 
 // Middleware manager constructor
-func NewMiddlewareManager(cfg *config.Config, origins []string, logger logger.Logger) *MiddlewareManager {
-	return &MiddlewareManager{cfg: cfg, origins: origins, logger: logger}
+func NewMiddlewareManager(cfg *config.Config, origins []string, authUC auth.UseCase, jwtKeys *jwtkeys.KeySet, logger logger.Logger) *MiddlewareManager {
+	return &MiddlewareManager{cfg: cfg, origins: origins, authUC: authUC, jwtKeys: jwtKeys, logger: logger}
 }
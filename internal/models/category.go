@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+type Category struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Description *string   `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// All Categories response
+type CategoryList struct {
+	TotalCount int64       `json:"total_count"`
+	TotalPages int         `json:"total_pages"`
+	Page       int         `json:"page"`
+	Size       int         `json:"size"`
+	HasMore    bool        `json:"has_more"`
+	Categories []*Category `json:"categories"`
+}
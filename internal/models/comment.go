@@ -0,0 +1,74 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type Comment struct {
+	ID          int           `json:"id"`
+	PostID      int           `json:"post_id"`
+	UserID      *int          `json:"user_id,omitempty"`
+	ParentID    *int          `json:"parent_id,omitempty"`
+	AuthorName  *string       `json:"author_name,omitempty"`
+	AuthorEmail *string       `json:"author_email,omitempty"`
+	Content     string        `json:"content"`
+	Status      CommentStatus `json:"status" gorm:"type:varchar(20)"`
+	Username    string        `json:"username,omitempty" gorm:"->"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	Replies     []*Comment    `json:"replies,omitempty" gorm:"-"`
+}
+
+// IsGuest reports whether the comment was written by an anonymous author
+func (c *Comment) IsGuest() bool {
+	return c.UserID == nil
+}
+
+type CommentStatus string
+
+const (
+	CommentStatusPending  CommentStatus = "pending"
+	CommentStatusApproved CommentStatus = "approved"
+	CommentStatusRejected CommentStatus = "rejected"
+	CommentStatusSpam     CommentStatus = "spam"
+)
+
+func (s CommentStatus) String() string {
+	return string(s)
+}
+
+var validCommentStatuses = map[CommentStatus]struct{}{
+	CommentStatusPending:  {},
+	CommentStatusApproved: {},
+	CommentStatusRejected: {},
+	CommentStatusSpam:     {},
+}
+
+func (s CommentStatus) IsValid() bool {
+	_, ok := validCommentStatuses[s]
+	return ok
+}
+
+// IsModerated reports whether s is a moderation decision rather than the initial state
+func (s CommentStatus) IsModerated() bool {
+	return s.IsValid() && s != CommentStatusPending
+}
+
+func ParseCommentStatus(s string) (CommentStatus, error) {
+	status := CommentStatus(s)
+	if !status.IsValid() {
+		return "", fmt.Errorf("invalid comment status: %s", s)
+	}
+	return status, nil
+}
+
+// All Comments response; the list is paginated by top-level comments
+type CommentList struct {
+	TotalCount int64      `json:"total_count"`
+	TotalPages int        `json:"total_pages"`
+	Page       int        `json:"page"`
+	Size       int        `json:"size"`
+	HasMore    bool       `json:"has_more"`
+	Comments   []*Comment `json:"comments"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+type FeedFormat string
+
+const (
+	FeedFormatRSS  FeedFormat = "rss"
+	FeedFormatAtom FeedFormat = "atom"
+)
+
+func (f FeedFormat) IsValid() bool {
+	return f == FeedFormatRSS || f == FeedFormatAtom
+}
+
+// Feed request; at most one of CategorySlug and TagSlug is set
+type FeedQuery struct {
+	Format       FeedFormat
+	CategorySlug string
+	TagSlug      string
+}
+
+// Feed is a rendered syndication document with its validators
+type Feed struct {
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"last_modified"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"io"
+	"time"
+)
+
+// Media is an uploaded file stored through the configured storage backend
+type Media struct {
+	ID           int       `json:"id"`
+	UserID       int       `json:"user_id"`
+	PostID       *int      `json:"post_id,omitempty"`
+	StorageKey   string    `json:"storage_key"`
+	ThumbnailKey *string   `json:"thumbnail_key,omitempty"`
+	Filename     string    `json:"filename"`
+	MimeType     string    `json:"mime_type"`
+	Size         int64     `json:"size"`
+	Width        *int      `json:"width,omitempty"`
+	Height       *int      `json:"height,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// Public URLs resolved from the storage keys
+	URL          string  `json:"url" gorm:"-"`
+	ThumbnailURL *string `json:"thumbnail_url,omitempty" gorm:"-"`
+}
+
+func (*Media) TableName() string {
+	return "media"
+}
+
+// MediaUpload is a file received for upload, optionally linked to a post
+type MediaUpload struct {
+	Filename string
+	Size     int64
+	File     io.Reader
+	PostID   *int
+}
+
+// Media list filter
+type MediaFilter struct {
+	UserID int
+	PostID int
+}
+
+// All Media response
+type MediaList struct {
+	TotalCount int64    `json:"total_count"`
+	TotalPages int      `json:"total_pages"`
+	Page       int      `json:"page"`
+	Size       int      `json:"size"`
+	HasMore    bool     `json:"has_more"`
+	Media      []*Media `json:"media"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// PasswordResetToken lets the owner of an email address set a new password once
+type PasswordResetToken struct {
+	ID        int        `json:"id" gorm:"primaryKey"`
+	UserID    int        `json:"user_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"not null;unique"` // SHA-256 of the emailed token
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,63 @@
+package models
+
+// Permission is an action a role may be granted, named resource:action
+type Permission string
+
+const (
+	PermPostsCreate        Permission = "posts:create"
+	PermPostsPublish       Permission = "posts:publish"
+	PermPostsEditAny       Permission = "posts:edit_any"
+	PermCommentsModerate   Permission = "comments:moderate"
+	PermCategoriesManage   Permission = "categories:manage"
+	PermTagsCreate         Permission = "tags:create"
+	PermMediaUpload        Permission = "media:upload"
+	PermMediaManageAny     Permission = "media:manage_any"
+	PermShortURLsManageAny Permission = "short_urls:manage_any"
+	PermUsersManage        Permission = "users:manage"
+)
+
+func (p Permission) String() string {
+	return string(p)
+}
+
+// authorPermissions are granted to anyone who writes their own posts
+var authorPermissions = []Permission{
+	PermPostsCreate,
+	PermPostsPublish,
+	PermTagsCreate,
+	PermMediaUpload,
+}
+
+// editorPermissions add managing the content of other users
+var editorPermissions = append([]Permission{
+	PermPostsEditAny,
+	PermCommentsModerate,
+	PermCategoriesManage,
+	PermMediaManageAny,
+}, authorPermissions...)
+
+// rolePermissions is the permission matrix. Admins are granted everything and
+// are not listed; readers may only comment, which needs no permission.
+var rolePermissions = map[UserRole]map[Permission]struct{}{
+	RoleEditor: permissionSet(editorPermissions),
+	RoleAuthor: permissionSet(authorPermissions),
+	RoleUser:   permissionSet(authorPermissions),
+	RoleReader: {},
+}
+
+func permissionSet(perms []Permission) map[Permission]struct{} {
+	set := make(map[Permission]struct{}, len(perms))
+	for _, p := range perms {
+		set[p] = struct{}{}
+	}
+	return set
+}
+
+// Can reports whether the role is granted the permission
+func (r UserRole) Can(p Permission) bool {
+	if r == RoleAdmin {
+		return true
+	}
+	_, ok := rolePermissions[r][p]
+	return ok
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserRole_Can(t *testing.T) {
+	all := []Permission{
+		PermPostsCreate,
+		PermPostsPublish,
+		PermPostsEditAny,
+		PermCommentsModerate,
+		PermCategoriesManage,
+		PermTagsCreate,
+		PermMediaUpload,
+		PermMediaManageAny,
+		PermShortURLsManageAny,
+		PermUsersManage,
+	}
+	author := []Permission{PermPostsCreate, PermPostsPublish, PermTagsCreate, PermMediaUpload}
+	editor := append([]Permission{PermPostsEditAny, PermCommentsModerate, PermCategoriesManage, PermMediaManageAny}, author...)
+
+	tcs := map[string]struct {
+		role    UserRole
+		granted []Permission
+	}{
+		"admin":        {role: RoleAdmin, granted: all},
+		"editor":       {role: RoleEditor, granted: editor},
+		"author":       {role: RoleAuthor, granted: author},
+		"legacy user":  {role: RoleUser, granted: author},
+		"reader":       {role: RoleReader},
+		"default role": {role: DefaultUserRole},
+		"unknown role": {role: "superuser"},
+		"empty role":   {role: ""},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			granted := make(map[Permission]bool, len(tc.granted))
+			for _, p := range tc.granted {
+				granted[p] = true
+			}
+			for _, p := range all {
+				assert.Equal(t, granted[p], tc.role.Can(p), p)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type Post struct {
+	ID          int        `json:"id"`
+	UserID      int        `json:"user_id"`
+	CategoryID  *int       `json:"category_id,omitempty"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	Content     string     `json:"content"`
+	Status      PostStatus `json:"status" gorm:"type:varchar(20)"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// IsScheduled reports whether the post is a draft waiting for its publish time
+func (p *Post) IsScheduled() bool {
+	return p.Status == PostStatusDraft && p.PublishedAt != nil
+}
+
+type PostStatus string
+
+const (
+	PostStatusDraft     PostStatus = "draft"
+	PostStatusPublished PostStatus = "published"
+	PostStatusArchived  PostStatus = "archived"
+)
+
+func (s PostStatus) String() string {
+	return string(s)
+}
+
+var validPostStatuses = map[PostStatus]struct{}{
+	PostStatusDraft:     {},
+	PostStatusPublished: {},
+	PostStatusArchived:  {},
+}
+
+func (s PostStatus) IsValid() bool {
+	_, ok := validPostStatuses[s]
+	return ok
+}
+
+// postStatusTransitions lists the statuses a post may move to from each status
+var postStatusTransitions = map[PostStatus][]PostStatus{
+	PostStatusDraft:     {PostStatusPublished},
+	PostStatusPublished: {PostStatusDraft, PostStatusArchived},
+	PostStatusArchived:  {PostStatusDraft},
+}
+
+// CanTransitionTo reports whether a post in status s may move to next
+func (s PostStatus) CanTransitionTo(next PostStatus) bool {
+	for _, allowed := range postStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func ParsePostStatus(s string) (PostStatus, error) {
+	status := PostStatus(s)
+	if !status.IsValid() {
+		return "", fmt.Errorf("invalid post status: %s", s)
+	}
+	return status, nil
+}
+
+// PostSlugRedirect maps a slug a post used before to the post
+type PostSlugRedirect struct {
+	ID        int       `json:"id"`
+	PostID    int       `json:"post_id"`
+	OldSlug   string    `json:"old_slug"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (*PostSlugRedirect) TableName() string {
+	return "post_slug_redirects"
+}
+
+// PostRevision is a snapshot of a post's title and content saved on every edit
+type PostRevision struct {
+	ID        int       `json:"id"`
+	PostID    int       `json:"post_id"`
+	UserID    int       `json:"user_id"`
+	Username  string    `json:"username" gorm:"->"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (*PostRevision) TableName() string {
+	return "post_revisions"
+}
+
+// Post revisions response, newest first
+type PostRevisionList struct {
+	TotalCount int64           `json:"total_count"`
+	TotalPages int             `json:"total_pages"`
+	Page       int             `json:"page"`
+	Size       int             `json:"size"`
+	HasMore    bool            `json:"has_more"`
+	Revisions  []*PostRevision `json:"revisions"`
+}
+
+// Unified diff between two revisions of a post
+type PostRevisionDiff struct {
+	From *PostRevision `json:"from"`
+	To   *PostRevision `json:"to"`
+	Diff string        `json:"diff"`
+}
+
+// Post list filter
+type PostFilter struct {
+	Status     PostStatus
+	UserID     int
+	CategoryID int
+	TagID      int
+}
+
+// All Posts response
+type PostList struct {
+	TotalCount int64   `json:"total_count"`
+	TotalPages int     `json:"total_pages"`
+	Page       int     `json:"page"`
+	Size       int     `json:"size"`
+	HasMore    bool    `json:"has_more"`
+	Posts      []*Post `json:"posts"`
+}
+
+// PostSearchMode selects how MySQL interprets a full-text search query
+type PostSearchMode string
+
+const (
+	PostSearchModeNatural PostSearchMode = "natural"
+	PostSearchModeBoolean PostSearchMode = "boolean"
+)
+
+func (m PostSearchMode) IsValid() bool {
+	return m == PostSearchModeNatural || m == PostSearchModeBoolean
+}
+
+// Post search query; empty filters are ignored
+type PostSearchQuery struct {
+	Query          string
+	Mode           PostSearchMode
+	CategorySlug   string
+	TagSlug        string
+	AuthorUsername string
+	From           *time.Time
+	To             *time.Time
+}
+
+// Post search hit with its relevance score and highlighted snippet
+type PostSearchResult struct {
+	Post    `gorm:"embedded"`
+	Score   float64 `json:"score"`
+	Snippet string  `json:"snippet" gorm:"-"`
+}
+
+// Post search response ordered by relevance
+type PostSearchList struct {
+	TotalCount int64               `json:"total_count"`
+	TotalPages int                 `json:"total_pages"`
+	Page       int                 `json:"page"`
+	Size       int                 `json:"size"`
+	HasMore    bool                `json:"has_more"`
+	Results    []*PostSearchResult `json:"results"`
+}
@@ -8,7 +8,19 @@ type RefreshToken struct {
 	ID        int       `json:"id" gorm:"primaryKey"`
 	UserID    int       `json:"user_id" gorm:"not null;index"`
 	Token     string    `json:"token" gorm:"not null;unique"`
+	FamilyID  string    `json:"family_id" gorm:"not null;index"` // Shared by a login token and every token rotated from it
 	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
 	Revoked   bool      `json:"revoked" gorm:"not null;default:false"`
+
+	// Device the token was issued to
+	UserAgent  string    `json:"user_agent" gorm:"not null"`
+	IP         string    `json:"ip" gorm:"not null"`
+	LastUsedAt time.Time `json:"last_used_at" gorm:"not null"`
+}
+
+// SessionClient describes the device a login or token refresh comes from
+type SessionClient struct {
+	UserAgent string
+	IP        string
 }
@@ -6,6 +6,7 @@ import (
 
 type ShortURL struct {
 	ID          uint64     `db:"id" json:"id"`
+	UserID      *int       `db:"user_id" json:"user_id,omitempty"`
 	OriginalURL string     `db:"original_url" json:"original_url"`
 	ShortCode   string     `db:"short_code" json:"short_code"`
 	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
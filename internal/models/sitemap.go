@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+type SitemapURLKind string
+
+const (
+	SitemapURLPost     SitemapURLKind = "post"
+	SitemapURLCategory SitemapURLKind = "category"
+	SitemapURLTag      SitemapURLKind = "tag"
+)
+
+// SitemapURL is a public page listed in the sitemap
+type SitemapURL struct {
+	Kind      SitemapURLKind `json:"kind"`
+	Slug      string         `json:"slug"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// Sitemap is a rendered urlset or sitemap index document
+type Sitemap struct {
+	Body []byte `json:"body"`
+}
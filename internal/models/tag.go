@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+)
+
+type Tag struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PostTag is a row of the post_tags pivot table
+type PostTag struct {
+	PostID int `json:"post_id" gorm:"primaryKey"`
+	TagID  int `json:"tag_id" gorm:"primaryKey"`
+}
+
+func (*PostTag) TableName() string {
+	return "post_tags"
+}
+
+// TagCount is a tag with the number of published posts using it
+type TagCount struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	PostCount int64  `json:"post_count"`
+}
+
+// All Tags response
+type TagList struct {
+	TotalCount int64  `json:"total_count"`
+	TotalPages int    `json:"total_pages"`
+	Page       int    `json:"page"`
+	Size       int    `json:"size"`
+	HasMore    bool   `json:"has_more"`
+	Tags       []*Tag `json:"tags"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+)
+
+// UserTOTP is the authenticator app secret of a user. Two-factor authentication is only
+// enabled once the user confirmed enrollment with a valid code.
+type UserTOTP struct {
+	UserID       int        `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	Secret       string     `json:"-"` // Encrypted with the configured TOTP key, see totp.Seal
+	ConfirmedAt  *time.Time `json:"confirmed_at"`
+	LastUsedStep int64      `json:"-"` // Time step of the last accepted code, so codes cannot be replayed
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (*UserTOTP) TableName() string {
+	return "user_totp"
+}
+
+// Enabled reports whether enrollment was confirmed
+func (t *UserTOTP) Enabled() bool {
+	return t != nil && t.ConfirmedAt != nil
+}
+
+// UserRecoveryCode is a single-use code that replaces a TOTP code when the authenticator is lost
+type UserRecoveryCode struct {
+	ID        int        `json:"id" gorm:"primaryKey"`
+	UserID    int        `json:"user_id" gorm:"not null;index"`
+	CodeHash  string     `json:"-" gorm:"not null"` // SHA-256 of the normalized code
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TOTPEnrollment is what an authenticator app needs to be set up
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// LoginChallenge is issued instead of tokens when a user with two-factor authentication
+// logs in with their password; the login completes once it is answered with a code.
+// Username is the one the password was given for, so wrong codes count against it.
+type LoginChallenge struct {
+	Token     string    `json:"-"`
+	UserID    int       `json:"user_id"`
+	Username  string    `json:"username"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -6,20 +6,30 @@ import (
 )
 
 type User struct {
-	ID        int       `json:"id"`
-	Username  string    `json:"username" validate:"required"`
-	Email     string    `json:"email" validate:"required,email"`
-	Password  string    `json:"password" validate:"required"`
-	Role      UserRole  `json:"role" validate:"required" gorm:"type:varchar(50)"` // Use the UserRole type, specify DB column type
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            int       `json:"id"`
+	Username      string    `json:"username" validate:"required"`
+	Email         string    `json:"email" validate:"required,email"`
+	EmailVerified bool      `json:"email_verified"`
+	Password      string    `json:"password" validate:"required"`
+	Role          UserRole  `json:"role" validate:"required" gorm:"type:varchar(50)"` // Use the UserRole type, specify DB column type
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 type UserRole string
 
 const (
-	RoleAdmin UserRole = "admin"
-	RoleUser  UserRole = "user"
+	RoleAdmin  UserRole = "admin"
+	RoleEditor UserRole = "editor"
+	RoleAuthor UserRole = "author"
+	RoleReader UserRole = "reader"
+	// RoleUser is the role of accounts created before editor, author and reader
+	// existed; it keeps the permissions of an author.
+	RoleUser UserRole = "user"
+
+	// DefaultUserRole is the role of every registered account; other roles are
+	// granted by users with the users:manage permission.
+	DefaultUserRole = RoleReader
 )
 
 func (r UserRole) String() string {
@@ -27,8 +37,11 @@ func (r UserRole) String() string {
 }
 
 var validUserRoles = map[UserRole]struct{}{
-	RoleAdmin: {},
-	RoleUser:  {},
+	RoleAdmin:  {},
+	RoleEditor: {},
+	RoleAuthor: {},
+	RoleReader: {},
+	RoleUser:   {},
 }
 
 func (r UserRole) IsValid() bool {
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// UserProfile is the public profile of a user; users without a row have an empty profile
+type UserProfile struct {
+	UserID      int         `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	DisplayName string      `json:"display_name"`
+	Bio         string      `json:"bio"`
+	AvatarURL   string      `json:"avatar_url"`
+	SocialLinks SocialLinks `json:"social_links" gorm:"type:json"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+func (*UserProfile) TableName() string {
+	return "user_profiles"
+}
+
+// SocialLinks maps a network name such as "github" to a profile URL, stored as JSON
+type SocialLinks map[string]string
+
+// Value implements driver.Valuer.
+func (l SocialLinks) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner.
+func (l *SocialLinks) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("unsupported social links type: %T", value)
+	}
+}
+
+// UserProfileUpdate holds the profile fields to change; nil fields are left as they are
+type UserProfileUpdate struct {
+	DisplayName *string
+	Bio         *string
+	AvatarURL   *string
+	SocialLinks *SocialLinks
+}
+
+// Author is a user with their public profile and published posts
+type Author struct {
+	User    *User
+	Profile *UserProfile
+	Posts   *PostList
+}
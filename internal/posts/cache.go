@@ -0,0 +1,15 @@
+//go:generate mockgen -source cache.go -destination mock/cache_mock.go -package mock
+package posts
+
+import (
+	"context"
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+)
+
+type Cache interface {
+	GetPostBySlug(ctx context.Context, slug string) (*models.Post, error)
+	SetPostBySlug(ctx context.Context, slug string, post *models.Post, ttl time.Duration) error
+	DeletePostBySlug(ctx context.Context, slug string) error
+}
@@ -0,0 +1,21 @@
+//go:generate mockgen -source delivery.go -destination mock/handlers_mock.go -package mock
+package posts
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type Handlers interface {
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	GetBySlug(c *gin.Context)
+	List(c *gin.Context)
+	Search(c *gin.Context)
+	Delete(c *gin.Context)
+	Publish(c *gin.Context)
+	Unpublish(c *gin.Context)
+	Archive(c *gin.Context)
+	ListRevisions(c *gin.Context)
+	DiffRevisions(c *gin.Context)
+	RestoreRevision(c *gin.Context)
+}
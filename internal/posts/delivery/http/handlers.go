@@ -0,0 +1,427 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/posts"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/response"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Posts handlers
+type handlers struct {
+	cfg     *config.Config
+	usecase posts.UseCase
+	logger  logger.Logger
+}
+
+// NewHandlers Posts handlers constructor
+func NewHandlers(cfg *config.Config, usecase posts.UseCase, logger logger.Logger) posts.Handlers {
+	return &handlers{cfg: cfg, usecase: usecase, logger: logger}
+}
+
+// Create godoc
+// @Summary      Create post
+// @Description  Create a new draft post authored by the current user; the slug is generated from the title when omitted
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Param        postRequest  body      PostRequest  true  "Post payload"
+// @Success      201          {object}  PostResponse
+// @Failure      400,401,409  {object}  response.Response
+// @Router       /posts [post]
+func (h *handlers) Create(c *gin.Context) {
+	var req PostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	post, err := h.usecase.Create(c.Request.Context(), req.ToModel())
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	response.WithCode(c, http.StatusCreated, FromPostModel(post))
+}
+
+// Update godoc
+// @Summary      Update post
+// @Description  Replace the title, slug, content and category of a post; without a slug a new one is generated when the title changes
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Param        id           path      int          true  "Post ID"
+// @Param        postRequest  body      PostRequest  true  "Post payload"
+// @Success      200          {object}  PostResponse
+// @Failure      400,401,403,404,409  {object}  response.Response
+// @Router       /posts/{id} [put]
+func (h *handlers) Update(c *gin.Context) {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	var req PostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	post := req.ToModel()
+	post.ID = postID
+	updated, err := h.usecase.Update(c.Request.Context(), post)
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	response.WithOK(c, FromPostModel(updated))
+}
+
+// GetBySlug godoc
+// @Summary      Get post by slug
+// @Description  Get a published post by its slug; a previous slug of the post redirects to the current one
+// @Tags         posts
+// @Produce      json
+// @Param        slug  path      string  true  "Post slug"
+// @Success      200   {object}  PostResponse
+// @Success      301   {string}  string  "Moved to the current slug"
+// @Header       301   {string}  Location  "Current post URL"
+// @Failure      404   {object}  response.Response
+// @Router       /posts/{slug} [get]
+func (h *handlers) GetBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+	post, err := h.usecase.GetBySlug(c.Request.Context(), slug)
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	if post.Slug != slug {
+		location := strings.TrimSuffix(c.Request.URL.Path, slug) + post.Slug
+		c.Redirect(http.StatusMovedPermanently, location)
+		return
+	}
+
+	response.WithOK(c, FromPostModel(post))
+}
+
+// List godoc
+// @Summary      List posts
+// @Description  List published posts with pagination
+// @Tags         posts
+// @Produce      json
+// @Param        page     query     int     false  "Page number"
+// @Param        size     query     int     false  "Page size"
+// @Param        orderBy  query     string  false  "Sort order (created_at, -created_at, published_at, -published_at, title, -title)"
+// @Success      200      {object}  PostListResponse
+// @Failure      400      {object}  response.Response
+// @Router       /posts [get]
+func (h *handlers) List(c *gin.Context) {
+	pq, err := utils.GetPaginationFromCtx(c)
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	list, err := h.usecase.List(c.Request.Context(), pq)
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	response.WithOK(c, FromPostListModel(list))
+}
+
+// Search godoc
+// @Summary      Search posts
+// @Description  Full-text search over published post titles and content, ordered by relevance
+// @Tags         posts
+// @Produce      json
+// @Param        q         query     string  true   "Search text"
+// @Param        mode      query     string  false  "Search mode (natural, boolean)"
+// @Param        category  query     string  false  "Category slug"
+// @Param        tag       query     string  false  "Tag slug"
+// @Param        author    query     string  false  "Author username"
+// @Param        from      query     string  false  "Published on or after (YYYY-MM-DD or RFC3339)"
+// @Param        to        query     string  false  "Published on or before (YYYY-MM-DD or RFC3339)"
+// @Param        page      query     int     false  "Page number"
+// @Param        size      query     int     false  "Page size"
+// @Success      200       {object}  PostSearchListResponse
+// @Failure      400       {object}  response.Response
+// @Router       /posts/search [get]
+func (h *handlers) Search(c *gin.Context) {
+	pq, err := utils.GetPaginationFromCtx(c)
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	query := &models.PostSearchQuery{
+		Query:          c.Query("q"),
+		Mode:           models.PostSearchMode(c.Query("mode")),
+		CategorySlug:   c.Query("category"),
+		TagSlug:        c.Query("tag"),
+		AuthorUsername: c.Query("author"),
+	}
+	if query.From, err = parseDateQuery(c.Query("from"), false); err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+	if query.To, err = parseDateQuery(c.Query("to"), true); err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	list, err := h.usecase.Search(c.Request.Context(), query, pq)
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	response.WithOK(c, FromPostSearchListModel(list))
+}
+
+// Delete godoc
+// @Summary      Delete post
+// @Description  Delete a post owned by the current user
+// @Tags         posts
+// @Param        id   path  int  true  "Post ID"
+// @Success      204
+// @Failure      400,401,403,404  {object}  response.Response
+// @Router       /posts/{id} [delete]
+func (h *handlers) Delete(c *gin.Context) {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	if err := h.usecase.Delete(c.Request.Context(), postID); err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	response.WithNoContent(c)
+}
+
+// Publish godoc
+// @Summary      Publish post
+// @Description  Publish a draft post now, or schedule it when published_at is in the future
+// @Tags         posts
+// @Accept       json
+// @Produce      json
+// @Param        id              path      int             true   "Post ID"
+// @Param        publishRequest  body      PublishRequest  false  "Optional publish time"
+// @Success      200             {object}  PostResponse
+// @Failure      400,401,403,404,409  {object}  response.Response
+// @Router       /posts/{id}/publish [post]
+func (h *handlers) Publish(c *gin.Context) {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	var req PublishRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.WithMappedError(c, err, posts.MapError)
+			return
+		}
+	}
+
+	post, err := h.usecase.Publish(c.Request.Context(), postID, req.PublishedAt)
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	response.WithOK(c, FromPostModel(post))
+}
+
+// Unpublish godoc
+// @Summary      Unpublish post
+// @Description  Move a published or archived post back to draft, cancelling any pending schedule
+// @Tags         posts
+// @Produce      json
+// @Param        id   path      int  true  "Post ID"
+// @Success      200  {object}  PostResponse
+// @Failure      400,401,403,404,409  {object}  response.Response
+// @Router       /posts/{id}/unpublish [post]
+func (h *handlers) Unpublish(c *gin.Context) {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	post, err := h.usecase.Unpublish(c.Request.Context(), postID)
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	response.WithOK(c, FromPostModel(post))
+}
+
+// Archive godoc
+// @Summary      Archive post
+// @Description  Archive a published post
+// @Tags         posts
+// @Produce      json
+// @Param        id   path      int  true  "Post ID"
+// @Success      200  {object}  PostResponse
+// @Failure      400,401,403,404,409  {object}  response.Response
+// @Router       /posts/{id}/archive [post]
+func (h *handlers) Archive(c *gin.Context) {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	post, err := h.usecase.Archive(c.Request.Context(), postID)
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	response.WithOK(c, FromPostModel(post))
+}
+
+// ListRevisions godoc
+// @Summary      List post revisions
+// @Description  List the saved revisions of a post owned by the current user, newest first
+// @Tags         posts
+// @Produce      json
+// @Param        id    path      int     true   "Post ID"
+// @Param        page  query     int     false  "Page number"
+// @Param        size  query     int     false  "Page size"
+// @Success      200   {object}  PostRevisionListResponse
+// @Failure      400,401,403,404  {object}  response.Response
+// @Router       /posts/{id}/revisions [get]
+func (h *handlers) ListRevisions(c *gin.Context) {
+	postID, err := revisionPostID(c)
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+	pq, err := utils.GetPaginationFromCtx(c)
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	list, err := h.usecase.ListRevisions(c.Request.Context(), postID, pq)
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	response.WithOK(c, FromPostRevisionListModel(list))
+}
+
+// DiffRevisions godoc
+// @Summary      Diff post revisions
+// @Description  Unified text diff of the title and content between two revisions of a post owned by the current user
+// @Tags         posts
+// @Produce      json
+// @Param        id    path      int     true  "Post ID"
+// @Param        from  query     int     true  "Revision ID to diff from"
+// @Param        to    query     int     true  "Revision ID to diff to"
+// @Success      200   {object}  PostRevisionDiffResponse
+// @Failure      400,401,403,404  {object}  response.Response
+// @Router       /posts/{id}/revisions/diff [get]
+func (h *handlers) DiffRevisions(c *gin.Context) {
+	postID, err := revisionPostID(c)
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+	fromID, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+	toID, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	d, err := h.usecase.DiffRevisions(c.Request.Context(), postID, fromID, toID)
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	response.WithOK(c, FromPostRevisionDiffModel(d))
+}
+
+// RestoreRevision godoc
+// @Summary      Restore post revision
+// @Description  Restore the title and content of an earlier revision; the restored content is saved as a new revision
+// @Tags         posts
+// @Produce      json
+// @Param        id          path      int  true  "Post ID"
+// @Param        revisionId  path      int  true  "Revision ID"
+// @Success      200         {object}  PostResponse
+// @Failure      400,401,403,404  {object}  response.Response
+// @Router       /posts/{id}/revisions/{revisionId}/restore [post]
+func (h *handlers) RestoreRevision(c *gin.Context) {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+	revisionID, err := strconv.Atoi(c.Param("revisionId"))
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	post, err := h.usecase.RestoreRevision(c.Request.Context(), postID, revisionID)
+	if err != nil {
+		response.WithMappedError(c, err, posts.MapError)
+		return
+	}
+
+	response.WithOK(c, FromPostModel(post))
+}
+
+// revisionPostID reads the post ID of the GET revision routes. Gin allows one
+// wildcard name per path segment, so these routes share the :slug wildcard of
+// GET /:slug and the value is parsed as the post ID.
+func revisionPostID(c *gin.Context) (int, error) {
+	return strconv.Atoi(c.Param("slug"))
+}
+
+// parseDateQuery accepts a date or an RFC3339 timestamp; a bare date used as an
+// upper bound covers the whole day.
+func parseDateQuery(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, posts.ErrInvalidSearchQuery
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
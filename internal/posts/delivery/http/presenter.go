@@ -0,0 +1,211 @@
+package http
+
+import (
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/markdown"
+)
+
+type PostRequest struct {
+	Title      string `json:"title" binding:"required"`
+	Slug       string `json:"slug,omitempty"`
+	Content    string `json:"content" binding:"required"` // Markdown source
+	CategoryID *int   `json:"category_id,omitempty"`
+}
+
+type PublishRequest struct {
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+type PostResponse struct {
+	ID          int               `json:"id"`
+	UserID      int               `json:"user_id"`
+	CategoryID  *int              `json:"category_id,omitempty"`
+	Title       string            `json:"title"`
+	Slug        string            `json:"slug"`
+	Content     string            `json:"content"`
+	ContentHTML string            `json:"content_html"`
+	TOC         []HeadingResponse `json:"toc"`
+	ReadingTime int               `json:"reading_time"`
+	Excerpt     string            `json:"excerpt"`
+	Status      string            `json:"status"`
+	PublishedAt *string           `json:"published_at,omitempty"`
+	CreatedAt   string            `json:"created_at"`
+	UpdatedAt   string            `json:"updated_at"`
+}
+
+type HeadingResponse struct {
+	Level  int    `json:"level"`
+	Title  string `json:"title"`
+	Anchor string `json:"anchor"`
+}
+
+type PostListResponse struct {
+	TotalCount int64          `json:"total_count"`
+	TotalPages int            `json:"total_pages"`
+	Page       int            `json:"page"`
+	Size       int            `json:"size"`
+	HasMore    bool           `json:"has_more"`
+	Posts      []PostResponse `json:"posts"`
+}
+
+type PostSearchResultResponse struct {
+	Post    PostResponse `json:"post"`
+	Score   float64      `json:"score"`
+	Snippet string       `json:"snippet"`
+}
+
+type PostSearchListResponse struct {
+	TotalCount int64                      `json:"total_count"`
+	TotalPages int                        `json:"total_pages"`
+	Page       int                        `json:"page"`
+	Size       int                        `json:"size"`
+	HasMore    bool                       `json:"has_more"`
+	Results    []PostSearchResultResponse `json:"results"`
+}
+
+type PostRevisionResponse struct {
+	ID        int    `json:"id"`
+	PostID    int    `json:"post_id"`
+	UserID    int    `json:"user_id"`
+	Username  string `json:"username"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
+}
+
+type PostRevisionListResponse struct {
+	TotalCount int64                  `json:"total_count"`
+	TotalPages int                    `json:"total_pages"`
+	Page       int                    `json:"page"`
+	Size       int                    `json:"size"`
+	HasMore    bool                   `json:"has_more"`
+	Revisions  []PostRevisionResponse `json:"revisions"`
+}
+
+type PostRevisionDiffResponse struct {
+	From PostRevisionResponse `json:"from"`
+	To   PostRevisionResponse `json:"to"`
+	Diff string               `json:"diff"`
+}
+
+func FormatTime(time time.Time) string {
+	return time.Format("2006-01-02 15:04:05")
+}
+
+func (r *PostRequest) ToModel() *models.Post {
+	return &models.Post{
+		Title:      r.Title,
+		Slug:       r.Slug,
+		Content:    r.Content,
+		CategoryID: r.CategoryID,
+	}
+}
+
+func FromPostModel(post *models.Post) PostResponse {
+	if post == nil {
+		return PostResponse{}
+	}
+
+	var publishedAt *string
+	if post.PublishedAt != nil {
+		v := FormatTime(*post.PublishedAt)
+		publishedAt = &v
+	}
+
+	doc := markdown.Render(post.Content)
+	toc := make([]HeadingResponse, len(doc.TOC))
+	for i, h := range doc.TOC {
+		toc[i] = HeadingResponse{Level: h.Level, Title: h.Title, Anchor: h.Anchor}
+	}
+
+	return PostResponse{
+		ID:          post.ID,
+		UserID:      post.UserID,
+		CategoryID:  post.CategoryID,
+		Title:       post.Title,
+		Slug:        post.Slug,
+		Content:     post.Content,
+		ContentHTML: doc.HTML,
+		TOC:         toc,
+		ReadingTime: doc.ReadingTime,
+		Excerpt:     doc.Excerpt,
+		Status:      post.Status.String(),
+		PublishedAt: publishedAt,
+		CreatedAt:   FormatTime(post.CreatedAt),
+		UpdatedAt:   FormatTime(post.UpdatedAt),
+	}
+}
+
+func FromPostListModel(list *models.PostList) PostListResponse {
+	postResponses := make([]PostResponse, len(list.Posts))
+	for i, post := range list.Posts {
+		postResponses[i] = FromPostModel(post)
+	}
+
+	return PostListResponse{
+		TotalCount: list.TotalCount,
+		TotalPages: list.TotalPages,
+		Page:       list.Page,
+		Size:       list.Size,
+		HasMore:    list.HasMore,
+		Posts:      postResponses,
+	}
+}
+
+func FromPostSearchListModel(list *models.PostSearchList) PostSearchListResponse {
+	results := make([]PostSearchResultResponse, len(list.Results))
+	for i, result := range list.Results {
+		results[i] = PostSearchResultResponse{
+			Post:    FromPostModel(&result.Post),
+			Score:   result.Score,
+			Snippet: result.Snippet,
+		}
+	}
+
+	return PostSearchListResponse{
+		TotalCount: list.TotalCount,
+		TotalPages: list.TotalPages,
+		Page:       list.Page,
+		Size:       list.Size,
+		HasMore:    list.HasMore,
+		Results:    results,
+	}
+}
+
+func FromPostRevisionModel(revision *models.PostRevision) PostRevisionResponse {
+	return PostRevisionResponse{
+		ID:        revision.ID,
+		PostID:    revision.PostID,
+		UserID:    revision.UserID,
+		Username:  revision.Username,
+		Title:     revision.Title,
+		Content:   revision.Content,
+		CreatedAt: FormatTime(revision.CreatedAt),
+	}
+}
+
+func FromPostRevisionListModel(list *models.PostRevisionList) PostRevisionListResponse {
+	revisions := make([]PostRevisionResponse, len(list.Revisions))
+	for i, revision := range list.Revisions {
+		revisions[i] = FromPostRevisionModel(revision)
+	}
+
+	return PostRevisionListResponse{
+		TotalCount: list.TotalCount,
+		TotalPages: list.TotalPages,
+		Page:       list.Page,
+		Size:       list.Size,
+		HasMore:    list.HasMore,
+		Revisions:  revisions,
+	}
+}
+
+func FromPostRevisionDiffModel(d *models.PostRevisionDiff) PostRevisionDiffResponse {
+	return PostRevisionDiffResponse{
+		From: FromPostRevisionModel(d.From),
+		To:   FromPostRevisionModel(d.To),
+		Diff: d.Diff,
+	}
+}
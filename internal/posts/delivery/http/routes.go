@@ -0,0 +1,26 @@
+package http
+
+import (
+	"github.com/ductong169z/shorten-url/internal/middleware"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/posts"
+	"github.com/gin-gonic/gin"
+)
+
+// Map posts routes
+func MapRoutes(group *gin.RouterGroup, h posts.Handlers, mw *middleware.MiddlewareManager) {
+	group.GET("", h.List)
+	group.GET("/search", h.Search)
+	group.GET("/:slug", h.GetBySlug)
+	group.Use(mw.AuthJWTMiddleware())
+	group.POST("", mw.RequirePermission(models.PermPostsCreate), mw.RequireVerifiedEmail(), h.Create)
+	group.PUT("/:id", h.Update)
+	group.DELETE("/:id", h.Delete)
+	group.POST("/:id/publish", mw.RequirePermission(models.PermPostsPublish), h.Publish)
+	group.POST("/:id/unpublish", mw.RequirePermission(models.PermPostsPublish), h.Unpublish)
+	group.POST("/:id/archive", mw.RequirePermission(models.PermPostsPublish), h.Archive)
+	// Revisions are keyed by post ID; the GET routes reuse the :slug wildcard name (see revisionPostID).
+	group.GET("/:slug/revisions", h.ListRevisions)
+	group.GET("/:slug/revisions/diff", h.DiffRevisions)
+	group.POST("/:id/revisions/:revisionId/restore", h.RestoreRevision)
+}
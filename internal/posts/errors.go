@@ -0,0 +1,103 @@
+// Package posts provides core error definitions and utilities for the blog posts domain.
+// It defines domain-specific error variables and error-to-HTTP status mapping for consistent error handling.
+package posts
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ductong169z/shorten-url/pkg/slug"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// postNotFound is returned when a requested post does not exist.
+	postNotFound = "post not found"
+	// slugAlreadyExists is returned when another post already uses the slug.
+	slugAlreadyExists = "slug already exists"
+	// invalidTitle is returned when the post title is empty or too long.
+	invalidTitle = "invalid title"
+	// invalidSlug is returned when the slug is not URL-safe.
+	invalidSlug = "invalid slug"
+	// invalidContent is returned when the post content is empty.
+	invalidContent = "invalid content"
+	// invalidStatusTransition is returned when a post cannot move to the requested status.
+	invalidStatusTransition = "invalid status transition"
+	// invalidSearchQuery is returned when the search text is empty, too long or a malformed boolean expression.
+	invalidSearchQuery = "invalid search query"
+	// invalidSearchMode is returned when the search mode is not natural or boolean.
+	invalidSearchMode = "invalid search mode"
+	// categoryNotFound is returned when the post refers to a category that does not exist.
+	categoryNotFound = "category not found"
+	// revisionNotFound is returned when a revision does not exist for the post.
+	revisionNotFound = "revision not found"
+	// noUniqueSlug is returned when every numbered variant of a generated slug is taken.
+	noUniqueSlug = "no unique slug available, please choose a slug"
+)
+
+var (
+	// ErrPostNotFound indicates that the post was not found.
+	ErrPostNotFound = errors.New(postNotFound)
+	// ErrSlugAlreadyExists indicates that the slug is already taken.
+	ErrSlugAlreadyExists = errors.New(slugAlreadyExists)
+	// ErrInvalidTitle indicates that the title is invalid.
+	ErrInvalidTitle = errors.New(invalidTitle)
+	// ErrInvalidSlug indicates that the slug is invalid.
+	ErrInvalidSlug = errors.New(invalidSlug)
+	// ErrInvalidContent indicates that the content is invalid.
+	ErrInvalidContent = errors.New(invalidContent)
+	// ErrInvalidStatusTransition indicates that the status change is not allowed.
+	ErrInvalidStatusTransition = errors.New(invalidStatusTransition)
+	// ErrInvalidSearchQuery indicates that the search text is invalid.
+	ErrInvalidSearchQuery = errors.New(invalidSearchQuery)
+	// ErrInvalidSearchMode indicates that the search mode is invalid.
+	ErrInvalidSearchMode = errors.New(invalidSearchMode)
+	// ErrCategoryNotFound indicates that the referenced category does not exist.
+	ErrCategoryNotFound = errors.New(categoryNotFound)
+	// ErrRevisionNotFound indicates that the post revision was not found.
+	ErrRevisionNotFound = errors.New(revisionNotFound)
+)
+
+// MapError maps a domain error to an HTTP status code and message.
+// It provides a unified way to translate domain errors to HTTP responses.
+func MapError(err error) (status int, message string) {
+	// Handle JSON binding/unmarshal errors as 400 Bad Request
+	switch err.(type) {
+	case *json.UnmarshalTypeError, *json.SyntaxError:
+		return http.StatusBadRequest, "Invalid request format"
+	case *strconv.NumError:
+		return http.StatusBadRequest, "Invalid parameter format"
+	}
+	if ginErr, ok := err.(*gin.Error); ok && ginErr.Type == gin.ErrorTypeBind {
+		return http.StatusBadRequest, "Invalid request format"
+	}
+
+	switch {
+	case errors.Is(err, ErrPostNotFound):
+		return http.StatusNotFound, postNotFound
+	case errors.Is(err, ErrSlugAlreadyExists):
+		return http.StatusConflict, slugAlreadyExists
+	case errors.Is(err, ErrInvalidTitle):
+		return http.StatusBadRequest, invalidTitle
+	case errors.Is(err, ErrInvalidSlug):
+		return http.StatusBadRequest, invalidSlug
+	case errors.Is(err, ErrInvalidContent):
+		return http.StatusBadRequest, invalidContent
+	case errors.Is(err, ErrInvalidStatusTransition):
+		return http.StatusConflict, invalidStatusTransition
+	case errors.Is(err, ErrInvalidSearchQuery):
+		return http.StatusBadRequest, invalidSearchQuery
+	case errors.Is(err, ErrInvalidSearchMode):
+		return http.StatusBadRequest, invalidSearchMode
+	case errors.Is(err, ErrCategoryNotFound):
+		return http.StatusBadRequest, categoryNotFound
+	case errors.Is(err, ErrRevisionNotFound):
+		return http.StatusNotFound, revisionNotFound
+	case errors.Is(err, slug.ErrNoUniqueSlug):
+		return http.StatusConflict, noUniqueSlug
+	default:
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cache.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/ductong169z/shorten-url/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCache is a mock of Cache interface.
+type MockCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheMockRecorder
+}
+
+// MockCacheMockRecorder is the mock recorder for MockCache.
+type MockCacheMockRecorder struct {
+	mock *MockCache
+}
+
+// NewMockCache creates a new mock instance.
+func NewMockCache(ctrl *gomock.Controller) *MockCache {
+	mock := &MockCache{ctrl: ctrl}
+	mock.recorder = &MockCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCache) EXPECT() *MockCacheMockRecorder {
+	return m.recorder
+}
+
+// DeletePostBySlug mocks base method.
+func (m *MockCache) DeletePostBySlug(ctx context.Context, slug string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePostBySlug", ctx, slug)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePostBySlug indicates an expected call of DeletePostBySlug.
+func (mr *MockCacheMockRecorder) DeletePostBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePostBySlug", reflect.TypeOf((*MockCache)(nil).DeletePostBySlug), ctx, slug)
+}
+
+// GetPostBySlug mocks base method.
+func (m *MockCache) GetPostBySlug(ctx context.Context, slug string) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostBySlug", ctx, slug)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostBySlug indicates an expected call of GetPostBySlug.
+func (mr *MockCacheMockRecorder) GetPostBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostBySlug", reflect.TypeOf((*MockCache)(nil).GetPostBySlug), ctx, slug)
+}
+
+// SetPostBySlug mocks base method.
+func (m *MockCache) SetPostBySlug(ctx context.Context, slug string, post *models.Post, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPostBySlug", ctx, slug, post, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPostBySlug indicates an expected call of SetPostBySlug.
+func (mr *MockCacheMockRecorder) SetPostBySlug(ctx, slug, post, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPostBySlug", reflect.TypeOf((*MockCache)(nil).SetPostBySlug), ctx, slug, post, ttl)
+}
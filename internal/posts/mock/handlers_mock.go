@@ -0,0 +1,179 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: delivery.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHandlers is a mock of Handlers interface.
+type MockHandlers struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlersMockRecorder
+}
+
+// MockHandlersMockRecorder is the mock recorder for MockHandlers.
+type MockHandlersMockRecorder struct {
+	mock *MockHandlers
+}
+
+// NewMockHandlers creates a new mock instance.
+func NewMockHandlers(ctrl *gomock.Controller) *MockHandlers {
+	mock := &MockHandlers{ctrl: ctrl}
+	mock.recorder = &MockHandlersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandlers) EXPECT() *MockHandlersMockRecorder {
+	return m.recorder
+}
+
+// Archive mocks base method.
+func (m *MockHandlers) Archive(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Archive", c)
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockHandlersMockRecorder) Archive(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockHandlers)(nil).Archive), c)
+}
+
+// Create mocks base method.
+func (m *MockHandlers) Create(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Create", c)
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHandlersMockRecorder) Create(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHandlers)(nil).Create), c)
+}
+
+// Delete mocks base method.
+func (m *MockHandlers) Delete(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Delete", c)
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockHandlersMockRecorder) Delete(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHandlers)(nil).Delete), c)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockHandlers) DiffRevisions(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DiffRevisions", c)
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockHandlersMockRecorder) DiffRevisions(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockHandlers)(nil).DiffRevisions), c)
+}
+
+// GetBySlug mocks base method.
+func (m *MockHandlers) GetBySlug(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetBySlug", c)
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockHandlersMockRecorder) GetBySlug(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockHandlers)(nil).GetBySlug), c)
+}
+
+// List mocks base method.
+func (m *MockHandlers) List(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "List", c)
+}
+
+// List indicates an expected call of List.
+func (mr *MockHandlersMockRecorder) List(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHandlers)(nil).List), c)
+}
+
+// ListRevisions mocks base method.
+func (m *MockHandlers) ListRevisions(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListRevisions", c)
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockHandlersMockRecorder) ListRevisions(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockHandlers)(nil).ListRevisions), c)
+}
+
+// Publish mocks base method.
+func (m *MockHandlers) Publish(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", c)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockHandlersMockRecorder) Publish(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockHandlers)(nil).Publish), c)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockHandlers) RestoreRevision(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RestoreRevision", c)
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockHandlersMockRecorder) RestoreRevision(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockHandlers)(nil).RestoreRevision), c)
+}
+
+// Search mocks base method.
+func (m *MockHandlers) Search(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Search", c)
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockHandlersMockRecorder) Search(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockHandlers)(nil).Search), c)
+}
+
+// Unpublish mocks base method.
+func (m *MockHandlers) Unpublish(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unpublish", c)
+}
+
+// Unpublish indicates an expected call of Unpublish.
+func (mr *MockHandlersMockRecorder) Unpublish(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unpublish", reflect.TypeOf((*MockHandlers)(nil).Unpublish), c)
+}
+
+// Update mocks base method.
+func (m *MockHandlers) Update(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Update", c)
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockHandlersMockRecorder) Update(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockHandlers)(nil).Update), c)
+}
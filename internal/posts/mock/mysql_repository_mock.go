@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mysql.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/ductong169z/shorten-url/internal/models"
+	utils "github.com/ductong169z/shorten-url/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateWithRevision mocks base method.
+func (m *MockRepository) CreateWithRevision(ctx context.Context, post *models.Post, revision *models.PostRevision) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithRevision", ctx, post, revision)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithRevision indicates an expected call of CreateWithRevision.
+func (mr *MockRepositoryMockRecorder) CreateWithRevision(ctx, post, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithRevision", reflect.TypeOf((*MockRepository)(nil).CreateWithRevision), ctx, post, revision)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, postID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, postID)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, postID int) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, postID)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, postID)
+}
+
+// GetBySlug mocks base method.
+func (m *MockRepository) GetBySlug(ctx context.Context, slug string) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlug", ctx, slug)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockRepositoryMockRecorder) GetBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockRepository)(nil).GetBySlug), ctx, slug)
+}
+
+// GetBySlugRedirect mocks base method.
+func (m *MockRepository) GetBySlugRedirect(ctx context.Context, oldSlug string) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlugRedirect", ctx, oldSlug)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlugRedirect indicates an expected call of GetBySlugRedirect.
+func (mr *MockRepositoryMockRecorder) GetBySlugRedirect(ctx, oldSlug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlugRedirect", reflect.TypeOf((*MockRepository)(nil).GetBySlugRedirect), ctx, oldSlug)
+}
+
+// GetRevision mocks base method.
+func (m *MockRepository) GetRevision(ctx context.Context, postID, revisionID int) (*models.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, postID, revisionID)
+	ret0, _ := ret[0].(*models.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockRepositoryMockRecorder) GetRevision(ctx, postID, revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockRepository)(nil).GetRevision), ctx, postID, revisionID)
+}
+
+// IsCategoryExist mocks base method.
+func (m *MockRepository) IsCategoryExist(ctx context.Context, categoryID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCategoryExist", ctx, categoryID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsCategoryExist indicates an expected call of IsCategoryExist.
+func (mr *MockRepositoryMockRecorder) IsCategoryExist(ctx, categoryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCategoryExist", reflect.TypeOf((*MockRepository)(nil).IsCategoryExist), ctx, categoryID)
+}
+
+// IsSlugExist mocks base method.
+func (m *MockRepository) IsSlugExist(ctx context.Context, slug string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSlugExist", ctx, slug)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSlugExist indicates an expected call of IsSlugExist.
+func (mr *MockRepositoryMockRecorder) IsSlugExist(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSlugExist", reflect.TypeOf((*MockRepository)(nil).IsSlugExist), ctx, slug)
+}
+
+// List mocks base method.
+func (m *MockRepository) List(ctx context.Context, filter *models.PostFilter, pq *utils.PaginationQuery) (*models.PostList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, pq)
+	ret0, _ := ret[0].(*models.PostList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepositoryMockRecorder) List(ctx, filter, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, filter, pq)
+}
+
+// ListRevisions mocks base method.
+func (m *MockRepository) ListRevisions(ctx context.Context, postID int, pq *utils.PaginationQuery) (*models.PostRevisionList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, postID, pq)
+	ret0, _ := ret[0].(*models.PostRevisionList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockRepositoryMockRecorder) ListRevisions(ctx, postID, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockRepository)(nil).ListRevisions), ctx, postID, pq)
+}
+
+// PublishDue mocks base method.
+func (m *MockRepository) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDue", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishDue indicates an expected call of PublishDue.
+func (mr *MockRepositoryMockRecorder) PublishDue(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDue", reflect.TypeOf((*MockRepository)(nil).PublishDue), ctx, now)
+}
+
+// Search mocks base method.
+func (m *MockRepository) Search(ctx context.Context, query *models.PostSearchQuery, pq *utils.PaginationQuery) (*models.PostSearchList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, pq)
+	ret0, _ := ret[0].(*models.PostSearchList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockRepositoryMockRecorder) Search(ctx, query, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockRepository)(nil).Search), ctx, query, pq)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(ctx context.Context, post *models.Post) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, post)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(ctx, post interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, post)
+}
+
+// UpdateWithRevision mocks base method.
+func (m *MockRepository) UpdateWithRevision(ctx context.Context, post *models.Post, oldSlug string, revision *models.PostRevision) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithRevision", ctx, post, oldSlug, revision)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWithRevision indicates an expected call of UpdateWithRevision.
+func (mr *MockRepositoryMockRecorder) UpdateWithRevision(ctx, post, oldSlug, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithRevision", reflect.TypeOf((*MockRepository)(nil).UpdateWithRevision), ctx, post, oldSlug, revision)
+}
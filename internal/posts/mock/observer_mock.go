@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: observer.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockObserver is a mock of Observer interface.
+type MockObserver struct {
+	ctrl     *gomock.Controller
+	recorder *MockObserverMockRecorder
+}
+
+// MockObserverMockRecorder is the mock recorder for MockObserver.
+type MockObserverMockRecorder struct {
+	mock *MockObserver
+}
+
+// NewMockObserver creates a new mock instance.
+func NewMockObserver(ctrl *gomock.Controller) *MockObserver {
+	mock := &MockObserver{ctrl: ctrl}
+	mock.recorder = &MockObserverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObserver) EXPECT() *MockObserverMockRecorder {
+	return m.recorder
+}
+
+// PostsChanged mocks base method.
+func (m *MockObserver) PostsChanged(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PostsChanged", ctx)
+}
+
+// PostsChanged indicates an expected call of PostsChanged.
+func (mr *MockObserverMockRecorder) PostsChanged(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostsChanged", reflect.TypeOf((*MockObserver)(nil).PostsChanged), ctx)
+}
@@ -0,0 +1,232 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/ductong169z/shorten-url/internal/models"
+	utils "github.com/ductong169z/shorten-url/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Archive mocks base method.
+func (m *MockUseCase) Archive(ctx context.Context, postID int) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", ctx, postID)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockUseCaseMockRecorder) Archive(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockUseCase)(nil).Archive), ctx, postID)
+}
+
+// Create mocks base method.
+func (m *MockUseCase) Create(ctx context.Context, post *models.Post) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, post)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUseCaseMockRecorder) Create(ctx, post interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUseCase)(nil).Create), ctx, post)
+}
+
+// Delete mocks base method.
+func (m *MockUseCase) Delete(ctx context.Context, postID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUseCaseMockRecorder) Delete(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUseCase)(nil).Delete), ctx, postID)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockUseCase) DiffRevisions(ctx context.Context, postID, fromID, toID int) (*models.PostRevisionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRevisions", ctx, postID, fromID, toID)
+	ret0, _ := ret[0].(*models.PostRevisionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockUseCaseMockRecorder) DiffRevisions(ctx, postID, fromID, toID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockUseCase)(nil).DiffRevisions), ctx, postID, fromID, toID)
+}
+
+// GetBySlug mocks base method.
+func (m *MockUseCase) GetBySlug(ctx context.Context, slug string) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlug", ctx, slug)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockUseCaseMockRecorder) GetBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockUseCase)(nil).GetBySlug), ctx, slug)
+}
+
+// List mocks base method.
+func (m *MockUseCase) List(ctx context.Context, pq *utils.PaginationQuery) (*models.PostList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, pq)
+	ret0, _ := ret[0].(*models.PostList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockUseCaseMockRecorder) List(ctx, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUseCase)(nil).List), ctx, pq)
+}
+
+// ListRevisions mocks base method.
+func (m *MockUseCase) ListRevisions(ctx context.Context, postID int, pq *utils.PaginationQuery) (*models.PostRevisionList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, postID, pq)
+	ret0, _ := ret[0].(*models.PostRevisionList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockUseCaseMockRecorder) ListRevisions(ctx, postID, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockUseCase)(nil).ListRevisions), ctx, postID, pq)
+}
+
+// Publish mocks base method.
+func (m *MockUseCase) Publish(ctx context.Context, postID int, publishAt *time.Time) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, postID, publishAt)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockUseCaseMockRecorder) Publish(ctx, postID, publishAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockUseCase)(nil).Publish), ctx, postID, publishAt)
+}
+
+// PublishScheduled mocks base method.
+func (m *MockUseCase) PublishScheduled(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScheduled", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishScheduled indicates an expected call of PublishScheduled.
+func (mr *MockUseCaseMockRecorder) PublishScheduled(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScheduled", reflect.TypeOf((*MockUseCase)(nil).PublishScheduled), ctx)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockUseCase) RestoreRevision(ctx context.Context, postID, revisionID int) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", ctx, postID, revisionID)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockUseCaseMockRecorder) RestoreRevision(ctx, postID, revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockUseCase)(nil).RestoreRevision), ctx, postID, revisionID)
+}
+
+// Search mocks base method.
+func (m *MockUseCase) Search(ctx context.Context, query *models.PostSearchQuery, pq *utils.PaginationQuery) (*models.PostSearchList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, pq)
+	ret0, _ := ret[0].(*models.PostSearchList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockUseCaseMockRecorder) Search(ctx, query, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUseCase)(nil).Search), ctx, query, pq)
+}
+
+// Unpublish mocks base method.
+func (m *MockUseCase) Unpublish(ctx context.Context, postID int) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unpublish", ctx, postID)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unpublish indicates an expected call of Unpublish.
+func (mr *MockUseCaseMockRecorder) Unpublish(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unpublish", reflect.TypeOf((*MockUseCase)(nil).Unpublish), ctx, postID)
+}
+
+// Update mocks base method.
+func (m *MockUseCase) Update(ctx context.Context, post *models.Post) (*models.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, post)
+	ret0, _ := ret[0].(*models.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUseCaseMockRecorder) Update(ctx, post interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUseCase)(nil).Update), ctx, post)
+}
@@ -0,0 +1,27 @@
+//go:generate mockgen -source mysql.go -destination mock/mysql_repository_mock.go -package mock
+package posts
+
+import (
+	"context"
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+type Repository interface {
+	CreateWithRevision(ctx context.Context, post *models.Post, revision *models.PostRevision) (*models.Post, error)
+	Update(ctx context.Context, post *models.Post) (*models.Post, error)
+	UpdateWithRevision(ctx context.Context, post *models.Post, oldSlug string, revision *models.PostRevision) (*models.Post, error)
+	GetByID(ctx context.Context, postID int) (*models.Post, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Post, error)
+	List(ctx context.Context, filter *models.PostFilter, pq *utils.PaginationQuery) (*models.PostList, error)
+	Delete(ctx context.Context, postID int) error
+	IsSlugExist(ctx context.Context, slug string) (bool, error)
+	IsCategoryExist(ctx context.Context, categoryID int) (bool, error)
+	GetBySlugRedirect(ctx context.Context, oldSlug string) (*models.Post, error)
+	PublishDue(ctx context.Context, now time.Time) (int64, error)
+	Search(ctx context.Context, query *models.PostSearchQuery, pq *utils.PaginationQuery) (*models.PostSearchList, error)
+	ListRevisions(ctx context.Context, postID int, pq *utils.PaginationQuery) (*models.PostRevisionList, error)
+	GetRevision(ctx context.Context, postID, revisionID int) (*models.PostRevision, error)
+}
@@ -0,0 +1,12 @@
+//go:generate mockgen -source observer.go -destination mock/observer_mock.go -package mock
+package posts
+
+import (
+	"context"
+)
+
+// Observer is notified after posts are created, changed or removed so derived
+// data such as feeds can be refreshed.
+type Observer interface {
+	PostsChanged(ctx context.Context)
+}
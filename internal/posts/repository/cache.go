@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/posts"
+	"github.com/ductong169z/shorten-url/pkg/cache/redis"
+)
+
+const slugKeyPrefix = "api-post:"
+
+// Posts redis repository
+type redisRepo struct {
+	rdb redis.Client
+}
+
+// Posts redis repository constructor
+func NewRedisRepo(rdb redis.Client) posts.Cache {
+	return &redisRepo{rdb: rdb}
+}
+
+func (r *redisRepo) GetPostBySlug(ctx context.Context, slug string) (*models.Post, error) {
+	data, err := r.rdb.Get(ctx, slugKeyPrefix+slug)
+	if err != nil {
+		return nil, err
+	}
+	var post models.Post
+	err = json.Unmarshal(data, &post)
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+func (r *redisRepo) SetPostBySlug(ctx context.Context, slug string, post *models.Post, ttl time.Duration) error {
+	data, err := json.Marshal(post)
+	if err != nil {
+		return err
+	}
+	return r.rdb.Set(ctx, slugKeyPrefix+slug, string(data), ttl)
+}
+
+func (r *redisRepo) DeletePostBySlug(ctx context.Context, slug string) error {
+	return r.rdb.Del(ctx, slugKeyPrefix+slug)
+}
@@ -0,0 +1,357 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/posts"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const defaultOrderBy = "created_at DESC"
+
+// orderByColumns whitelists the columns a client may sort posts by
+var orderByColumns = map[string]string{
+	"created_at":    "created_at DESC",
+	"-created_at":   "created_at ASC",
+	"published_at":  "published_at DESC",
+	"-published_at": "published_at ASC",
+	"title":         "title ASC",
+	"-title":        "title DESC",
+}
+
+// matchAgainst maps a search mode to its MATCH ... AGAINST expression on idx_posts_fulltext
+var matchAgainst = map[models.PostSearchMode]string{
+	models.PostSearchModeNatural: "MATCH(posts.title, posts.content) AGAINST (? IN NATURAL LANGUAGE MODE)",
+	models.PostSearchModeBoolean: "MATCH(posts.title, posts.content) AGAINST (? IN BOOLEAN MODE)",
+}
+
+// Posts Repository
+type repo struct {
+	db *gorm.DB
+}
+
+// Posts repository constructor
+func NewRepository(db *gorm.DB) posts.Repository {
+	return &repo{db: db}
+}
+
+// CreateWithRevision implements posts.Repository.
+// The post and its first revision are stored in one transaction.
+func (r *repo) CreateWithRevision(ctx context.Context, post *models.Post, revision *models.PostRevision) (*models.Post, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(post).Error; err != nil {
+			return err
+		}
+		revision.PostID = post.ID
+		return tx.Create(revision).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// Update implements posts.Repository.
+func (r *repo) Update(ctx context.Context, post *models.Post) (*models.Post, error) {
+	if err := r.db.WithContext(ctx).Save(post).Error; err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// UpdateWithRevision implements posts.Repository.
+// The post is saved, oldSlug recorded as a redirect when the slug changed and the
+// revision stored in one transaction, so the history never misses an edit.
+func (r *repo) UpdateWithRevision(ctx context.Context, post *models.Post, oldSlug string, revision *models.PostRevision) (*models.Post, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(post).Error; err != nil {
+			return err
+		}
+		if post.Slug != oldSlug {
+			if err := addSlugRedirect(tx, post.ID, oldSlug); err != nil {
+				return err
+			}
+		}
+		return tx.Create(revision).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// GetByID implements posts.Repository.
+func (r *repo) GetByID(ctx context.Context, postID int) (*models.Post, error) {
+	var post models.Post
+	if err := r.db.WithContext(ctx).Where("id = ?", postID).First(&post).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, posts.ErrPostNotFound
+		}
+		return nil, err
+	}
+	return &post, nil
+}
+
+// GetBySlug implements posts.Repository.
+func (r *repo) GetBySlug(ctx context.Context, slug string) (*models.Post, error) {
+	var post models.Post
+	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&post).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, posts.ErrPostNotFound
+		}
+		return nil, err
+	}
+	return &post, nil
+}
+
+// List implements posts.Repository.
+func (r *repo) List(ctx context.Context, filter *models.PostFilter, pq *utils.PaginationQuery) (*models.PostList, error) {
+	query := r.db.WithContext(ctx).Model(&models.Post{})
+	if filter != nil {
+		if filter.Status != "" {
+			query = query.Where("status = ?", filter.Status)
+		}
+		if filter.UserID != 0 {
+			query = query.Where("user_id = ?", filter.UserID)
+		}
+		if filter.CategoryID != 0 {
+			query = query.Where("category_id = ?", filter.CategoryID)
+		}
+		if filter.TagID != 0 {
+			query = query.Where("id IN (SELECT post_id FROM post_tags WHERE tag_id = ?)", filter.TagID)
+		}
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, err
+	}
+
+	list := &models.PostList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Posts:      make([]*models.Post, 0),
+	}
+	if totalCount == 0 {
+		return list, nil
+	}
+
+	if err := query.
+		Order(orderBy(pq.GetOrderBy())).
+		Offset(pq.GetOffset()).
+		Limit(pq.GetLimit()).
+		Find(&list.Posts).Error; err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Delete implements posts.Repository.
+func (r *repo) Delete(ctx context.Context, postID int) error {
+	result := r.db.WithContext(ctx).Where("id = ?", postID).Delete(&models.Post{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return posts.ErrPostNotFound
+	}
+	return nil
+}
+
+// IsSlugExist implements posts.Repository.
+func (r *repo) IsSlugExist(ctx context.Context, slug string) (bool, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.Post{}).
+		Where("slug = ?", slug).
+		Count(&count).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// IsCategoryExist implements posts.Repository.
+func (r *repo) IsCategoryExist(ctx context.Context, categoryID int) (bool, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.Category{}).
+		Where("id = ?", categoryID).
+		Count(&count).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// GetBySlugRedirect implements posts.Repository.
+func (r *repo) GetBySlugRedirect(ctx context.Context, oldSlug string) (*models.Post, error) {
+	var post models.Post
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN post_slug_redirects ON post_slug_redirects.post_id = posts.id").
+		Where("post_slug_redirects.old_slug = ?", oldSlug).
+		First(&post).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, posts.ErrPostNotFound
+		}
+		return nil, err
+	}
+	return &post, nil
+}
+
+// PublishDue implements posts.Repository.
+func (r *repo) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Post{}).
+		Where("status = ? AND published_at IS NOT NULL AND published_at <= ?", models.PostStatusDraft, now).
+		Update("status", models.PostStatusPublished)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// Search implements posts.Repository.
+// Only published posts are searched; results are ordered by relevance.
+func (r *repo) Search(ctx context.Context, sq *models.PostSearchQuery, pq *utils.PaginationQuery) (*models.PostSearchList, error) {
+	match, ok := matchAgainst[sq.Mode]
+	if !ok {
+		match = matchAgainst[models.PostSearchModeNatural]
+	}
+
+	query := r.db.WithContext(ctx).
+		Table("posts").
+		Where("posts.status = ?", models.PostStatusPublished).
+		Where(match, sq.Query)
+	if sq.CategorySlug != "" {
+		query = query.Where("posts.category_id IN (SELECT id FROM categories WHERE slug = ?)", sq.CategorySlug)
+	}
+	if sq.TagSlug != "" {
+		query = query.Where("posts.id IN (SELECT post_tags.post_id FROM post_tags JOIN tags ON tags.id = post_tags.tag_id WHERE tags.slug = ?)", sq.TagSlug)
+	}
+	if sq.AuthorUsername != "" {
+		query = query.Where("posts.user_id IN (SELECT id FROM users WHERE username = ?)", sq.AuthorUsername)
+	}
+	if sq.From != nil {
+		query = query.Where("posts.published_at >= ?", *sq.From)
+	}
+	if sq.To != nil {
+		query = query.Where("posts.published_at <= ?", *sq.To)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, err
+	}
+
+	list := &models.PostSearchList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Results:    make([]*models.PostSearchResult, 0),
+	}
+	if totalCount == 0 {
+		return list, nil
+	}
+
+	if err := query.
+		Select("posts.*, "+match+" AS score", sq.Query).
+		Order("score DESC, posts.published_at DESC").
+		Offset(pq.GetOffset()).
+		Limit(pq.GetLimit()).
+		Find(&list.Results).Error; err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// ListRevisions implements posts.Repository.
+func (r *repo) ListRevisions(ctx context.Context, postID int, pq *utils.PaginationQuery) (*models.PostRevisionList, error) {
+	var totalCount int64
+	if err := r.db.WithContext(ctx).
+		Model(&models.PostRevision{}).
+		Where("post_id = ?", postID).
+		Count(&totalCount).Error; err != nil {
+		return nil, err
+	}
+
+	list := &models.PostRevisionList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Revisions:  make([]*models.PostRevision, 0),
+	}
+	if totalCount == 0 {
+		return list, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Scopes(revisionWithAuthor).
+		Where("post_revisions.post_id = ?", postID).
+		Order("post_revisions.id DESC").
+		Offset(pq.GetOffset()).
+		Limit(pq.GetLimit()).
+		Find(&list.Revisions).Error; err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// GetRevision implements posts.Repository.
+func (r *repo) GetRevision(ctx context.Context, postID, revisionID int) (*models.PostRevision, error) {
+	var revision models.PostRevision
+	if err := r.db.WithContext(ctx).
+		Scopes(revisionWithAuthor).
+		Where("post_revisions.id = ? AND post_revisions.post_id = ?", revisionID, postID).
+		First(&revision).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, posts.ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// addSlugRedirect records oldSlug for postID; an old slug already recorded for
+// another post now points to postID.
+func addSlugRedirect(db *gorm.DB, postID int, oldSlug string) error {
+	redirect := &models.PostSlugRedirect{PostID: postID, OldSlug: oldSlug}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "old_slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"post_id"}),
+	}).Create(redirect).Error
+}
+
+// revisionWithAuthor selects revisions together with the username of their author
+func revisionWithAuthor(db *gorm.DB) *gorm.DB {
+	return db.Select("post_revisions.*, users.username AS username").
+		Joins("LEFT JOIN users ON users.id = post_revisions.user_id")
+}
+
+func orderBy(key string) string {
+	if clause, ok := orderByColumns[key]; ok {
+		return clause
+	}
+	return defaultOrderBy
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// newMockDB opens gorm on a sqlmock connection
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func TestRepo_CreateWithRevision(t *testing.T) {
+	tcs := map[string]struct {
+		revisionErr error
+	}{
+		"post and revision stored": {},
+		"failed revision rolls the post back": {
+			revisionErr: errors.New("disk full"),
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			mock.ExpectBegin()
+			mock.ExpectExec("INSERT INTO `posts`").WillReturnResult(sqlmock.NewResult(5, 1))
+			revision := mock.ExpectExec("INSERT INTO `post_revisions`")
+			if tc.revisionErr != nil {
+				revision.WillReturnError(tc.revisionErr)
+				mock.ExpectRollback()
+			} else {
+				revision.WillReturnResult(sqlmock.NewResult(9, 1))
+				mock.ExpectCommit()
+			}
+
+			rev := &models.PostRevision{UserID: 7, Title: "Hello", Content: "World"}
+			post, err := NewRepository(db).CreateWithRevision(context.Background(), &models.Post{UserID: 7, Title: "Hello", Slug: "hello", Content: "World"}, rev)
+			require.NoError(t, mock.ExpectationsWereMet())
+			if tc.revisionErr != nil {
+				assert.ErrorIs(t, err, tc.revisionErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 5, post.ID)
+			assert.Equal(t, 5, rev.PostID)
+		})
+	}
+}
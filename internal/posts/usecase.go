@@ -0,0 +1,30 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package posts
+
+import (
+	"context"
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+type UseCase interface {
+	Create(ctx context.Context, post *models.Post) (*models.Post, error)
+	Update(ctx context.Context, post *models.Post) (*models.Post, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Post, error)
+	List(ctx context.Context, pq *utils.PaginationQuery) (*models.PostList, error)
+	Search(ctx context.Context, query *models.PostSearchQuery, pq *utils.PaginationQuery) (*models.PostSearchList, error)
+	Delete(ctx context.Context, postID int) error
+
+	// Revision history methods
+	ListRevisions(ctx context.Context, postID int, pq *utils.PaginationQuery) (*models.PostRevisionList, error)
+	DiffRevisions(ctx context.Context, postID, fromID, toID int) (*models.PostRevisionDiff, error)
+	RestoreRevision(ctx context.Context, postID, revisionID int) (*models.Post, error)
+
+	// Lifecycle methods
+	Publish(ctx context.Context, postID int, publishAt *time.Time) (*models.Post, error)
+	Unpublish(ctx context.Context, postID int) (*models.Post, error)
+	Archive(ctx context.Context, postID int) (*models.Post, error)
+	PublishScheduled(ctx context.Context) (int64, error)
+}
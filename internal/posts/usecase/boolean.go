@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"strings"
+	"unicode"
+)
+
+// booleanOperators prefix a word, phrase or group in a boolean mode search
+const booleanOperators = "+-~<>"
+
+// validBooleanQuery reports whether query is an expression MySQL accepts in
+// boolean mode: every operator applies to a word, phrase or group, quotes and
+// parentheses are balanced, * only ends a word and @distance only follows a phrase.
+func validBooleanQuery(query string) bool {
+	p := &booleanParser{in: []rune(query)}
+	return p.expr(0) && p.pos == len(p.in)
+}
+
+// booleanParser walks a boolean mode expression without building a tree
+type booleanParser struct {
+	in  []rune
+	pos int
+}
+
+// expr consumes terms up to the end of input or, inside a group, up to the closing parenthesis
+func (p *booleanParser) expr(depth int) bool {
+	for {
+		p.skipSpace()
+		if p.pos == len(p.in) {
+			return depth == 0
+		}
+		if p.in[p.pos] == ')' {
+			return depth > 0
+		}
+		if !p.term(depth) {
+			return false
+		}
+	}
+}
+
+func (p *booleanParser) term(depth int) bool {
+	if strings.ContainsRune(booleanOperators, p.in[p.pos]) {
+		p.pos++
+	}
+	if p.pos == len(p.in) {
+		return false
+	}
+
+	switch p.in[p.pos] {
+	case '(':
+		p.pos++
+		p.skipSpace()
+		if p.pos < len(p.in) && p.in[p.pos] == ')' {
+			return false
+		}
+		if !p.expr(depth+1) || p.pos == len(p.in) {
+			return false
+		}
+		p.pos++
+		return true
+	case '"':
+		end := indexRune(p.in, p.pos+1, '"')
+		if end < 0 {
+			return false
+		}
+		p.pos = end + 1
+		return p.distance()
+	default:
+		return p.word()
+	}
+}
+
+// word consumes a word with an optional trailing * wildcard. Operator characters
+// may appear inside a word (e-mail, c++) but not as a second prefix.
+func (p *booleanParser) word() bool {
+	if strings.ContainsRune(booleanOperators, p.in[p.pos]) {
+		return false
+	}
+	start := p.pos
+	for p.pos < len(p.in) && !unicode.IsSpace(p.in[p.pos]) && !strings.ContainsRune(`()"@*`, p.in[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		return false
+	}
+	if p.pos < len(p.in) && p.in[p.pos] == '*' {
+		p.pos++
+		return p.pos == len(p.in) || unicode.IsSpace(p.in[p.pos]) || p.in[p.pos] == ')'
+	}
+	return true
+}
+
+// distance consumes an optional @N proximity suffix after a phrase
+func (p *booleanParser) distance() bool {
+	start := p.pos
+	p.skipSpace()
+	if p.pos == len(p.in) || p.in[p.pos] != '@' {
+		p.pos = start
+		return true
+	}
+	p.pos++
+	digits := p.pos
+	for p.pos < len(p.in) && unicode.IsDigit(p.in[p.pos]) {
+		p.pos++
+	}
+	return p.pos > digits
+}
+
+func (p *booleanParser) skipSpace() {
+	for p.pos < len(p.in) && unicode.IsSpace(p.in[p.pos]) {
+		p.pos++
+	}
+}
+
+func indexRune(in []rune, from int, r rune) int {
+	for i := from; i < len(in); i++ {
+		if in[i] == r {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidBooleanQuery(t *testing.T) {
+	tcs := map[string]struct {
+		query string
+		exp   bool
+	}{
+		"plain words":            {query: "golang tips", exp: true},
+		"operators":              {query: `+go -java ~rust <c >zig`, exp: true},
+		"phrase":                 {query: `+"golang tips" go*`, exp: true},
+		"phrase with distance":   {query: `"golang tips" @3`, exp: true},
+		"groups":                 {query: `+go +(<tips >tricks)`, exp: true},
+		"word with inner dashes": {query: "e-mail c++", exp: true},
+		"unbalanced quote":       {query: `"golang tips`, exp: false},
+		"lone plus":              {query: "go +", exp: false},
+		"lone minus":             {query: "go - java", exp: false},
+		"double operator":        {query: "+-go", exp: false},
+		"lone at":                {query: "go @", exp: false},
+		"distance after word":    {query: "go @3", exp: false},
+		"distance without digit": {query: `"go tips" @x`, exp: false},
+		"lone wildcard":          {query: "go *", exp: false},
+		"wildcard inside word":   {query: "go*lang", exp: false},
+		"unclosed group":         {query: "+(go tips", exp: false},
+		"unopened group":         {query: "go tips)", exp: false},
+		"empty group":            {query: "go ()", exp: false},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			assert.Equal(t, tc.exp, validBooleanQuery(tc.query))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/posts"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+)
+
+const DefaultSchedulerInterval = 1 * time.Minute
+
+// Scheduler publishes scheduled posts once their publish time has passed
+type Scheduler struct {
+	usecase  posts.UseCase
+	interval time.Duration
+	logger   logger.Logger
+}
+
+// Scheduler constructor
+func NewScheduler(usecase posts.UseCase, interval time.Duration, logger logger.Logger) *Scheduler {
+	if interval <= 0 {
+		interval = DefaultSchedulerInterval
+	}
+	return &Scheduler{usecase: usecase, interval: interval, logger: logger}
+}
+
+// Run blocks and publishes due posts on every tick until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.logger.Infof(ctx, "Post scheduler started, interval: %s", s.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info(ctx, "Post scheduler stopped")
+			return
+		case <-ticker.C:
+			published, err := s.usecase.PublishScheduled(ctx)
+			if err != nil {
+				s.logger.Errorf(ctx, "Post scheduler failed to publish scheduled posts: %v", err)
+				continue
+			}
+			if published > 0 {
+				s.logger.Infof(ctx, "Post scheduler published %d post(s)", published)
+			}
+		}
+	}
+}
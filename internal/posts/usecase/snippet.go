@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	snippetLength = 200
+	markOpen      = "<mark>"
+	markClose     = "</mark>"
+)
+
+// searchTerms splits a search query into lower-cased words, dropping boolean mode operators
+func searchTerms(query string) []string {
+	fields := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '_'
+	})
+
+	seen := make(map[string]struct{}, len(fields))
+	terms := make([]string, 0, len(fields))
+	for _, f := range fields {
+		term := strings.ToLower(f)
+		if _, ok := seen[term]; ok {
+			continue
+		}
+		seen[term] = struct{}{}
+		terms = append(terms, term)
+	}
+
+	// Longest first so a term never hides a longer one it prefixes
+	sort.SliceStable(terms, func(i, j int) bool {
+		return len([]rune(terms[i])) > len([]rune(terms[j]))
+	})
+	return terms
+}
+
+// highlightSnippet cuts a window of content around the first matching term,
+// escapes it as HTML and wraps every term occurrence in <mark> tags.
+func highlightSnippet(content string, terms []string) string {
+	text := []rune(strings.Join(strings.Fields(content), " "))
+	lower := make([]rune, len(text))
+	for i, r := range text {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	start := 0
+	if first := firstMatch(lower, terms); first > snippetLength/4 {
+		start = first - snippetLength/4
+	}
+	end := start + snippetLength
+	if end > len(text) {
+		end = len(text)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; {
+		if n := matchAt(lower, i, end, terms); n > 0 {
+			b.WriteString(markOpen)
+			b.WriteString(html.EscapeString(string(text[i : i+n])))
+			b.WriteString(markClose)
+			i += n
+			continue
+		}
+		b.WriteString(html.EscapeString(string(text[i])))
+		i++
+	}
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+// firstMatch returns the rune index of the earliest term occurrence, or 0
+func firstMatch(lower []rune, terms []string) int {
+	for i := range lower {
+		if matchAt(lower, i, len(lower), terms) > 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+// matchAt returns the rune length of the term starting at i, or 0 when none does
+func matchAt(lower []rune, i, end int, terms []string) int {
+	for _, term := range terms {
+		t := []rune(term)
+		if i+len(t) > end {
+			continue
+		}
+		if string(lower[i:i+len(t)]) == term {
+			return len(t)
+		}
+	}
+	return 0
+}
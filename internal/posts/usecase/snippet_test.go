@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t, []string{"golang", "tips", "go"}, searchTerms(`+Go -"golang tips" go*`))
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	tcs := map[string]struct {
+		content string
+		terms   []string
+		exp     string
+	}{
+		"case insensitive": {
+			content: "Learning Go is fun",
+			terms:   []string{"go"},
+			exp:     "Learning <mark>Go</mark> is fun",
+		},
+		"html is escaped": {
+			content: "<b>Go</b> & more",
+			terms:   []string{"go"},
+			exp:     "&lt;b&gt;<mark>Go</mark>&lt;/b&gt; &amp; more",
+		},
+		"unicode": {
+			content: "Học lập trình Go",
+			terms:   []string{"học"},
+			exp:     "<mark>Học</mark> lập trình Go",
+		},
+		"no match keeps the start": {
+			content: "nothing here",
+			terms:   []string{"go"},
+			exp:     "nothing here",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.exp, highlightSnippet(tc.content, tc.terms))
+		})
+	}
+}
@@ -0,0 +1,470 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/posts"
+	"github.com/ductong169z/shorten-url/pkg/diff"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/markdown"
+	"github.com/ductong169z/shorten-url/pkg/slug"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+const (
+	DefaultCacheTTL = 1 * time.Hour
+	maxTitleLength  = 255
+	maxSlugLength   = 280
+	minQueryLength  = 2
+	maxQueryLength  = 200
+)
+
+type usecase struct {
+	cfg       *config.Config
+	repo      posts.Repository
+	cache     posts.Cache
+	logger    logger.Logger
+	observers []posts.Observer
+}
+
+// Posts UseCase constructor; observers are notified after published content may have changed
+func NewUseCase(cfg *config.Config, repo posts.Repository, cache posts.Cache, logger logger.Logger, observers ...posts.Observer) posts.UseCase {
+	return &usecase{cfg: cfg, repo: repo, cache: cache, logger: logger, observers: observers}
+}
+
+// Create implements posts.UseCase.
+// Without a slug one is generated from the title. The new content is stored
+// as the first revision.
+func (u *usecase) Create(ctx context.Context, post *models.Post) (*models.Post, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	post.UserID = user.ID
+	post.Status = models.PostStatusDraft
+	post.PublishedAt = nil
+
+	generated := post.Slug == ""
+	if generated {
+		post.Slug = makeSlug(post.Title)
+	}
+
+	if err := validatePost(post); err != nil {
+		return nil, err
+	}
+	if err := u.validateCategory(ctx, post.CategoryID); err != nil {
+		return nil, err
+	}
+
+	if generated {
+		if post.Slug, err = u.uniqueSlug(ctx, post.Slug, ""); err != nil {
+			return nil, err
+		}
+	} else {
+		exist, err := u.repo.IsSlugExist(ctx, post.Slug)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			return nil, posts.ErrSlugAlreadyExists
+		}
+	}
+
+	return u.repo.CreateWithRevision(ctx, post, newRevision(user.ID, post))
+}
+
+// Update implements posts.UseCase.
+// Without a slug a new one is generated when the title changes. The previous
+// slug is kept as a redirect whenever the slug changes, and the new title and
+// content are stored as a revision.
+func (u *usecase) Update(ctx context.Context, post *models.Post) (*models.Post, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := u.repo.GetByID(ctx, post.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateIsOwner(ctx, existing.UserID, models.PermPostsEditAny, u.logger); err != nil {
+		return nil, err
+	}
+
+	generated := post.Slug == ""
+	if generated {
+		post.Slug = existing.Slug
+		if strings.TrimSpace(post.Title) != existing.Title {
+			post.Slug = makeSlug(post.Title)
+		}
+	}
+
+	if err := validatePost(post); err != nil {
+		return nil, err
+	}
+	if err := u.validateCategory(ctx, post.CategoryID); err != nil {
+		return nil, err
+	}
+
+	if generated {
+		if post.Slug, err = u.uniqueSlug(ctx, post.Slug, existing.Slug); err != nil {
+			return nil, err
+		}
+	} else if post.Slug != existing.Slug {
+		exist, err := u.repo.IsSlugExist(ctx, post.Slug)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			return nil, posts.ErrSlugAlreadyExists
+		}
+	}
+
+	oldSlug := existing.Slug
+	existing.Title = post.Title
+	existing.Slug = post.Slug
+	existing.Content = post.Content
+	existing.CategoryID = post.CategoryID
+
+	updated, err := u.repo.UpdateWithRevision(ctx, existing, oldSlug, newRevision(user.ID, existing))
+	if err != nil {
+		return nil, err
+	}
+
+	u.invalidateCache(ctx, oldSlug)
+	u.notify(ctx)
+
+	return updated, nil
+}
+
+// GetBySlug implements posts.UseCase.
+// A slug the post used before resolves to the post under its current slug.
+func (u *usecase) GetBySlug(ctx context.Context, slug string) (*models.Post, error) {
+	post, err := u.cache.GetPostBySlug(ctx, slug)
+	if err != nil {
+		u.logger.Debugf(ctx, "Cache miss for post %s: %v", slug, err)
+	}
+	if post != nil {
+		return post, nil
+	}
+
+	post, err = u.repo.GetBySlug(ctx, slug)
+	if errors.Is(err, posts.ErrPostNotFound) {
+		post, err = u.repo.GetBySlugRedirect(ctx, slug)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if post.Status != models.PostStatusPublished {
+		return nil, posts.ErrPostNotFound
+	}
+	if post.Slug != slug {
+		return post, nil
+	}
+
+	if err := u.cache.SetPostBySlug(ctx, slug, post, DefaultCacheTTL); err != nil {
+		u.logger.Errorf(ctx, "Failed to set post %s in cache: %v", slug, err)
+	}
+
+	return post, nil
+}
+
+// List implements posts.UseCase.
+func (u *usecase) List(ctx context.Context, pq *utils.PaginationQuery) (*models.PostList, error) {
+	filter := &models.PostFilter{Status: models.PostStatusPublished}
+	return u.repo.List(ctx, filter, pq)
+}
+
+// Search implements posts.UseCase.
+func (u *usecase) Search(ctx context.Context, sq *models.PostSearchQuery, pq *utils.PaginationQuery) (*models.PostSearchList, error) {
+	sq.Query = strings.TrimSpace(sq.Query)
+	if n := utf8.RuneCountInString(sq.Query); n < minQueryLength || n > maxQueryLength {
+		return nil, posts.ErrInvalidSearchQuery
+	}
+	if sq.Mode == "" {
+		sq.Mode = models.PostSearchModeNatural
+	}
+	if !sq.Mode.IsValid() {
+		return nil, posts.ErrInvalidSearchMode
+	}
+	if sq.Mode == models.PostSearchModeBoolean && !validBooleanQuery(sq.Query) {
+		return nil, posts.ErrInvalidSearchQuery
+	}
+
+	list, err := u.repo.Search(ctx, sq, pq)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := searchTerms(sq.Query)
+	for _, result := range list.Results {
+		result.Snippet = highlightSnippet(markdown.PlainText(result.Content), terms)
+	}
+	return list, nil
+}
+
+// Delete implements posts.UseCase.
+func (u *usecase) Delete(ctx context.Context, postID int) error {
+	post, err := u.repo.GetByID(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if err := utils.ValidateIsOwner(ctx, post.UserID, models.PermPostsEditAny, u.logger); err != nil {
+		return err
+	}
+
+	if err := u.repo.Delete(ctx, postID); err != nil {
+		return err
+	}
+
+	u.invalidateCache(ctx, post.Slug)
+	u.notify(ctx)
+
+	return nil
+}
+
+// ListRevisions implements posts.UseCase.
+func (u *usecase) ListRevisions(ctx context.Context, postID int, pq *utils.PaginationQuery) (*models.PostRevisionList, error) {
+	post, err := u.repo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateIsOwner(ctx, post.UserID, models.PermPostsEditAny, u.logger); err != nil {
+		return nil, err
+	}
+
+	return u.repo.ListRevisions(ctx, post.ID, pq)
+}
+
+// DiffRevisions implements posts.UseCase.
+// The diff covers the title line followed by the content of each revision.
+func (u *usecase) DiffRevisions(ctx context.Context, postID, fromID, toID int) (*models.PostRevisionDiff, error) {
+	post, err := u.repo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateIsOwner(ctx, post.UserID, models.PermPostsEditAny, u.logger); err != nil {
+		return nil, err
+	}
+
+	from, err := u.repo.GetRevision(ctx, post.ID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := u.repo.GetRevision(ctx, post.ID, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PostRevisionDiff{
+		From: from,
+		To:   to,
+		Diff: diff.Unified(revisionText(from), revisionText(to), revisionLabel(from), revisionLabel(to)),
+	}, nil
+}
+
+// RestoreRevision implements posts.UseCase.
+// The post gets the title and content of the revision back and the result is
+// stored as a new revision; the slug is left unchanged.
+func (u *usecase) RestoreRevision(ctx context.Context, postID, revisionID int) (*models.Post, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := u.repo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateIsOwner(ctx, post.UserID, models.PermPostsEditAny, u.logger); err != nil {
+		return nil, err
+	}
+
+	revision, err := u.repo.GetRevision(ctx, postID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	post.Title = revision.Title
+	post.Content = revision.Content
+	updated, err := u.repo.UpdateWithRevision(ctx, post, post.Slug, newRevision(user.ID, post))
+	if err != nil {
+		return nil, err
+	}
+
+	u.invalidateCache(ctx, updated.Slug)
+	u.notify(ctx)
+
+	return updated, nil
+}
+
+// Publish implements posts.UseCase.
+// A publish time in the future schedules the post: it stays a draft until the
+// scheduler flips it to published once that time has passed.
+func (u *usecase) Publish(ctx context.Context, postID int, publishAt *time.Time) (*models.Post, error) {
+	post, err := u.repo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateIsOwner(ctx, post.UserID, models.PermPostsEditAny, u.logger); err != nil {
+		return nil, err
+	}
+	if !post.Status.CanTransitionTo(models.PostStatusPublished) {
+		return nil, posts.ErrInvalidStatusTransition
+	}
+
+	now := time.Now()
+	if publishAt == nil {
+		publishAt = &now
+	}
+	post.PublishedAt = publishAt
+	if !publishAt.After(now) {
+		post.Status = models.PostStatusPublished
+	}
+
+	return u.updateStatus(ctx, post)
+}
+
+// Unpublish implements posts.UseCase.
+// It moves a published or archived post back to draft and also cancels a pending schedule.
+func (u *usecase) Unpublish(ctx context.Context, postID int) (*models.Post, error) {
+	post, err := u.repo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateIsOwner(ctx, post.UserID, models.PermPostsEditAny, u.logger); err != nil {
+		return nil, err
+	}
+	if !post.IsScheduled() && !post.Status.CanTransitionTo(models.PostStatusDraft) {
+		return nil, posts.ErrInvalidStatusTransition
+	}
+
+	post.Status = models.PostStatusDraft
+	post.PublishedAt = nil
+
+	return u.updateStatus(ctx, post)
+}
+
+// Archive implements posts.UseCase.
+func (u *usecase) Archive(ctx context.Context, postID int) (*models.Post, error) {
+	post, err := u.repo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateIsOwner(ctx, post.UserID, models.PermPostsEditAny, u.logger); err != nil {
+		return nil, err
+	}
+	if !post.Status.CanTransitionTo(models.PostStatusArchived) {
+		return nil, posts.ErrInvalidStatusTransition
+	}
+
+	post.Status = models.PostStatusArchived
+
+	return u.updateStatus(ctx, post)
+}
+
+// PublishScheduled implements posts.UseCase.
+func (u *usecase) PublishScheduled(ctx context.Context) (int64, error) {
+	published, err := u.repo.PublishDue(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if published > 0 {
+		u.notify(ctx)
+	}
+	return published, nil
+}
+
+func (u *usecase) updateStatus(ctx context.Context, post *models.Post) (*models.Post, error) {
+	updated, err := u.repo.Update(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+
+	u.invalidateCache(ctx, post.Slug)
+	u.notify(ctx)
+
+	return updated, nil
+}
+
+// uniqueSlug suffixes a generated slug until it is free; current is the slug
+// the post already owns and is not treated as taken.
+func (u *usecase) uniqueSlug(ctx context.Context, base, current string) (string, error) {
+	return slug.Unique(base, maxSlugLength, func(s string) (bool, error) {
+		if s == current {
+			return false, nil
+		}
+		return u.repo.IsSlugExist(ctx, s)
+	})
+}
+
+// validateCategory checks that the category a post refers to exists; a post may have none
+func (u *usecase) validateCategory(ctx context.Context, categoryID *int) error {
+	if categoryID == nil {
+		return nil
+	}
+	exist, err := u.repo.IsCategoryExist(ctx, *categoryID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return posts.ErrCategoryNotFound
+	}
+	return nil
+}
+
+// newRevision captures the current title and content of post as edited by userID
+func newRevision(userID int, post *models.Post) *models.PostRevision {
+	return &models.PostRevision{
+		PostID:  post.ID,
+		UserID:  userID,
+		Title:   post.Title,
+		Content: post.Content,
+	}
+}
+
+// makeSlug generates a slug from title, time based for titles without any Latin letter or digit
+func makeSlug(title string) string {
+	return slug.Truncate(slug.MakeOr(title, "post"), maxSlugLength)
+}
+
+func (u *usecase) notify(ctx context.Context) {
+	for _, o := range u.observers {
+		o.PostsChanged(ctx)
+	}
+}
+
+func (u *usecase) invalidateCache(ctx context.Context, slug string) {
+	if err := u.cache.DeletePostBySlug(ctx, slug); err != nil {
+		u.logger.Errorf(ctx, "Failed to delete post %s from cache: %v", slug, err)
+	}
+}
+
+func validatePost(post *models.Post) error {
+	post.Title = strings.TrimSpace(post.Title)
+	if post.Title == "" || utf8.RuneCountInString(post.Title) > maxTitleLength {
+		return posts.ErrInvalidTitle
+	}
+	if !slug.IsValid(post.Slug, maxSlugLength) {
+		return posts.ErrInvalidSlug
+	}
+	if strings.TrimSpace(post.Content) == "" {
+		return posts.ErrInvalidContent
+	}
+	return nil
+}
+
+func revisionText(r *models.PostRevision) string {
+	return r.Title + "\n\n" + r.Content
+}
+
+func revisionLabel(r *models.PostRevision) string {
+	return "revision " + strconv.Itoa(r.ID) + " (" + r.CreatedAt.UTC().Format(time.RFC3339) + ")"
+}
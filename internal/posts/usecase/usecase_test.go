@@ -0,0 +1,483 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/posts"
+	mock "github.com/ductong169z/shorten-url/internal/posts/mock"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
+)
+
+func newTestUseCase(t *testing.T) (*gomock.Controller, *mock.MockRepository, *mock.MockCache, posts.UseCase) {
+	cfg := &config.Config{}
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	ctrl := gomock.NewController(t)
+
+	repo := mock.NewMockRepository(ctrl)
+	cache := mock.NewMockCache(ctrl)
+	return ctrl, repo, cache, NewUseCase(cfg, repo, cache, apiLogger)
+}
+
+func withUser(user *models.User) context.Context {
+	return context.WithValue(context.Background(), utils.UserCtxKey{}, user)
+}
+
+func TestUseCase_Create(t *testing.T) {
+	author := &models.User{ID: 7, Role: models.RoleUser}
+	categoryID, exist, missing := 3, true, false
+
+	tcs := map[string]struct {
+		ctx           context.Context
+		input         models.Post
+		categoryExist *bool
+		expSlugCall   bool
+		slugExist     bool
+		expCreate     bool
+		expErr        error
+	}{
+		"success": {
+			ctx:         withUser(author),
+			input:       models.Post{Title: "Hello", Slug: "hello", Content: "body"},
+			expSlugCall: true,
+			expCreate:   true,
+		},
+		"unauthenticated": {
+			ctx:    context.Background(),
+			input:  models.Post{Title: "Hello", Slug: "hello", Content: "body"},
+			expErr: pkgErrors.Unauthorized,
+		},
+		"empty title": {
+			ctx:    withUser(author),
+			input:  models.Post{Title: "  ", Slug: "hello", Content: "body"},
+			expErr: posts.ErrInvalidTitle,
+		},
+		"invalid slug": {
+			ctx:    withUser(author),
+			input:  models.Post{Title: "Hello", Slug: "Hello World", Content: "body"},
+			expErr: posts.ErrInvalidSlug,
+		},
+		"status is always draft": {
+			ctx:         withUser(author),
+			input:       models.Post{Title: "Hello", Slug: "hello", Content: "body", Status: models.PostStatusPublished},
+			expSlugCall: true,
+			expCreate:   true,
+		},
+		"duplicate slug": {
+			ctx:         withUser(author),
+			input:       models.Post{Title: "Hello", Slug: "hello", Content: "body"},
+			expSlugCall: true,
+			slugExist:   true,
+			expErr:      posts.ErrSlugAlreadyExists,
+		},
+		"known category": {
+			ctx:           withUser(author),
+			input:         models.Post{Title: "Hello", Slug: "hello", Content: "body", CategoryID: &categoryID},
+			categoryExist: &exist,
+			expSlugCall:   true,
+			expCreate:     true,
+		},
+		"unknown category": {
+			ctx:           withUser(author),
+			input:         models.Post{Title: "Hello", Slug: "hello", Content: "body", CategoryID: &categoryID},
+			categoryExist: &missing,
+			expErr:        posts.ErrCategoryNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			ctrl, repo, _, uc := newTestUseCase(t)
+			defer ctrl.Finish()
+
+			if tc.categoryExist != nil {
+				repo.EXPECT().IsCategoryExist(gomock.Any(), categoryID).Return(*tc.categoryExist, nil)
+			}
+			if tc.expSlugCall {
+				repo.EXPECT().IsSlugExist(gomock.Any(), tc.input.Slug).Return(tc.slugExist, nil)
+			}
+			if tc.expCreate {
+				repo.EXPECT().CreateWithRevision(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, p *models.Post, rev *models.PostRevision) (*models.Post, error) {
+						assert.Equal(t, author.ID, rev.UserID)
+						assert.Equal(t, p.Title, rev.Title)
+						assert.Equal(t, p.Content, rev.Content)
+						return p, nil
+					},
+				)
+			}
+
+			input := tc.input
+			post, err := uc.Create(tc.ctx, &input)
+
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, author.ID, post.UserID)
+			assert.Equal(t, models.PostStatusDraft, post.Status)
+		})
+	}
+}
+
+func TestUseCase_SearchMalformedBooleanQuery(t *testing.T) {
+	ctrl, _, _, uc := newTestUseCase(t)
+	defer ctrl.Finish()
+
+	_, err := uc.Search(context.Background(), &models.PostSearchQuery{Query: `"golang tips`, Mode: models.PostSearchModeBoolean}, &utils.PaginationQuery{})
+	assert.ErrorIs(t, err, posts.ErrInvalidSearchQuery)
+}
+
+func TestUseCase_Delete(t *testing.T) {
+	post := &models.Post{ID: 1, UserID: 7, Slug: "hello"}
+
+	tcs := map[string]struct {
+		ctx       context.Context
+		expDelete bool
+		expErr    error
+	}{
+		"author": {
+			ctx:       withUser(&models.User{ID: 7, Role: models.RoleUser}),
+			expDelete: true,
+		},
+		"admin": {
+			ctx:       withUser(&models.User{ID: 1, Role: models.RoleAdmin}),
+			expDelete: true,
+		},
+		"editor": {
+			ctx:       withUser(&models.User{ID: 2, Role: models.RoleEditor}),
+			expDelete: true,
+		},
+		"other user": {
+			ctx:    withUser(&models.User{ID: 8, Role: models.RoleUser}),
+			expErr: pkgErrors.Forbidden,
+		},
+		"other author": {
+			ctx:    withUser(&models.User{ID: 9, Role: models.RoleAuthor}),
+			expErr: pkgErrors.Forbidden,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			ctrl, repo, cache, uc := newTestUseCase(t)
+			defer ctrl.Finish()
+
+			repo.EXPECT().GetByID(gomock.Any(), post.ID).Return(post, nil)
+			if tc.expDelete {
+				repo.EXPECT().Delete(gomock.Any(), post.ID).Return(nil)
+				cache.EXPECT().DeletePostBySlug(gomock.Any(), post.Slug).Return(nil)
+			}
+
+			err := uc.Delete(tc.ctx, post.ID)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUseCase_Publish(t *testing.T) {
+	author := withUser(&models.User{ID: 7, Role: models.RoleUser})
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tcs := map[string]struct {
+		status    models.PostStatus
+		publishAt *time.Time
+		expStatus models.PostStatus
+		expErr    error
+	}{
+		"publish now": {
+			status:    models.PostStatusDraft,
+			expStatus: models.PostStatusPublished,
+		},
+		"backdated": {
+			status:    models.PostStatusDraft,
+			publishAt: &past,
+			expStatus: models.PostStatusPublished,
+		},
+		"scheduled": {
+			status:    models.PostStatusDraft,
+			publishAt: &future,
+			expStatus: models.PostStatusDraft,
+		},
+		"already published": {
+			status: models.PostStatusPublished,
+			expErr: posts.ErrInvalidStatusTransition,
+		},
+		"archived": {
+			status: models.PostStatusArchived,
+			expErr: posts.ErrInvalidStatusTransition,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			ctrl, repo, cache, uc := newTestUseCase(t)
+			defer ctrl.Finish()
+
+			post := &models.Post{ID: 1, UserID: 7, Slug: "hello", Status: tc.status}
+			repo.EXPECT().GetByID(gomock.Any(), post.ID).Return(post, nil)
+			if tc.expErr == nil {
+				repo.EXPECT().Update(gomock.Any(), post).Return(post, nil)
+				cache.EXPECT().DeletePostBySlug(gomock.Any(), post.Slug).Return(nil)
+			}
+
+			updated, err := uc.Publish(author, post.ID, tc.publishAt)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expStatus, updated.Status)
+			assert.NotNil(t, updated.PublishedAt)
+			assert.Equal(t, tc.expStatus == models.PostStatusDraft, updated.IsScheduled())
+		})
+	}
+}
+
+func TestUseCase_Unpublish(t *testing.T) {
+	author := withUser(&models.User{ID: 7, Role: models.RoleUser})
+	publishedAt := time.Now().Add(time.Hour)
+
+	tcs := map[string]struct {
+		post   models.Post
+		expErr error
+	}{
+		"published": {
+			post: models.Post{Status: models.PostStatusPublished, PublishedAt: &publishedAt},
+		},
+		"archived": {
+			post: models.Post{Status: models.PostStatusArchived, PublishedAt: &publishedAt},
+		},
+		"cancel schedule": {
+			post: models.Post{Status: models.PostStatusDraft, PublishedAt: &publishedAt},
+		},
+		"plain draft": {
+			post:   models.Post{Status: models.PostStatusDraft},
+			expErr: posts.ErrInvalidStatusTransition,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			ctrl, repo, cache, uc := newTestUseCase(t)
+			defer ctrl.Finish()
+
+			post := tc.post
+			post.ID, post.UserID, post.Slug = 1, 7, "hello"
+			repo.EXPECT().GetByID(gomock.Any(), post.ID).Return(&post, nil)
+			if tc.expErr == nil {
+				repo.EXPECT().Update(gomock.Any(), &post).Return(&post, nil)
+				cache.EXPECT().DeletePostBySlug(gomock.Any(), post.Slug).Return(nil)
+			}
+
+			updated, err := uc.Unpublish(author, post.ID)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, models.PostStatusDraft, updated.Status)
+			assert.Nil(t, updated.PublishedAt)
+		})
+	}
+}
+
+func TestUseCase_UpdateSlug(t *testing.T) {
+	author := &models.User{ID: 7, Role: models.RoleUser}
+
+	tcs := map[string]struct {
+		input       models.Post
+		taken       []string
+		expSlug     string
+		expRedirect bool
+	}{
+		"title change generates a new slug": {
+			input:       models.Post{ID: 1, Title: "Xin chào Việt Nam", Content: "body"},
+			expSlug:     "xin-chao-viet-nam",
+			expRedirect: true,
+		},
+		"generated slug collision gets a suffix": {
+			input:       models.Post{ID: 1, Title: "Second", Content: "body"},
+			taken:       []string{"second"},
+			expSlug:     "second-2",
+			expRedirect: true,
+		},
+		"same title keeps the slug": {
+			input:   models.Post{ID: 1, Title: "Hello", Content: "new body"},
+			expSlug: "hello",
+		},
+		"explicit slug": {
+			input:       models.Post{ID: 1, Title: "Hello", Slug: "greeting", Content: "body"},
+			expSlug:     "greeting",
+			expRedirect: true,
+		},
+		"title without latin letters gets a time based slug": {
+			input:       models.Post{ID: 1, Title: "你好 🎉", Content: "body"},
+			expSlug:     "post-",
+			expRedirect: true,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			ctrl, repo, cache, uc := newTestUseCase(t)
+			defer ctrl.Finish()
+
+			existing := &models.Post{ID: 1, UserID: author.ID, Title: "Hello", Slug: "hello", Content: "body"}
+			repo.EXPECT().GetByID(gomock.Any(), existing.ID).Return(existing, nil)
+			repo.EXPECT().IsSlugExist(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, s string) (bool, error) {
+					for _, taken := range tc.taken {
+						if s == taken {
+							return true, nil
+						}
+					}
+					return false, nil
+				},
+			).AnyTimes()
+			repo.EXPECT().UpdateWithRevision(gomock.Any(), gomock.Any(), "hello", gomock.Any()).DoAndReturn(
+				func(_ context.Context, p *models.Post, _ string, r *models.PostRevision) (*models.Post, error) {
+					assert.Equal(t, &models.PostRevision{PostID: 1, UserID: author.ID, Title: p.Title, Content: p.Content}, r)
+					return p, nil
+				},
+			)
+			cache.EXPECT().DeletePostBySlug(gomock.Any(), "hello").Return(nil)
+
+			input := tc.input
+			post, err := uc.Update(withUser(author), &input)
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(post.Slug, tc.expSlug), post.Slug)
+			assert.Equal(t, tc.expRedirect, post.Slug != "hello")
+		})
+	}
+}
+
+func TestUseCase_UpdateUnknownCategory(t *testing.T) {
+	ctrl, repo, _, uc := newTestUseCase(t)
+	defer ctrl.Finish()
+
+	author := &models.User{ID: 7, Role: models.RoleUser}
+	categoryID := 3
+	existing := &models.Post{ID: 1, UserID: author.ID, Title: "Hello", Slug: "hello", Content: "body"}
+	repo.EXPECT().GetByID(gomock.Any(), existing.ID).Return(existing, nil)
+	repo.EXPECT().IsCategoryExist(gomock.Any(), categoryID).Return(false, nil)
+
+	_, err := uc.Update(withUser(author), &models.Post{ID: 1, Title: "Hello", Content: "body", CategoryID: &categoryID})
+	assert.ErrorIs(t, err, posts.ErrCategoryNotFound)
+}
+
+func TestUseCase_UpdateUnauthenticated(t *testing.T) {
+	ctrl, _, _, uc := newTestUseCase(t)
+	defer ctrl.Finish()
+
+	_, err := uc.Update(context.Background(), &models.Post{ID: 1, Title: "Hello", Content: "body"})
+	assert.ErrorIs(t, err, pkgErrors.Unauthorized)
+}
+
+func TestUseCase_GetBySlugRedirect(t *testing.T) {
+	ctrl, repo, cache, uc := newTestUseCase(t)
+	defer ctrl.Finish()
+
+	post := &models.Post{ID: 1, Slug: "new-slug", Status: models.PostStatusPublished}
+	cache.EXPECT().GetPostBySlug(gomock.Any(), "old-slug").Return(nil, nil)
+	repo.EXPECT().GetBySlug(gomock.Any(), "old-slug").Return(nil, posts.ErrPostNotFound)
+	repo.EXPECT().GetBySlugRedirect(gomock.Any(), "old-slug").Return(post, nil)
+
+	found, err := uc.GetBySlug(context.Background(), "old-slug")
+	assert.NoError(t, err)
+	assert.Equal(t, "new-slug", found.Slug)
+}
+
+func TestUseCase_RestoreRevision(t *testing.T) {
+	author := &models.User{ID: 7, Role: models.RoleUser}
+	other := &models.User{ID: 8, Role: models.RoleUser}
+	revision := &models.PostRevision{ID: 3, PostID: 1, UserID: author.ID, Title: "Old title", Content: "old body"}
+
+	tcs := map[string]struct {
+		user        *models.User
+		revisionErr error
+		expErr      error
+	}{
+		"success": {
+			user: author,
+		},
+		"not the author": {
+			user:   other,
+			expErr: pkgErrors.Forbidden,
+		},
+		"revision of another post": {
+			user:        author,
+			revisionErr: posts.ErrRevisionNotFound,
+			expErr:      posts.ErrRevisionNotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			ctrl, repo, cache, uc := newTestUseCase(t)
+			defer ctrl.Finish()
+
+			post := &models.Post{ID: 1, UserID: author.ID, Title: "New title", Slug: "hello", Content: "new body"}
+			repo.EXPECT().GetByID(gomock.Any(), post.ID).Return(post, nil)
+			if tc.user == author {
+				repo.EXPECT().GetRevision(gomock.Any(), post.ID, revision.ID).Return(revision, tc.revisionErr)
+			}
+			if tc.expErr == nil {
+				repo.EXPECT().UpdateWithRevision(gomock.Any(), post, "hello", &models.PostRevision{
+					PostID:  post.ID,
+					UserID:  author.ID,
+					Title:   revision.Title,
+					Content: revision.Content,
+				}).Return(post, nil)
+				cache.EXPECT().DeletePostBySlug(gomock.Any(), "hello").Return(nil)
+			}
+
+			restored, err := uc.RestoreRevision(withUser(tc.user), post.ID, revision.ID)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "Old title", restored.Title)
+			assert.Equal(t, "old body", restored.Content)
+			assert.Equal(t, "hello", restored.Slug)
+		})
+	}
+}
+
+func TestUseCase_DiffRevisions(t *testing.T) {
+	ctrl, repo, _, uc := newTestUseCase(t)
+	defer ctrl.Finish()
+
+	author := &models.User{ID: 7, Role: models.RoleUser}
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	post := &models.Post{ID: 1, UserID: author.ID, Slug: "hello", Status: models.PostStatusDraft}
+	from := &models.PostRevision{ID: 2, PostID: 1, Title: "Hello", Content: "first\nsecond\n", CreatedAt: createdAt}
+	to := &models.PostRevision{ID: 5, PostID: 1, Title: "Hello", Content: "first\nchanged\n", CreatedAt: createdAt.Add(time.Hour)}
+
+	repo.EXPECT().GetByID(gomock.Any(), post.ID).Return(post, nil)
+	repo.EXPECT().GetRevision(gomock.Any(), post.ID, from.ID).Return(from, nil)
+	repo.EXPECT().GetRevision(gomock.Any(), post.ID, to.ID).Return(to, nil)
+
+	d, err := uc.DiffRevisions(withUser(author), post.ID, from.ID, to.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "--- revision 2 (2024-05-01T10:00:00Z)\n"+
+		"+++ revision 5 (2024-05-01T11:00:00Z)\n"+
+		"@@ -1,4 +1,4 @@\n Hello\n \n first\n-second\n+changed\n", d.Diff)
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"time"
 
 	authHttp "github.com/ductong169z/shorten-url/internal/auth/delivery/http"
 	authGraphQL "github.com/ductong169z/shorten-url/internal/auth/delivery/graphql"
@@ -9,12 +10,45 @@ import (
 	authUseCase "github.com/ductong169z/shorten-url/internal/auth/usecase"
 	apiMiddlewares "github.com/ductong169z/shorten-url/internal/middleware"
 
+	postHttp "github.com/ductong169z/shorten-url/internal/posts/delivery/http"
+	postRepository "github.com/ductong169z/shorten-url/internal/posts/repository"
+	postUseCase "github.com/ductong169z/shorten-url/internal/posts/usecase"
+
+	categoryHttp "github.com/ductong169z/shorten-url/internal/category/delivery/http"
+	categoryRepository "github.com/ductong169z/shorten-url/internal/category/repository"
+	categoryUseCase "github.com/ductong169z/shorten-url/internal/category/usecase"
+
+	tagHttp "github.com/ductong169z/shorten-url/internal/tag/delivery/http"
+	tagRepository "github.com/ductong169z/shorten-url/internal/tag/repository"
+	tagUseCase "github.com/ductong169z/shorten-url/internal/tag/usecase"
+
+	commentHttp "github.com/ductong169z/shorten-url/internal/comment/delivery/http"
+	commentRepository "github.com/ductong169z/shorten-url/internal/comment/repository"
+	commentUseCase "github.com/ductong169z/shorten-url/internal/comment/usecase"
+
+	feedHttp "github.com/ductong169z/shorten-url/internal/feed/delivery/http"
+	feedRepository "github.com/ductong169z/shorten-url/internal/feed/repository"
+	feedUseCase "github.com/ductong169z/shorten-url/internal/feed/usecase"
+
+	sitemapHttp "github.com/ductong169z/shorten-url/internal/sitemap/delivery/http"
+	sitemapRepository "github.com/ductong169z/shorten-url/internal/sitemap/repository"
+	sitemapUseCase "github.com/ductong169z/shorten-url/internal/sitemap/usecase"
+
+	mediaHttp "github.com/ductong169z/shorten-url/internal/media/delivery/http"
+	mediaRepository "github.com/ductong169z/shorten-url/internal/media/repository"
+	mediaUseCase "github.com/ductong169z/shorten-url/internal/media/usecase"
+
+	authorHttp "github.com/ductong169z/shorten-url/internal/author/delivery/http"
+	authorUseCase "github.com/ductong169z/shorten-url/internal/author/usecase"
+
 	shortHttp "github.com/ductong169z/shorten-url/internal/shortener/delivery/http"
 	shortGraphQL "github.com/ductong169z/shorten-url/internal/shortener/delivery/graphql"
 	shortRepository "github.com/ductong169z/shorten-url/internal/shortener/repository"
 	shortUseCase "github.com/ductong169z/shorten-url/internal/shortener/usecase"
 
+	"github.com/ductong169z/shorten-url/pkg/jwtkeys"
 	"github.com/ductong169z/shorten-url/pkg/metric"
+	"github.com/ductong169z/shorten-url/pkg/storage"
 	"github.com/gin-contrib/requestid"
 
 	// Swagger UI imports
@@ -25,6 +59,12 @@ import (
 // Map Server Handlers
 func (s *Server) MapHandlers() error {
 	ctx := context.Background()
+
+	// Client IPs feed the login throttle and sessions, so X-Forwarded-For is only read from known proxies
+	if err := s.gin.SetTrustedProxies(s.cfg.Server.TrustedProxies); err != nil {
+		return err
+	}
+
 	metrics, err := metric.CreateMetrics(s.cfg.Metrics.URL, s.cfg.Metrics.ServiceName)
 	if err != nil {
 		s.logger.Errorf(ctx, "CreateMetrics Error: %s", err)
@@ -45,16 +85,63 @@ func (s *Server) MapHandlers() error {
 	shortRepo := shortRepository.NewRepository(s.db)
 	shortRedisRepo := shortRepository.NewRedisRepo(s.redis)
 
+	postRepo := postRepository.NewRepository(s.db)
+	postRedisRepo := postRepository.NewRedisRepo(s.redis)
+
+	categoryRepo := categoryRepository.NewRepository(s.db)
+
+	tagRepo := tagRepository.NewRepository(s.db)
+
+	commentRepo := commentRepository.NewRepository(s.db)
+
+	feedRedisRepo := feedRepository.NewRedisRepo(s.redis)
+
+	sitemapRepo := sitemapRepository.NewRepository(s.db)
+	sitemapRedisRepo := sitemapRepository.NewRedisRepo(s.redis)
+
+	mediaRepo := mediaRepository.NewRepository(s.db)
+
 	// Init useCases
-	authUC := authUseCase.NewUseCase(s.cfg, authRepo, authRedisRepo, s.logger)
+	authUC := authUseCase.NewUseCase(s.cfg, authRepo, authRedisRepo, s.mailer, s.logger)
 
 	shortUC := shortUseCase.NewUseCase(s.cfg, shortRepo, shortRedisRepo, s.logger)
 
+	feedUC := feedUseCase.NewUseCase(s.cfg, feedRedisRepo, postRepo, categoryRepo, tagRepo, s.logger)
+
+	sitemapUC := sitemapUseCase.NewUseCase(s.cfg, sitemapRepo, sitemapRedisRepo, s.logger)
+
+	postUC := postUseCase.NewUseCase(s.cfg, postRepo, postRedisRepo, s.logger, feedUC, sitemapUC)
+	s.postScheduler = postUseCase.NewScheduler(postUC, time.Duration(s.cfg.Server.PostSchedulerInterval)*time.Second, s.logger)
+
+	categoryUC := categoryUseCase.NewUseCase(s.cfg, categoryRepo, postRepo, s.logger, feedUC, sitemapUC)
+
+	tagUC := tagUseCase.NewUseCase(s.cfg, tagRepo, postRepo, s.logger, feedUC, sitemapUC)
+
+	commentUC := commentUseCase.NewUseCase(s.cfg, commentRepo, postRepo, s.logger)
+
+	mediaUC := mediaUseCase.NewUseCase(s.cfg, mediaRepo, postRepo, s.storage, s.logger)
+
+	authorUC := authorUseCase.NewUseCase(s.cfg, authRepo, postRepo, s.logger)
+
+	// Without configured keys access tokens are signed with HS256 and the JWT secret
+	jwtKeys := s.jwtKeys
+	if jwtKeys == nil {
+		jwtKeys = jwtkeys.NewHMAC(s.cfg.Server.JwtSecretKey)
+	}
+
 	// Init handlers
-	authHandlers := authHttp.NewHandlers(s.cfg, authUC, s.logger)
+	authHandlers := authHttp.NewHandlers(s.cfg, authUC, jwtKeys, s.logger)
 	shortHandlers := shortHttp.NewHandlers(s.cfg, shortUC, s.logger)
+	postHandlers := postHttp.NewHandlers(s.cfg, postUC, s.logger)
+	categoryHandlers := categoryHttp.NewHandlers(s.cfg, categoryUC, s.logger)
+	tagHandlers := tagHttp.NewHandlers(s.cfg, tagUC, s.logger)
+	commentHandlers := commentHttp.NewHandlers(s.cfg, commentUC, s.logger)
+	feedHandlers := feedHttp.NewHandlers(s.cfg, feedUC, s.logger)
+	sitemapHandlers := sitemapHttp.NewHandlers(s.cfg, sitemapUC, s.logger)
+	mediaHandlers := mediaHttp.NewHandlers(s.cfg, mediaUC, s.logger)
+	authorHandlers := authorHttp.NewHandlers(s.cfg, authorUC, s.logger)
 
-	mw := apiMiddlewares.NewMiddlewareManager(s.cfg, []string{"*"}, s.logger)
+	mw := apiMiddlewares.NewMiddlewareManager(s.cfg, []string{"*"}, authUC, jwtKeys, s.logger)
 
 	s.gin.Use(requestid.New())
 	s.gin.Use(mw.MetricsMiddleware(metrics))
@@ -63,20 +150,44 @@ func (s *Server) MapHandlers() error {
 	// Swagger docs endpoint
 	s.gin.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Uploaded files are served by the API itself only with local storage
+	if local, ok := s.storage.(*storage.Local); ok {
+		s.gin.Static(local.URLPath(), local.Dir())
+	}
+
 	v1 := s.gin.Group("/api/v1")
 	noPrefixGroup := s.gin.Group("")
 	authGroup := v1.Group("/auth")
 	shortGroup := noPrefixGroup.Group("")
+	feedGroup := noPrefixGroup.Group("")
+	sitemapGroup := noPrefixGroup.Group("")
+	postGroup := v1.Group("/posts")
+	categoryGroup := v1.Group("/categories")
+	tagGroup := v1.Group("/tags")
+	commentGroup := v1.Group("/comments")
+	mediaGroup := v1.Group("/media")
+	authorGroup := v1.Group("/authors")
+	// Tag and comment routes nested under /posts must not inherit the posts auth middleware
+	postChildGroup := v1.Group("/posts")
 	
 	// Create a separate group for GraphQL that doesn't have auth middleware
 	graphqlGroup := v1.Group("/graphql")
 
 	// Register HTTP routes
 	authHttp.MapRoutes(authGroup, authHandlers, mw)
-	shortHttp.MapRoutes(shortGroup, shortHandlers)
+	authHttp.MapWellKnownRoutes(noPrefixGroup, authHandlers)
+	shortHttp.MapRoutes(shortGroup, shortHandlers, mw)
+	postHttp.MapRoutes(postGroup, postHandlers, mw)
+	categoryHttp.MapRoutes(categoryGroup, categoryHandlers, mw)
+	tagHttp.MapRoutes(tagGroup, postChildGroup, tagHandlers, mw)
+	commentHttp.MapRoutes(commentGroup, postChildGroup, commentHandlers, mw)
+	feedHttp.MapRoutes(feedGroup, feedHandlers)
+	sitemapHttp.MapRoutes(sitemapGroup, sitemapHandlers)
+	mediaHttp.MapRoutes(mediaGroup, mediaHandlers, mw)
+	authorHttp.MapRoutes(authorGroup, authorHandlers)
 	
 	// Register GraphQL routes - using a separate group that bypasses auth
-	authGraphQL.RegisterGraphQLRoutes(graphqlGroup, s.cfg, authUC, s.logger)
+	authGraphQL.RegisterGraphQLRoutes(graphqlGroup, s.cfg, authUC, jwtKeys, s.logger)
 	
 	// Create a separate group for shortener GraphQL
 	shortGraphQLGroup := v1.Group("/graphql/shortener")
@@ -2,7 +2,10 @@ package server
 
 import (
 	"github.com/ductong169z/shorten-url/pkg/cache/redis"
+	"github.com/ductong169z/shorten-url/pkg/jwtkeys"
 	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/mailer"
+	"github.com/ductong169z/shorten-url/pkg/storage"
 	"github.com/gin-gonic/gin"
 )
 
@@ -21,6 +24,24 @@ func Redis(rdb redis.Client) Option {
 	}
 }
 
+func Storage(storage storage.Storage) Option {
+	return func(s *Server) {
+		s.storage = storage
+	}
+}
+
+func Mailer(mailer mailer.Mailer) Option {
+	return func(s *Server) {
+		s.mailer = mailer
+	}
+}
+
+func JWTKeys(keys *jwtkeys.KeySet) Option {
+	return func(s *Server) {
+		s.jwtKeys = keys
+	}
+}
+
 func Logger(logger logger.Logger) Option {
 	return func(s *Server) {
 		s.logger = logger
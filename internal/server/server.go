@@ -8,19 +8,28 @@ import (
 	"syscall"
 
 	"github.com/ductong169z/shorten-url/config"
+	postUseCase "github.com/ductong169z/shorten-url/internal/posts/usecase"
 	"github.com/ductong169z/shorten-url/pkg/cache/redis"
+	"github.com/ductong169z/shorten-url/pkg/jwtkeys"
 	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/mailer"
+	"github.com/ductong169z/shorten-url/pkg/storage"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 // Server struct
 type Server struct {
-	gin    *gin.Engine
-	cfg    *config.Config
-	db     *gorm.DB
-	redis  redis.Client
-	logger logger.Logger
+	gin     *gin.Engine
+	cfg     *config.Config
+	db      *gorm.DB
+	redis   redis.Client
+	storage storage.Storage
+	mailer  mailer.Mailer
+	jwtKeys *jwtkeys.KeySet
+	logger  logger.Logger
+
+	postScheduler *postUseCase.Scheduler
 }
 
 // NewServer New Server constructor
@@ -44,7 +53,11 @@ func (s *Server) Run() error {
 		return err
 	}
 
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go s.postScheduler.Run(ctx)
+
 	go func() {
 		s.logger.Infof(ctx, "Server is listening on PORT: %s", s.cfg.Server.Port)
 		ln, _ := net.Listen("tcp", ":"+s.cfg.Server.Port)
@@ -11,4 +11,5 @@ import (
 type Cache interface {
 	GetShortURLByCode(ctx context.Context, code string) (*models.ShortURL, error)
 	SetShortURLByCode(ctx context.Context, code string, url *models.ShortURL, ttl time.Duration) error
+	DeleteShortURLByCode(ctx context.Context, code string) error
 }
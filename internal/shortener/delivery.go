@@ -8,4 +8,5 @@ import (
 type Handlers interface {
 	Shorten(c *gin.Context)
 	Resolve(c *gin.Context)
+	Delete(c *gin.Context)
 }
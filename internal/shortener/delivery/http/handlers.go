@@ -25,7 +25,7 @@ func NewHandlers(cfg *config.Config, usecase shortener.UseCase, logger logger.Lo
 
 // Shorten godoc
 // @Summary      Create a shortened URL
-// @Description  Generate a short URL for the given original URL
+// @Description  Generate a short URL for the given original URL; with an Authorization header it is owned by the current user
 // @Tags         shortener
 // @Accept       json
 // @Produce      json
@@ -78,3 +78,19 @@ func (h *handlers) Resolve(c *gin.Context) {
 	}
 	c.Redirect(http.StatusFound, shortURL.OriginalURL)
 }
+
+// Delete godoc
+// @Summary      Delete a short URL
+// @Description  Delete a short URL owned by the current user; admins may delete any short URL
+// @Tags         shortener
+// @Param        code   path      string  true  "Short code"
+// @Success      204
+// @Failure      401,403,404  {object}  response.Response
+// @Router       /{code} [delete]
+func (h *handlers) Delete(c *gin.Context) {
+	if err := h.usecase.DeleteShortURL(c.Request.Context(), c.Param("code")); err != nil {
+		response.WithMappedError(c, err, shortener.MapError)
+		return
+	}
+	response.WithNoContent(c)
+}
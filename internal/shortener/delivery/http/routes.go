@@ -1,11 +1,13 @@
 package http
 
 import (
+	"github.com/ductong169z/shorten-url/internal/middleware"
 	"github.com/ductong169z/shorten-url/internal/shortener"
 	"github.com/gin-gonic/gin"
 )
 
-func MapRoutes(group *gin.RouterGroup, h shortener.Handlers) {
-	group.POST("/shorten", h.Shorten)
+func MapRoutes(group *gin.RouterGroup, h shortener.Handlers, mw *middleware.MiddlewareManager) {
+	group.POST("/shorten", mw.OptionalAuthJWTMiddleware(), h.Shorten)
 	group.GET("/:code", h.Resolve)
+	group.DELETE("/:code", mw.AuthJWTMiddleware(), h.Delete)
 }
@@ -36,6 +36,20 @@ func (m *MockCache) EXPECT() *MockCacheMockRecorder {
 	return m.recorder
 }
 
+// DeleteShortURLByCode mocks base method.
+func (m *MockCache) DeleteShortURLByCode(ctx context.Context, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShortURLByCode", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShortURLByCode indicates an expected call of DeleteShortURLByCode.
+func (mr *MockCacheMockRecorder) DeleteShortURLByCode(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShortURLByCode", reflect.TypeOf((*MockCache)(nil).DeleteShortURLByCode), ctx, code)
+}
+
 // GetShortURLByCode mocks base method.
 func (m *MockCache) GetShortURLByCode(ctx context.Context, code string) (*models.ShortURL, error) {
 	m.ctrl.T.Helper()
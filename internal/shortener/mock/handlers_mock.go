@@ -34,6 +34,18 @@ func (m *MockHandlers) EXPECT() *MockHandlersMockRecorder {
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockHandlers) Delete(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Delete", c)
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockHandlersMockRecorder) Delete(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHandlers)(nil).Delete), c)
+}
+
 // Resolve mocks base method.
 func (m *MockHandlers) Resolve(c *gin.Context) {
 	m.ctrl.T.Helper()
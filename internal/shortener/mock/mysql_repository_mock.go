@@ -49,6 +49,20 @@ func (mr *MockRepositoryMockRecorder) CreateShortURL(ctx, url interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShortURL", reflect.TypeOf((*MockRepository)(nil).CreateShortURL), ctx, url)
 }
 
+// DeleteShortURL mocks base method.
+func (m *MockRepository) DeleteShortURL(ctx context.Context, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShortURL", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShortURL indicates an expected call of DeleteShortURL.
+func (mr *MockRepositoryMockRecorder) DeleteShortURL(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShortURL", reflect.TypeOf((*MockRepository)(nil).DeleteShortURL), ctx, code)
+}
+
 // GetShortURLByCode mocks base method.
 func (m *MockRepository) GetShortURLByCode(ctx context.Context, code string) (*models.ShortURL, error) {
 	m.ctrl.T.Helper()
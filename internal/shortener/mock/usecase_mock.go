@@ -35,6 +35,20 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 	return m.recorder
 }
 
+// DeleteShortURL mocks base method.
+func (m *MockUseCase) DeleteShortURL(ctx context.Context, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShortURL", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShortURL indicates an expected call of DeleteShortURL.
+func (mr *MockUseCaseMockRecorder) DeleteShortURL(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShortURL", reflect.TypeOf((*MockUseCase)(nil).DeleteShortURL), ctx, code)
+}
+
 // ResolveShortCode mocks base method.
 func (m *MockUseCase) ResolveShortCode(ctx context.Context, code string) (*models.ShortURL, error) {
 	m.ctrl.T.Helper()
@@ -12,4 +12,5 @@ type Repository interface {
 	GetShortURLByCode(ctx context.Context, code string) (*models.ShortURL, error)
 	IncrementClickCount(ctx context.Context, code string) error
 	IsShortCodeExist(ctx context.Context, code string) (bool, error)
+	DeleteShortURL(ctx context.Context, code string) error
 }
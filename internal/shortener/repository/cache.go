@@ -44,3 +44,7 @@ func (r *redisRepo) SetShortURLByCode(ctx context.Context, code string, url *mod
 	}
 	return nil
 }
+
+func (r *redisRepo) DeleteShortURLByCode(ctx context.Context, code string) error {
+	return r.rdb.Del(ctx, code)
+}
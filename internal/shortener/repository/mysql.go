@@ -52,3 +52,7 @@ func (r *repo) IsShortCodeExist(ctx context.Context, code string) (bool, error)
 
 	return count > 0, nil
 }
+
+func (r *repo) DeleteShortURL(ctx context.Context, code string) error {
+	return r.db.WithContext(ctx).Where("short_code = ?", code).Delete(&models.ShortURL{}).Error
+}
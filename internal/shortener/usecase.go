@@ -10,4 +10,5 @@ import (
 type UseCase interface {
 	ShortenURL(ctx context.Context, shortURL *models.ShortURL) (*models.ShortURL, error)
 	ResolveShortCode(ctx context.Context, code string) (*models.ShortURL, error)
+	DeleteShortURL(ctx context.Context, code string) error
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/ductong169z/shorten-url/internal/models"
 	"github.com/ductong169z/shorten-url/internal/shortener"
 	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/utils"
 )
 
 type usecase struct {
@@ -25,7 +26,12 @@ func NewUseCase(cfg *config.Config, repo shortener.Repository, cache shortener.C
 	return &usecase{cfg: cfg, repo: repo, cache: cache, logger: logger}
 }
 
+// ShortenURL implements shortener.UseCase.
+// Short URLs created by a logged-in user are owned by them.
 func (u *usecase) ShortenURL(ctx context.Context, shortURL *models.ShortURL) (*models.ShortURL, error) {
+	if user, err := utils.GetUserFromCtx(ctx); err == nil {
+		shortURL.UserID = &user.ID
+	}
 
 	if shortURL.ShortCode == "" {
 		shortURL.ShortCode = generateShortCode(8)
@@ -101,6 +107,30 @@ func (u *usecase) ResolveShortCode(ctx context.Context, code string) (*models.Sh
 	return url, nil
 }
 
+// DeleteShortURL implements shortener.UseCase.
+// Anonymous short URLs have no owner and may only be deleted by an admin.
+func (u *usecase) DeleteShortURL(ctx context.Context, code string) error {
+	url, err := u.repo.GetShortURLByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	if url == nil {
+		return shortener.ErrShortCodeNotFound
+	}
+	if err := utils.ValidateIsOwner(ctx, utils.OwnerID(url.UserID), models.PermShortURLsManageAny, u.logger); err != nil {
+		return err
+	}
+
+	if err := u.repo.DeleteShortURL(ctx, code); err != nil {
+		return err
+	}
+	if err := u.cache.DeleteShortURLByCode(ctx, code); err != nil {
+		u.logger.Errorf(ctx, "Failed to delete short URL %s from cache: %v", code, err)
+	}
+
+	return nil
+}
+
 const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 
 func generateShortCode(n int) string {
@@ -1 +1,89 @@
 package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/shortener"
+	mock "github.com/ductong169z/shorten-url/internal/shortener/mock"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
+)
+
+func withUser(user *models.User) context.Context {
+	return context.WithValue(context.Background(), utils.UserCtxKey{}, user)
+}
+
+func TestUseCase_DeleteShortURL(t *testing.T) {
+	ownerID := 7
+
+	tcs := map[string]struct {
+		ctx       context.Context
+		url       *models.ShortURL
+		expDelete bool
+		expErr    error
+	}{
+		"owner": {
+			ctx:       withUser(&models.User{ID: 7, Role: models.RoleUser}),
+			url:       &models.ShortURL{ShortCode: "abcd", UserID: &ownerID},
+			expDelete: true,
+		},
+		"admin": {
+			ctx:       withUser(&models.User{ID: 1, Role: models.RoleAdmin}),
+			url:       &models.ShortURL{ShortCode: "abcd", UserID: &ownerID},
+			expDelete: true,
+		},
+		"other user": {
+			ctx:    withUser(&models.User{ID: 8, Role: models.RoleEditor}),
+			url:    &models.ShortURL{ShortCode: "abcd", UserID: &ownerID},
+			expErr: pkgErrors.Forbidden,
+		},
+		"anonymous short URL": {
+			ctx:    withUser(&models.User{ID: 7, Role: models.RoleUser}),
+			url:    &models.ShortURL{ShortCode: "abcd"},
+			expErr: pkgErrors.Forbidden,
+		},
+		"not logged in": {
+			ctx:    context.Background(),
+			url:    &models.ShortURL{ShortCode: "abcd", UserID: &ownerID},
+			expErr: pkgErrors.Unauthorized,
+		},
+		"not found": {
+			ctx:    withUser(&models.User{ID: 7, Role: models.RoleUser}),
+			expErr: shortener.ErrShortCodeNotFound,
+		},
+	}
+
+	for desc, tc := range tcs {
+		t.Run(desc, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			cache := mock.NewMockCache(ctrl)
+			uc := NewUseCase(cfg, repo, cache, apiLogger)
+
+			repo.EXPECT().GetShortURLByCode(gomock.Any(), "abcd").Return(tc.url, nil)
+			if tc.expDelete {
+				repo.EXPECT().DeleteShortURL(gomock.Any(), "abcd").Return(nil)
+				cache.EXPECT().DeleteShortURLByCode(gomock.Any(), "abcd").Return(nil)
+			}
+
+			err := uc.DeleteShortURL(tc.ctx, "abcd")
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+//go:generate mockgen -source cache.go -destination mock/cache_mock.go -package mock
+package sitemap
+
+import (
+	"context"
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+)
+
+type Cache interface {
+	GetSitemap(ctx context.Context, key string) (*models.Sitemap, error)
+	SetSitemap(ctx context.Context, key string, sitemap *models.Sitemap, ttl time.Duration) error
+	Invalidate(ctx context.Context) error
+}
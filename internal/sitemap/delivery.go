@@ -0,0 +1,11 @@
+//go:generate mockgen -source delivery.go -destination mock/handlers_mock.go -package mock
+package sitemap
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type Handlers interface {
+	Index(c *gin.Context)
+	Page(c *gin.Context)
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/sitemap"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	xmlContentType = "application/xml; charset=utf-8"
+	cacheControl   = "public, max-age=3600"
+)
+
+// Sitemap handlers
+type handlers struct {
+	cfg     *config.Config
+	usecase sitemap.UseCase
+	logger  logger.Logger
+}
+
+// NewHandlers Sitemap handlers constructor
+func NewHandlers(cfg *config.Config, usecase sitemap.UseCase, logger logger.Logger) sitemap.Handlers {
+	return &handlers{cfg: cfg, usecase: usecase, logger: logger}
+}
+
+// Index godoc
+// @Summary      XML sitemap
+// @Description  Sitemap of published posts, categories and tags; switches to a sitemap index above 50,000 URLs
+// @Tags         sitemap
+// @Produce      xml
+// @Success      200
+// @Router       /sitemap.xml [get]
+func (h *handlers) Index(c *gin.Context) {
+	s, err := h.usecase.Index(c.Request.Context())
+	if err != nil {
+		response.WithMappedError(c, err, sitemap.MapError)
+		return
+	}
+	h.write(c, s)
+}
+
+// Page godoc
+// @Summary      XML sitemap page
+// @Description  One page of the sitemap when the sitemap index is in use
+// @Tags         sitemap
+// @Produce      xml
+// @Param        file  path  string  true  "Page file, e.g. sitemap-1.xml"
+// @Success      200
+// @Failure      404  {object}  response.Response
+// @Router       /sitemaps/{file} [get]
+func (h *handlers) Page(c *gin.Context) {
+	page, err := sitemap.ParsePageFile(c.Param("file"))
+	if err != nil {
+		response.WithMappedError(c, err, sitemap.MapError)
+		return
+	}
+
+	s, err := h.usecase.Page(c.Request.Context(), page)
+	if err != nil {
+		response.WithMappedError(c, err, sitemap.MapError)
+		return
+	}
+	h.write(c, s)
+}
+
+func (h *handlers) write(c *gin.Context, s *models.Sitemap) {
+	c.Header("Cache-Control", cacheControl)
+	c.Data(http.StatusOK, xmlContentType, s.Body)
+}
@@ -0,0 +1,12 @@
+package http
+
+import (
+	"github.com/ductong169z/shorten-url/internal/sitemap"
+	"github.com/gin-gonic/gin"
+)
+
+// Map sitemap routes
+func MapRoutes(group *gin.RouterGroup, h sitemap.Handlers) {
+	group.GET("/sitemap.xml", h.Index)
+	group.GET("/sitemaps/:file", h.Page)
+}
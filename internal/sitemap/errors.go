@@ -0,0 +1,29 @@
+// Package sitemap provides core error definitions and utilities for the XML sitemap domain.
+// It defines domain-specific error variables and error-to-HTTP status mapping for consistent error handling.
+package sitemap
+
+import (
+	"errors"
+	"net/http"
+)
+
+const (
+	// sitemapNotFound is returned when a sitemap page does not exist.
+	sitemapNotFound = "sitemap not found"
+)
+
+var (
+	// ErrSitemapNotFound indicates that the sitemap page was not found.
+	ErrSitemapNotFound = errors.New(sitemapNotFound)
+)
+
+// MapError maps a domain error to an HTTP status code and message.
+// It provides a unified way to translate domain errors to HTTP responses.
+func MapError(err error) (status int, message string) {
+	switch {
+	case errors.Is(err, ErrSitemapNotFound):
+		return http.StatusNotFound, sitemapNotFound
+	default:
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
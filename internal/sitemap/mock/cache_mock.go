@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cache.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/ductong169z/shorten-url/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCache is a mock of Cache interface.
+type MockCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheMockRecorder
+}
+
+// MockCacheMockRecorder is the mock recorder for MockCache.
+type MockCacheMockRecorder struct {
+	mock *MockCache
+}
+
+// NewMockCache creates a new mock instance.
+func NewMockCache(ctrl *gomock.Controller) *MockCache {
+	mock := &MockCache{ctrl: ctrl}
+	mock.recorder = &MockCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCache) EXPECT() *MockCacheMockRecorder {
+	return m.recorder
+}
+
+// GetSitemap mocks base method.
+func (m *MockCache) GetSitemap(ctx context.Context, key string) (*models.Sitemap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSitemap", ctx, key)
+	ret0, _ := ret[0].(*models.Sitemap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSitemap indicates an expected call of GetSitemap.
+func (mr *MockCacheMockRecorder) GetSitemap(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSitemap", reflect.TypeOf((*MockCache)(nil).GetSitemap), ctx, key)
+}
+
+// Invalidate mocks base method.
+func (m *MockCache) Invalidate(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invalidate", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockCacheMockRecorder) Invalidate(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockCache)(nil).Invalidate), ctx)
+}
+
+// SetSitemap mocks base method.
+func (m *MockCache) SetSitemap(ctx context.Context, key string, sitemap *models.Sitemap, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSitemap", ctx, key, sitemap, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSitemap indicates an expected call of SetSitemap.
+func (mr *MockCacheMockRecorder) SetSitemap(ctx, key, sitemap, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSitemap", reflect.TypeOf((*MockCache)(nil).SetSitemap), ctx, key, sitemap, ttl)
+}
@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ductong169z/shorten-url/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Index mocks base method.
+func (m *MockUseCase) Index(ctx context.Context) (*models.Sitemap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index", ctx)
+	ret0, _ := ret[0].(*models.Sitemap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockUseCaseMockRecorder) Index(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockUseCase)(nil).Index), ctx)
+}
+
+// Page mocks base method.
+func (m *MockUseCase) Page(ctx context.Context, page int) (*models.Sitemap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Page", ctx, page)
+	ret0, _ := ret[0].(*models.Sitemap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Page indicates an expected call of Page.
+func (mr *MockUseCaseMockRecorder) Page(ctx, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Page", reflect.TypeOf((*MockUseCase)(nil).Page), ctx, page)
+}
+
+// PostsChanged mocks base method.
+func (m *MockUseCase) PostsChanged(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PostsChanged", ctx)
+}
+
+// PostsChanged indicates an expected call of PostsChanged.
+func (mr *MockUseCaseMockRecorder) PostsChanged(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostsChanged", reflect.TypeOf((*MockUseCase)(nil).PostsChanged), ctx)
+}
@@ -0,0 +1,13 @@
+//go:generate mockgen -source mysql.go -destination mock/mysql_repository_mock.go -package mock
+package sitemap
+
+import (
+	"context"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+)
+
+type Repository interface {
+	CountURLs(ctx context.Context) (int64, error)
+	ListURLs(ctx context.Context, offset, limit int) ([]*models.SitemapURL, error)
+}
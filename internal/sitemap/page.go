@@ -0,0 +1,23 @@
+package sitemap
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PageFile is the file name of a sitemap page
+func PageFile(page int) string {
+	return "sitemap-" + strconv.Itoa(page) + ".xml"
+}
+
+// ParsePageFile returns the page number of a sitemap page file name
+func ParsePageFile(file string) (int, error) {
+	if !strings.HasPrefix(file, "sitemap-") || !strings.HasSuffix(file, ".xml") {
+		return 0, ErrSitemapNotFound
+	}
+	page, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(file, "sitemap-"), ".xml"))
+	if err != nil {
+		return 0, ErrSitemapNotFound
+	}
+	return page, nil
+}
@@ -0,0 +1,18 @@
+package sitemap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePageFile(t *testing.T) {
+	page, err := ParsePageFile(PageFile(12))
+	assert.NoError(t, err)
+	assert.Equal(t, 12, page)
+
+	for _, file := range []string{"sitemap.xml", "sitemap-x.xml", "feed-1.xml", "sitemap-1.txt"} {
+		_, err := ParsePageFile(file)
+		assert.ErrorIs(t, err, ErrSitemapNotFound, file)
+	}
+}
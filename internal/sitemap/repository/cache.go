@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/sitemap"
+	"github.com/ductong169z/shorten-url/pkg/cache/redis"
+)
+
+const (
+	sitemapKeyPrefix = "api-sitemap:"
+	generationKey    = sitemapKeyPrefix + "generation"
+)
+
+// Sitemap redis repository.
+// Keys embed a generation number so every cached sitemap is invalidated by
+// writing a new generation; stale keys expire on their own.
+type redisRepo struct {
+	rdb redis.Client
+}
+
+// Sitemap redis repository constructor
+func NewRedisRepo(rdb redis.Client) sitemap.Cache {
+	return &redisRepo{rdb: rdb}
+}
+
+func (r *redisRepo) GetSitemap(ctx context.Context, key string) (*models.Sitemap, error) {
+	data, err := r.rdb.Get(ctx, r.key(ctx, key))
+	if err != nil {
+		return nil, err
+	}
+	var s models.Sitemap
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *redisRepo) SetSitemap(ctx context.Context, key string, s *models.Sitemap, ttl time.Duration) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return r.rdb.Set(ctx, r.key(ctx, key), string(data), ttl)
+}
+
+func (r *redisRepo) Invalidate(ctx context.Context) error {
+	return r.rdb.Set(ctx, generationKey, strconv.FormatInt(time.Now().UnixNano(), 10), 0)
+}
+
+func (r *redisRepo) key(ctx context.Context, key string) string {
+	generation, err := r.rdb.Get(ctx, generationKey)
+	if err != nil {
+		generation = []byte("0")
+	}
+	return sitemapKeyPrefix + string(generation) + ":" + key
+}
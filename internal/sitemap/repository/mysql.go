@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/sitemap"
+	"gorm.io/gorm"
+)
+
+const countURLsQuery = `SELECT
+	(SELECT COUNT(*) FROM posts WHERE status = ?) +
+	(SELECT COUNT(*) FROM categories) +
+	(SELECT COUNT(*) FROM tags)`
+
+// Posts first, then categories and tags, each by slug so pages stay stable
+const listURLsQuery = `SELECT kind, slug, updated_at FROM (
+	SELECT 1 AS ord, ? AS kind, slug, updated_at FROM posts WHERE status = ?
+	UNION ALL
+	SELECT 2 AS ord, ? AS kind, slug, updated_at FROM categories
+	UNION ALL
+	SELECT 3 AS ord, ? AS kind, slug, updated_at FROM tags
+) AS urls
+ORDER BY ord, slug
+LIMIT ? OFFSET ?`
+
+// Sitemap Repository
+type repo struct {
+	db *gorm.DB
+}
+
+// Sitemap repository constructor
+func NewRepository(db *gorm.DB) sitemap.Repository {
+	return &repo{db: db}
+}
+
+// CountURLs implements sitemap.Repository.
+func (r *repo) CountURLs(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Raw(countURLsQuery, models.PostStatusPublished).Scan(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListURLs implements sitemap.Repository.
+func (r *repo) ListURLs(ctx context.Context, offset, limit int) ([]*models.SitemapURL, error) {
+	urls := make([]*models.SitemapURL, 0)
+	if err := r.db.WithContext(ctx).Raw(
+		listURLsQuery,
+		models.SitemapURLPost, models.PostStatusPublished,
+		models.SitemapURLCategory,
+		models.SitemapURLTag,
+		limit, offset,
+	).Scan(&urls).Error; err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
@@ -0,0 +1,14 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package sitemap
+
+import (
+	"context"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+)
+
+type UseCase interface {
+	Index(ctx context.Context) (*models.Sitemap, error)
+	Page(ctx context.Context, page int) (*models.Sitemap, error)
+	PostsChanged(ctx context.Context)
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/sitemap"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+)
+
+const (
+	DefaultCacheTTL = 1 * time.Hour
+	// MaxURLsPerSitemap is the sitemaps.org limit for a single urlset
+	MaxURLsPerSitemap = 50000
+	sitemapNamespace  = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	indexKey          = "index"
+	pageKeyPrefix     = "page:"
+)
+
+// pathPrefixes maps URL kinds to their path on the public blog
+var pathPrefixes = map[models.SitemapURLKind]string{
+	models.SitemapURLPost:     "posts",
+	models.SitemapURLCategory: "categories",
+	models.SitemapURLTag:      "tags",
+}
+
+type urlSet struct {
+	XMLName xml.Name   `xml:"urlset"`
+	NS      string     `xml:"xmlns,attr"`
+	URLs    []urlEntry `xml:"url"`
+}
+
+type urlEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name   `xml:"sitemapindex"`
+	NS       string     `xml:"xmlns,attr"`
+	Sitemaps []urlEntry `xml:"sitemap"`
+}
+
+type usecase struct {
+	cfg    *config.Config
+	repo   sitemap.Repository
+	cache  sitemap.Cache
+	logger logger.Logger
+}
+
+// Sitemap UseCase constructor
+func NewUseCase(cfg *config.Config, repo sitemap.Repository, cache sitemap.Cache, logger logger.Logger) sitemap.UseCase {
+	return &usecase{cfg: cfg, repo: repo, cache: cache, logger: logger}
+}
+
+// Index implements sitemap.UseCase.
+// Up to MaxURLsPerSitemap URLs are listed directly, beyond that it returns a
+// sitemap index pointing at <blog URL>/sitemaps/sitemap-N.xml pages.
+func (u *usecase) Index(ctx context.Context) (*models.Sitemap, error) {
+	if cached := u.getCache(ctx, indexKey); cached != nil {
+		return cached, nil
+	}
+
+	count, err := u.repo.CountURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	if count <= MaxURLsPerSitemap {
+		urls, err := u.repo.ListURLs(ctx, 0, MaxURLsPerSitemap)
+		if err != nil {
+			return nil, err
+		}
+		doc = u.urlSet(urls)
+	} else {
+		pages := int((count + MaxURLsPerSitemap - 1) / MaxURLsPerSitemap)
+		index := &sitemapIndex{NS: sitemapNamespace, Sitemaps: make([]urlEntry, pages)}
+		for i := range index.Sitemaps {
+			index.Sitemaps[i] = urlEntry{Loc: u.url("sitemaps", sitemap.PageFile(i+1))}
+		}
+		doc = index
+	}
+
+	return u.render(ctx, indexKey, doc)
+}
+
+// Page implements sitemap.UseCase.
+func (u *usecase) Page(ctx context.Context, page int) (*models.Sitemap, error) {
+	if page < 1 {
+		return nil, sitemap.ErrSitemapNotFound
+	}
+	key := pageKeyPrefix + strconv.Itoa(page)
+	if cached := u.getCache(ctx, key); cached != nil {
+		return cached, nil
+	}
+
+	urls, err := u.repo.ListURLs(ctx, (page-1)*MaxURLsPerSitemap, MaxURLsPerSitemap)
+	if err != nil {
+		return nil, err
+	}
+	if len(urls) == 0 {
+		return nil, sitemap.ErrSitemapNotFound
+	}
+
+	return u.render(ctx, key, u.urlSet(urls))
+}
+
+// PostsChanged implements posts.Observer by dropping every cached sitemap.
+func (u *usecase) PostsChanged(ctx context.Context) {
+	if err := u.cache.Invalidate(ctx); err != nil {
+		u.logger.Errorf(ctx, "Failed to invalidate sitemaps: %v", err)
+	}
+}
+
+func (u *usecase) urlSet(urls []*models.SitemapURL) *urlSet {
+	set := &urlSet{NS: sitemapNamespace, URLs: make([]urlEntry, len(urls))}
+	for i, url := range urls {
+		entry := urlEntry{Loc: u.url(pathPrefixes[url.Kind], url.Slug)}
+		if !url.UpdatedAt.IsZero() {
+			entry.LastMod = url.UpdatedAt.UTC().Format(time.RFC3339)
+		}
+		set.URLs[i] = entry
+	}
+	return set
+}
+
+// url joins path segments onto the public blog URL
+func (u *usecase) url(segments ...string) string {
+	return strings.Join(append([]string{strings.TrimRight(u.cfg.Blog.URL, "/")}, segments...), "/")
+}
+
+func (u *usecase) render(ctx context.Context, key string, doc interface{}) (*models.Sitemap, error) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	s := &models.Sitemap{Body: append([]byte(xml.Header), body...)}
+
+	if err := u.cache.SetSitemap(ctx, key, s, DefaultCacheTTL); err != nil {
+		u.logger.Errorf(ctx, "Failed to set sitemap %s in cache: %v", key, err)
+	}
+	return s, nil
+}
+
+func (u *usecase) getCache(ctx context.Context, key string) *models.Sitemap {
+	cached, err := u.cache.GetSitemap(ctx, key)
+	if err != nil {
+		u.logger.Debugf(ctx, "Cache miss for sitemap %s: %v", key, err)
+	}
+	return cached
+}
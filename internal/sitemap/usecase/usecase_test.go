@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/sitemap"
+	mock "github.com/ductong169z/shorten-url/internal/sitemap/mock"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestUseCase(t *testing.T) (sitemap.UseCase, *mock.MockRepository, *mock.MockCache) {
+	cfg := &config.Config{Blog: config.BlogConfig{URL: "https://blog.example.com/"}}
+	apiLogger := logger.NewApiLogger(cfg)
+	apiLogger.InitLogger()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock.NewMockRepository(ctrl)
+	cache := mock.NewMockCache(ctrl)
+	return NewUseCase(cfg, repo, cache, apiLogger), repo, cache
+}
+
+func TestUseCase_Index(t *testing.T) {
+	updatedAt := time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)
+	urls := []*models.SitemapURL{
+		{Kind: models.SitemapURLPost, Slug: "hello", UpdatedAt: updatedAt},
+		{Kind: models.SitemapURLCategory, Slug: "go"},
+		{Kind: models.SitemapURLTag, Slug: "gin"},
+	}
+
+	tcs := map[string]struct {
+		count       int64
+		expContains []string
+	}{
+		"urlset": {
+			count: int64(len(urls)),
+			expContains: []string{
+				`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`,
+				`<loc>https://blog.example.com/posts/hello</loc>`,
+				`<lastmod>2024-05-02T08:30:00Z</lastmod>`,
+				`<loc>https://blog.example.com/categories/go</loc>`,
+				`<loc>https://blog.example.com/tags/gin</loc>`,
+			},
+		},
+		"sitemap index": {
+			count: MaxURLsPerSitemap*2 + 1,
+			expContains: []string{
+				`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`,
+				`<loc>https://blog.example.com/sitemaps/sitemap-1.xml</loc>`,
+				`<loc>https://blog.example.com/sitemaps/sitemap-3.xml</loc>`,
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			u, repo, cache := newTestUseCase(t)
+			ctx := context.Background()
+
+			cache.EXPECT().GetSitemap(ctx, indexKey).Return(nil, nil)
+			repo.EXPECT().CountURLs(ctx).Return(tc.count, nil)
+			if tc.count <= MaxURLsPerSitemap {
+				repo.EXPECT().ListURLs(ctx, 0, MaxURLsPerSitemap).Return(urls, nil)
+			}
+			cache.EXPECT().SetSitemap(ctx, indexKey, gomock.Any(), DefaultCacheTTL).Return(nil)
+
+			s, err := u.Index(ctx)
+			assert.NoError(t, err)
+			for _, want := range tc.expContains {
+				assert.Contains(t, string(s.Body), want)
+			}
+		})
+	}
+}
+
+func TestUseCase_Page(t *testing.T) {
+	tcs := map[string]struct {
+		page   int
+		urls   []*models.SitemapURL
+		expErr error
+	}{
+		"second page": {
+			page: 2,
+			urls: []*models.SitemapURL{{Kind: models.SitemapURLPost, Slug: "hello"}},
+		},
+		"out of range": {
+			page:   5,
+			expErr: sitemap.ErrSitemapNotFound,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			u, repo, cache := newTestUseCase(t)
+			ctx := context.Background()
+
+			key := pageKeyPrefix + strconv.Itoa(tc.page)
+			cache.EXPECT().GetSitemap(ctx, key).Return(nil, nil)
+			repo.EXPECT().ListURLs(ctx, (tc.page-1)*MaxURLsPerSitemap, MaxURLsPerSitemap).Return(tc.urls, nil)
+			if tc.expErr == nil {
+				cache.EXPECT().SetSitemap(ctx, key, gomock.Any(), DefaultCacheTTL).Return(nil)
+			}
+
+			s, err := u.Page(ctx, tc.page)
+			assert.ErrorIs(t, err, tc.expErr)
+			if tc.expErr == nil {
+				assert.Contains(t, string(s.Body), `<loc>https://blog.example.com/posts/hello</loc>`)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+//go:generate mockgen -source delivery.go -destination mock/handlers_mock.go -package mock
+package tag
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type Handlers interface {
+	Create(c *gin.Context)
+	List(c *gin.Context)
+	Cloud(c *gin.Context)
+	ListPosts(c *gin.Context)
+	ListPostTags(c *gin.Context)
+	ReplacePostTags(c *gin.Context)
+}
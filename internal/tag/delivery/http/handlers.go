@@ -0,0 +1,187 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ductong169z/shorten-url/config"
+	postHttp "github.com/ductong169z/shorten-url/internal/posts/delivery/http"
+	"github.com/ductong169z/shorten-url/internal/tag"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/response"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Tag handlers
+type handlers struct {
+	cfg     *config.Config
+	usecase tag.UseCase
+	logger  logger.Logger
+}
+
+// NewHandlers Tag handlers constructor
+func NewHandlers(cfg *config.Config, usecase tag.UseCase, logger logger.Logger) tag.Handlers {
+	return &handlers{cfg: cfg, usecase: usecase, logger: logger}
+}
+
+// Create godoc
+// @Summary      Create tag
+// @Description  Create a new tag; the slug is generated from the name when omitted
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        tagRequest   body      TagRequest  true  "Tag payload"
+// @Success      201          {object}  TagResponse
+// @Failure      400,401,409  {object}  response.Response
+// @Router       /tags [post]
+func (h *handlers) Create(c *gin.Context) {
+	var req TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, tag.MapError)
+		return
+	}
+
+	created, err := h.usecase.Create(c.Request.Context(), req.ToModel())
+	if err != nil {
+		response.WithMappedError(c, err, tag.MapError)
+		return
+	}
+
+	response.WithCode(c, http.StatusCreated, FromTagModel(created))
+}
+
+// List godoc
+// @Summary      List tags
+// @Description  List tags ordered by name with pagination
+// @Tags         tags
+// @Produce      json
+// @Param        page  query     int  false  "Page number"
+// @Param        size  query     int  false  "Page size"
+// @Success      200   {object}  TagListResponse
+// @Failure      400   {object}  response.Response
+// @Router       /tags [get]
+func (h *handlers) List(c *gin.Context) {
+	pq, err := utils.GetPaginationFromCtx(c)
+	if err != nil {
+		response.WithMappedError(c, err, tag.MapError)
+		return
+	}
+
+	list, err := h.usecase.List(c.Request.Context(), pq)
+	if err != nil {
+		response.WithMappedError(c, err, tag.MapError)
+		return
+	}
+
+	response.WithOK(c, FromTagListModel(list))
+}
+
+// Cloud godoc
+// @Summary      Tag cloud
+// @Description  List the most used tags with the number of published posts using them
+// @Tags         tags
+// @Produce      json
+// @Param        limit  query     int  false  "Maximum number of tags"
+// @Success      200    {array}   TagCountResponse
+// @Failure      400    {object}  response.Response
+// @Router       /tags/cloud [get]
+func (h *handlers) Cloud(c *gin.Context) {
+	limit := 0
+	if l := c.Query("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil {
+			response.WithMappedError(c, err, tag.MapError)
+			return
+		}
+		limit = n
+	}
+
+	counts, err := h.usecase.Cloud(c.Request.Context(), limit)
+	if err != nil {
+		response.WithMappedError(c, err, tag.MapError)
+		return
+	}
+
+	response.WithOK(c, FromTagCountModelList(counts))
+}
+
+// ListPosts godoc
+// @Summary      List posts by tag
+// @Description  List published posts with a tag with pagination
+// @Tags         tags
+// @Produce      json
+// @Param        slug     path      string  true   "Tag slug"
+// @Param        page     query     int     false  "Page number"
+// @Param        size     query     int     false  "Page size"
+// @Param        orderBy  query     string  false  "Sort order"
+// @Success      200      {object}  http.PostListResponse
+// @Failure      400,404  {object}  response.Response
+// @Router       /tags/{slug}/posts [get]
+func (h *handlers) ListPosts(c *gin.Context) {
+	pq, err := utils.GetPaginationFromCtx(c)
+	if err != nil {
+		response.WithMappedError(c, err, tag.MapError)
+		return
+	}
+
+	list, err := h.usecase.ListPosts(c.Request.Context(), c.Param("slug"), pq)
+	if err != nil {
+		response.WithMappedError(c, err, tag.MapError)
+		return
+	}
+
+	response.WithOK(c, postHttp.FromPostListModel(list))
+}
+
+// ListPostTags godoc
+// @Summary      List post tags
+// @Description  List the tags of a published post
+// @Tags         tags
+// @Produce      json
+// @Param        slug  path      string  true  "Post slug"
+// @Success      200   {array}   TagResponse
+// @Failure      404   {object}  response.Response
+// @Router       /posts/{slug}/tags [get]
+func (h *handlers) ListPostTags(c *gin.Context) {
+	tags, err := h.usecase.ListPostTags(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		response.WithMappedError(c, err, tag.MapError)
+		return
+	}
+
+	response.WithOK(c, FromTagModelList(tags))
+}
+
+// ReplacePostTags godoc
+// @Summary      Replace post tags
+// @Description  Replace the full set of tags on a post (author or admin)
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        id               path      int              true  "Post ID"
+// @Param        postTagsRequest  body      PostTagsRequest  true  "Tag IDs"
+// @Success      200              {array}   TagResponse
+// @Failure      400,401,403,404  {object}  response.Response
+// @Router       /posts/{id}/tags [put]
+func (h *handlers) ReplacePostTags(c *gin.Context) {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.WithMappedError(c, err, tag.MapError)
+		return
+	}
+
+	var req PostTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.WithMappedError(c, err, tag.MapError)
+		return
+	}
+
+	tags, err := h.usecase.ReplacePostTags(c.Request.Context(), postID, req.TagIDs)
+	if err != nil {
+		response.WithMappedError(c, err, tag.MapError)
+		return
+	}
+
+	response.WithOK(c, FromTagModelList(tags))
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+)
+
+type TagRequest struct {
+	Name string `json:"name" binding:"required"`
+	Slug string `json:"slug,omitempty"`
+}
+
+type PostTagsRequest struct {
+	TagIDs []int `json:"tag_ids"`
+}
+
+type TagResponse struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type TagListResponse struct {
+	TotalCount int64         `json:"total_count"`
+	TotalPages int           `json:"total_pages"`
+	Page       int           `json:"page"`
+	Size       int           `json:"size"`
+	HasMore    bool          `json:"has_more"`
+	Tags       []TagResponse `json:"tags"`
+}
+
+type TagCountResponse struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	PostCount int64  `json:"post_count"`
+}
+
+func FormatTime(time time.Time) string {
+	return time.Format("2006-01-02 15:04:05")
+}
+
+func (r *TagRequest) ToModel() *models.Tag {
+	return &models.Tag{
+		Name: r.Name,
+		Slug: r.Slug,
+	}
+}
+
+func FromTagModel(t *models.Tag) TagResponse {
+	if t == nil {
+		return TagResponse{}
+	}
+
+	return TagResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		Slug:      t.Slug,
+		CreatedAt: FormatTime(t.CreatedAt),
+		UpdatedAt: FormatTime(t.UpdatedAt),
+	}
+}
+
+func FromTagModelList(tags []*models.Tag) []TagResponse {
+	tagResponses := make([]TagResponse, len(tags))
+	for i, t := range tags {
+		tagResponses[i] = FromTagModel(t)
+	}
+	return tagResponses
+}
+
+func FromTagListModel(list *models.TagList) TagListResponse {
+	return TagListResponse{
+		TotalCount: list.TotalCount,
+		TotalPages: list.TotalPages,
+		Page:       list.Page,
+		Size:       list.Size,
+		HasMore:    list.HasMore,
+		Tags:       FromTagModelList(list.Tags),
+	}
+}
+
+func FromTagCountModelList(counts []*models.TagCount) []TagCountResponse {
+	countResponses := make([]TagCountResponse, len(counts))
+	for i, tc := range counts {
+		countResponses[i] = TagCountResponse{
+			ID:        tc.ID,
+			Name:      tc.Name,
+			Slug:      tc.Slug,
+			PostCount: tc.PostCount,
+		}
+	}
+	return countResponses
+}
@@ -0,0 +1,19 @@
+package http
+
+import (
+	"github.com/ductong169z/shorten-url/internal/middleware"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/tag"
+	"github.com/gin-gonic/gin"
+)
+
+// Map tag routes; postGroup must be a /posts group without middlewares
+func MapRoutes(group *gin.RouterGroup, postGroup *gin.RouterGroup, h tag.Handlers, mw *middleware.MiddlewareManager) {
+	group.GET("", h.List)
+	group.GET("/cloud", h.Cloud)
+	group.GET("/:slug/posts", h.ListPosts)
+	group.POST("", mw.AuthJWTMiddleware(), mw.RequirePermission(models.PermTagsCreate), h.Create)
+
+	postGroup.GET("/:slug/tags", h.ListPostTags)
+	postGroup.PUT("/:id/tags", mw.AuthJWTMiddleware(), h.ReplacePostTags)
+}
@@ -0,0 +1,71 @@
+// Package tag provides core error definitions and utilities for the post tags domain.
+// It defines domain-specific error variables and error-to-HTTP status mapping for consistent error handling.
+package tag
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ductong169z/shorten-url/internal/posts"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// tagNotFound is returned when a requested tag does not exist.
+	tagNotFound = "tag not found"
+	// tagAlreadyExists is returned when a tag with the same name or slug exists.
+	tagAlreadyExists = "tag already exists"
+	// invalidName is returned when the tag name is empty or too long.
+	invalidName = "invalid name"
+	// invalidSlug is returned when the slug is not URL-safe.
+	invalidSlug = "invalid slug"
+	// tooManyTags is returned when a post is given more tags than allowed.
+	tooManyTags = "too many tags"
+)
+
+var (
+	// ErrTagNotFound indicates that the tag was not found.
+	ErrTagNotFound = errors.New(tagNotFound)
+	// ErrTagAlreadyExists indicates that the tag already exists.
+	ErrTagAlreadyExists = errors.New(tagAlreadyExists)
+	// ErrInvalidName indicates that the name is invalid.
+	ErrInvalidName = errors.New(invalidName)
+	// ErrInvalidSlug indicates that the slug is invalid.
+	ErrInvalidSlug = errors.New(invalidSlug)
+	// ErrTooManyTags indicates that the tag set is too large.
+	ErrTooManyTags = errors.New(tooManyTags)
+)
+
+// MapError maps a domain error to an HTTP status code and message.
+// It provides a unified way to translate domain errors to HTTP responses.
+func MapError(err error) (status int, message string) {
+	// Handle JSON binding/unmarshal errors as 400 Bad Request
+	switch err.(type) {
+	case *json.UnmarshalTypeError, *json.SyntaxError:
+		return http.StatusBadRequest, "Invalid request format"
+	case *strconv.NumError:
+		return http.StatusBadRequest, "Invalid parameter format"
+	}
+	if ginErr, ok := err.(*gin.Error); ok && ginErr.Type == gin.ErrorTypeBind {
+		return http.StatusBadRequest, "Invalid request format"
+	}
+
+	switch {
+	case errors.Is(err, ErrTagNotFound):
+		return http.StatusNotFound, tagNotFound
+	case errors.Is(err, ErrTagAlreadyExists):
+		return http.StatusConflict, tagAlreadyExists
+	case errors.Is(err, ErrInvalidName):
+		return http.StatusBadRequest, invalidName
+	case errors.Is(err, ErrInvalidSlug):
+		return http.StatusBadRequest, invalidSlug
+	case errors.Is(err, ErrTooManyTags):
+		return http.StatusBadRequest, tooManyTags
+	case errors.Is(err, posts.ErrPostNotFound):
+		return http.StatusNotFound, posts.ErrPostNotFound.Error()
+	default:
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: delivery.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gin "github.com/gin-gonic/gin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHandlers is a mock of Handlers interface.
+type MockHandlers struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlersMockRecorder
+}
+
+// MockHandlersMockRecorder is the mock recorder for MockHandlers.
+type MockHandlersMockRecorder struct {
+	mock *MockHandlers
+}
+
+// NewMockHandlers creates a new mock instance.
+func NewMockHandlers(ctrl *gomock.Controller) *MockHandlers {
+	mock := &MockHandlers{ctrl: ctrl}
+	mock.recorder = &MockHandlersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandlers) EXPECT() *MockHandlersMockRecorder {
+	return m.recorder
+}
+
+// Cloud mocks base method.
+func (m *MockHandlers) Cloud(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Cloud", c)
+}
+
+// Cloud indicates an expected call of Cloud.
+func (mr *MockHandlersMockRecorder) Cloud(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cloud", reflect.TypeOf((*MockHandlers)(nil).Cloud), c)
+}
+
+// Create mocks base method.
+func (m *MockHandlers) Create(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Create", c)
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHandlersMockRecorder) Create(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHandlers)(nil).Create), c)
+}
+
+// List mocks base method.
+func (m *MockHandlers) List(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "List", c)
+}
+
+// List indicates an expected call of List.
+func (mr *MockHandlersMockRecorder) List(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHandlers)(nil).List), c)
+}
+
+// ListPostTags mocks base method.
+func (m *MockHandlers) ListPostTags(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListPostTags", c)
+}
+
+// ListPostTags indicates an expected call of ListPostTags.
+func (mr *MockHandlersMockRecorder) ListPostTags(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostTags", reflect.TypeOf((*MockHandlers)(nil).ListPostTags), c)
+}
+
+// ListPosts mocks base method.
+func (m *MockHandlers) ListPosts(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListPosts", c)
+}
+
+// ListPosts indicates an expected call of ListPosts.
+func (mr *MockHandlersMockRecorder) ListPosts(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPosts", reflect.TypeOf((*MockHandlers)(nil).ListPosts), c)
+}
+
+// ReplacePostTags mocks base method.
+func (m *MockHandlers) ReplacePostTags(c *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReplacePostTags", c)
+}
+
+// ReplacePostTags indicates an expected call of ReplacePostTags.
+func (mr *MockHandlersMockRecorder) ReplacePostTags(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplacePostTags", reflect.TypeOf((*MockHandlers)(nil).ReplacePostTags), c)
+}
@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mysql.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ductong169z/shorten-url/internal/models"
+	utils "github.com/ductong169z/shorten-url/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Cloud mocks base method.
+func (m *MockRepository) Cloud(ctx context.Context, limit int) ([]*models.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cloud", ctx, limit)
+	ret0, _ := ret[0].([]*models.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cloud indicates an expected call of Cloud.
+func (mr *MockRepositoryMockRecorder) Cloud(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cloud", reflect.TypeOf((*MockRepository)(nil).Cloud), ctx, limit)
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, tag)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, tag)
+}
+
+// GetByIDs mocks base method.
+func (m *MockRepository) GetByIDs(ctx context.Context, tagIDs []int) ([]*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, tagIDs)
+	ret0, _ := ret[0].([]*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockRepositoryMockRecorder) GetByIDs(ctx, tagIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockRepository)(nil).GetByIDs), ctx, tagIDs)
+}
+
+// GetBySlug mocks base method.
+func (m *MockRepository) GetBySlug(ctx context.Context, slug string) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySlug", ctx, slug)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySlug indicates an expected call of GetBySlug.
+func (mr *MockRepositoryMockRecorder) GetBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockRepository)(nil).GetBySlug), ctx, slug)
+}
+
+// IsExist mocks base method.
+func (m *MockRepository) IsExist(ctx context.Context, name, slug string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsExist", ctx, name, slug)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsExist indicates an expected call of IsExist.
+func (mr *MockRepositoryMockRecorder) IsExist(ctx, name, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExist", reflect.TypeOf((*MockRepository)(nil).IsExist), ctx, name, slug)
+}
+
+// IsSlugExist mocks base method.
+func (m *MockRepository) IsSlugExist(ctx context.Context, slug string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSlugExist", ctx, slug)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSlugExist indicates an expected call of IsSlugExist.
+func (mr *MockRepositoryMockRecorder) IsSlugExist(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSlugExist", reflect.TypeOf((*MockRepository)(nil).IsSlugExist), ctx, slug)
+}
+
+// List mocks base method.
+func (m *MockRepository) List(ctx context.Context, pq *utils.PaginationQuery) (*models.TagList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, pq)
+	ret0, _ := ret[0].(*models.TagList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepositoryMockRecorder) List(ctx, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, pq)
+}
+
+// ListByPostID mocks base method.
+func (m *MockRepository) ListByPostID(ctx context.Context, postID int) ([]*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByPostID", ctx, postID)
+	ret0, _ := ret[0].([]*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByPostID indicates an expected call of ListByPostID.
+func (mr *MockRepositoryMockRecorder) ListByPostID(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPostID", reflect.TypeOf((*MockRepository)(nil).ListByPostID), ctx, postID)
+}
+
+// ReplacePostTags mocks base method.
+func (m *MockRepository) ReplacePostTags(ctx context.Context, postID int, tagIDs []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplacePostTags", ctx, postID, tagIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplacePostTags indicates an expected call of ReplacePostTags.
+func (mr *MockRepositoryMockRecorder) ReplacePostTags(ctx, postID, tagIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplacePostTags", reflect.TypeOf((*MockRepository)(nil).ReplacePostTags), ctx, postID, tagIDs)
+}
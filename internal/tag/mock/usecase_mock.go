@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ductong169z/shorten-url/internal/models"
+	utils "github.com/ductong169z/shorten-url/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Cloud mocks base method.
+func (m *MockUseCase) Cloud(ctx context.Context, limit int) ([]*models.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cloud", ctx, limit)
+	ret0, _ := ret[0].([]*models.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cloud indicates an expected call of Cloud.
+func (mr *MockUseCaseMockRecorder) Cloud(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cloud", reflect.TypeOf((*MockUseCase)(nil).Cloud), ctx, limit)
+}
+
+// Create mocks base method.
+func (m *MockUseCase) Create(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, tag)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUseCaseMockRecorder) Create(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUseCase)(nil).Create), ctx, tag)
+}
+
+// List mocks base method.
+func (m *MockUseCase) List(ctx context.Context, pq *utils.PaginationQuery) (*models.TagList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, pq)
+	ret0, _ := ret[0].(*models.TagList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockUseCaseMockRecorder) List(ctx, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUseCase)(nil).List), ctx, pq)
+}
+
+// ListPostTags mocks base method.
+func (m *MockUseCase) ListPostTags(ctx context.Context, postSlug string) ([]*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostTags", ctx, postSlug)
+	ret0, _ := ret[0].([]*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPostTags indicates an expected call of ListPostTags.
+func (mr *MockUseCaseMockRecorder) ListPostTags(ctx, postSlug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostTags", reflect.TypeOf((*MockUseCase)(nil).ListPostTags), ctx, postSlug)
+}
+
+// ListPosts mocks base method.
+func (m *MockUseCase) ListPosts(ctx context.Context, slug string, pq *utils.PaginationQuery) (*models.PostList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPosts", ctx, slug, pq)
+	ret0, _ := ret[0].(*models.PostList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPosts indicates an expected call of ListPosts.
+func (mr *MockUseCaseMockRecorder) ListPosts(ctx, slug, pq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPosts", reflect.TypeOf((*MockUseCase)(nil).ListPosts), ctx, slug, pq)
+}
+
+// ReplacePostTags mocks base method.
+func (m *MockUseCase) ReplacePostTags(ctx context.Context, postID int, tagIDs []int) ([]*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplacePostTags", ctx, postID, tagIDs)
+	ret0, _ := ret[0].([]*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplacePostTags indicates an expected call of ReplacePostTags.
+func (mr *MockUseCaseMockRecorder) ReplacePostTags(ctx, postID, tagIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplacePostTags", reflect.TypeOf((*MockUseCase)(nil).ReplacePostTags), ctx, postID, tagIDs)
+}
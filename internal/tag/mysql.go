@@ -0,0 +1,21 @@
+//go:generate mockgen -source mysql.go -destination mock/mysql_repository_mock.go -package mock
+package tag
+
+import (
+	"context"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+type Repository interface {
+	Create(ctx context.Context, tag *models.Tag) (*models.Tag, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Tag, error)
+	GetByIDs(ctx context.Context, tagIDs []int) ([]*models.Tag, error)
+	List(ctx context.Context, pq *utils.PaginationQuery) (*models.TagList, error)
+	IsExist(ctx context.Context, name, slug string) (bool, error)
+	IsSlugExist(ctx context.Context, slug string) (bool, error)
+	ListByPostID(ctx context.Context, postID int) ([]*models.Tag, error)
+	ReplacePostTags(ctx context.Context, postID int, tagIDs []int) error
+	Cloud(ctx context.Context, limit int) ([]*models.TagCount, error)
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/tag"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// Tag Repository
+type repo struct {
+	db *gorm.DB
+}
+
+// Tag repository constructor
+func NewRepository(db *gorm.DB) tag.Repository {
+	return &repo{db: db}
+}
+
+// Create implements tag.Repository.
+func (r *repo) Create(ctx context.Context, t *models.Tag) (*models.Tag, error) {
+	if err := r.db.WithContext(ctx).Create(t).Error; err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// GetBySlug implements tag.Repository.
+func (r *repo) GetBySlug(ctx context.Context, slug string) (*models.Tag, error) {
+	var t models.Tag
+	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&t).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, tag.ErrTagNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// GetByIDs implements tag.Repository.
+func (r *repo) GetByIDs(ctx context.Context, tagIDs []int) ([]*models.Tag, error) {
+	tags := make([]*models.Tag, 0, len(tagIDs))
+	if len(tagIDs) == 0 {
+		return tags, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", tagIDs).Order("name ASC").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// List implements tag.Repository.
+func (r *repo) List(ctx context.Context, pq *utils.PaginationQuery) (*models.TagList, error) {
+	var totalCount int64
+	if err := r.db.WithContext(ctx).Model(&models.Tag{}).Count(&totalCount).Error; err != nil {
+		return nil, err
+	}
+
+	list := &models.TagList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, pq.GetSize()),
+		Page:       pq.GetPage(),
+		Size:       pq.GetSize(),
+		HasMore:    utils.GetHasMore(pq.GetPage(), totalCount, pq.GetSize()),
+		Tags:       make([]*models.Tag, 0),
+	}
+	if totalCount == 0 {
+		return list, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Order("name ASC").
+		Offset(pq.GetOffset()).
+		Limit(pq.GetLimit()).
+		Find(&list.Tags).Error; err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// IsExist implements tag.Repository.
+func (r *repo) IsExist(ctx context.Context, name, slug string) (bool, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.Tag{}).
+		Where("name = ? OR slug = ?", name, slug).
+		Count(&count).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// IsSlugExist implements tag.Repository.
+func (r *repo) IsSlugExist(ctx context.Context, slug string) (bool, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).
+		Model(&models.Tag{}).
+		Where("slug = ?", slug).
+		Count(&count).Error
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// ListByPostID implements tag.Repository.
+func (r *repo) ListByPostID(ctx context.Context, postID int) ([]*models.Tag, error) {
+	tags := make([]*models.Tag, 0)
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN post_tags ON post_tags.tag_id = tags.id").
+		Where("post_tags.post_id = ?", postID).
+		Order("tags.name ASC").
+		Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// ReplacePostTags implements tag.Repository.
+// The old set is removed and the new one inserted in a single transaction.
+func (r *repo) ReplacePostTags(ctx context.Context, postID int, tagIDs []int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("post_id = ?", postID).Delete(&models.PostTag{}).Error; err != nil {
+			return err
+		}
+		if len(tagIDs) == 0 {
+			return nil
+		}
+
+		rows := make([]models.PostTag, len(tagIDs))
+		for i, tagID := range tagIDs {
+			rows[i] = models.PostTag{PostID: postID, TagID: tagID}
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+// Cloud implements tag.Repository.
+func (r *repo) Cloud(ctx context.Context, limit int) ([]*models.TagCount, error) {
+	counts := make([]*models.TagCount, 0)
+	if err := r.db.WithContext(ctx).
+		Table("tags").
+		Select("tags.id, tags.name, tags.slug, COUNT(post_tags.post_id) AS post_count").
+		Joins("JOIN post_tags ON post_tags.tag_id = tags.id").
+		Joins("JOIN posts ON posts.id = post_tags.post_id AND posts.status = ?", models.PostStatusPublished).
+		Group("tags.id, tags.name, tags.slug").
+		Order("post_count DESC, tags.name ASC").
+		Limit(limit).
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
@@ -0,0 +1,18 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package tag
+
+import (
+	"context"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+type UseCase interface {
+	Create(ctx context.Context, tag *models.Tag) (*models.Tag, error)
+	List(ctx context.Context, pq *utils.PaginationQuery) (*models.TagList, error)
+	Cloud(ctx context.Context, limit int) ([]*models.TagCount, error)
+	ListPosts(ctx context.Context, slug string, pq *utils.PaginationQuery) (*models.PostList, error)
+	ListPostTags(ctx context.Context, postSlug string) ([]*models.Tag, error)
+	ReplacePostTags(ctx context.Context, postID int, tagIDs []int) ([]*models.Tag, error)
+}
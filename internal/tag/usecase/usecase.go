@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/internal/posts"
+	"github.com/ductong169z/shorten-url/internal/tag"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/slug"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+)
+
+const (
+	maxNameLength   = 50
+	maxSlugLength   = 60
+	maxTagsPerPost  = 20
+	defaultCloudMax = 50
+	maxCloudLimit   = 200
+)
+
+type usecase struct {
+	cfg       *config.Config
+	repo      tag.Repository
+	postRepo  posts.Repository
+	logger    logger.Logger
+	observers []posts.Observer
+}
+
+// Tag UseCase constructor; observers are notified as tags appear in feeds and sitemaps
+func NewUseCase(cfg *config.Config, repo tag.Repository, postRepo posts.Repository, logger logger.Logger, observers ...posts.Observer) tag.UseCase {
+	return &usecase{cfg: cfg, repo: repo, postRepo: postRepo, logger: logger, observers: observers}
+}
+
+// Create implements tag.UseCase.
+// Without a slug one is generated from the name.
+func (u *usecase) Create(ctx context.Context, t *models.Tag) (*models.Tag, error) {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" || utf8.RuneCountInString(t.Name) > maxNameLength {
+		return nil, tag.ErrInvalidName
+	}
+	generated := t.Slug == ""
+	if generated {
+		t.Slug = slug.Truncate(slug.MakeOr(t.Name, "tag"), maxSlugLength)
+	}
+	if !slug.IsValid(t.Slug, maxSlugLength) {
+		return nil, tag.ErrInvalidSlug
+	}
+	if generated {
+		unique, err := slug.Unique(t.Slug, maxSlugLength, func(s string) (bool, error) {
+			return u.repo.IsSlugExist(ctx, s)
+		})
+		if err != nil {
+			return nil, err
+		}
+		t.Slug = unique
+	}
+
+	exist, err := u.repo.IsExist(ctx, t.Name, t.Slug)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, tag.ErrTagAlreadyExists
+	}
+
+	created, err := u.repo.Create(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	u.notify(ctx)
+	return created, nil
+}
+
+// List implements tag.UseCase.
+func (u *usecase) List(ctx context.Context, pq *utils.PaginationQuery) (*models.TagList, error) {
+	return u.repo.List(ctx, pq)
+}
+
+// Cloud implements tag.UseCase.
+func (u *usecase) Cloud(ctx context.Context, limit int) ([]*models.TagCount, error) {
+	if limit <= 0 {
+		limit = defaultCloudMax
+	}
+	if limit > maxCloudLimit {
+		limit = maxCloudLimit
+	}
+	return u.repo.Cloud(ctx, limit)
+}
+
+// ListPosts implements tag.UseCase.
+func (u *usecase) ListPosts(ctx context.Context, slug string, pq *utils.PaginationQuery) (*models.PostList, error) {
+	t, err := u.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &models.PostFilter{
+		Status: models.PostStatusPublished,
+		TagID:  t.ID,
+	}
+	return u.postRepo.List(ctx, filter, pq)
+}
+
+// ListPostTags implements tag.UseCase.
+func (u *usecase) ListPostTags(ctx context.Context, postSlug string) ([]*models.Tag, error) {
+	post, err := u.postRepo.GetBySlug(ctx, postSlug)
+	if err != nil {
+		return nil, err
+	}
+	if post.Status != models.PostStatusPublished {
+		return nil, posts.ErrPostNotFound
+	}
+
+	return u.repo.ListByPostID(ctx, post.ID)
+}
+
+// ReplacePostTags implements tag.UseCase.
+func (u *usecase) ReplacePostTags(ctx context.Context, postID int, tagIDs []int) ([]*models.Tag, error) {
+	post, err := u.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateIsOwner(ctx, post.UserID, models.PermPostsEditAny, u.logger); err != nil {
+		return nil, err
+	}
+
+	tagIDs = uniqueIDs(tagIDs)
+	if len(tagIDs) > maxTagsPerPost {
+		return nil, tag.ErrTooManyTags
+	}
+
+	tags, err := u.repo.GetByIDs(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) != len(tagIDs) {
+		return nil, tag.ErrTagNotFound
+	}
+
+	if err := u.repo.ReplacePostTags(ctx, postID, tagIDs); err != nil {
+		return nil, err
+	}
+	u.notify(ctx)
+
+	return tags, nil
+}
+
+func (u *usecase) notify(ctx context.Context) {
+	for _, o := range u.observers {
+		o.PostsChanged(ctx)
+	}
+}
+
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]struct{}, len(ids))
+	result := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/models"
+	postMock "github.com/ductong169z/shorten-url/internal/posts/mock"
+	"github.com/ductong169z/shorten-url/internal/tag"
+	mock "github.com/ductong169z/shorten-url/internal/tag/mock"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/ductong169z/shorten-url/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	pkgErrors "github.com/ductong169z/shorten-url/pkg/errors"
+)
+
+func withUser(user *models.User) context.Context {
+	return context.WithValue(context.Background(), utils.UserCtxKey{}, user)
+}
+
+func TestUseCase_ReplacePostTags(t *testing.T) {
+	author := &models.User{ID: 7, Role: models.RoleUser}
+	other := &models.User{ID: 8, Role: models.RoleUser}
+	admin := &models.User{ID: 1, Role: models.RoleAdmin}
+	post := &models.Post{ID: 3, UserID: author.ID}
+
+	tcs := map[string]struct {
+		ctx        context.Context
+		tagIDs     []int
+		expLookup  []int
+		found      []*models.Tag
+		expReplace bool
+		expErr     error
+	}{
+		"success with duplicates removed": {
+			ctx:        withUser(author),
+			tagIDs:     []int{1, 2, 1},
+			expLookup:  []int{1, 2},
+			found:      []*models.Tag{{ID: 1}, {ID: 2}},
+			expReplace: true,
+		},
+		"admin may replace": {
+			ctx:        withUser(admin),
+			tagIDs:     []int{1},
+			expLookup:  []int{1},
+			found:      []*models.Tag{{ID: 1}},
+			expReplace: true,
+		},
+		"clear all tags": {
+			ctx:        withUser(author),
+			tagIDs:     nil,
+			expLookup:  []int{},
+			found:      []*models.Tag{},
+			expReplace: true,
+		},
+		"not the author": {
+			ctx:    withUser(other),
+			tagIDs: []int{1},
+			expErr: pkgErrors.Forbidden,
+		},
+		"unknown tag": {
+			ctx:       withUser(author),
+			tagIDs:    []int{1, 99},
+			expLookup: []int{1, 99},
+			found:     []*models.Tag{{ID: 1}},
+			expErr:    tag.ErrTagNotFound,
+		},
+		"too many tags": {
+			ctx:    withUser(author),
+			tagIDs: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21},
+			expErr: tag.ErrTooManyTags,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock.NewMockRepository(ctrl)
+			postRepo := postMock.NewMockRepository(ctrl)
+			observer := postMock.NewMockObserver(ctrl)
+			uc := NewUseCase(cfg, repo, postRepo, apiLogger, observer)
+
+			postRepo.EXPECT().GetByID(gomock.Any(), post.ID).Return(post, nil)
+			if tc.expLookup != nil {
+				repo.EXPECT().GetByIDs(gomock.Any(), tc.expLookup).Return(tc.found, nil)
+			}
+			if tc.expReplace {
+				repo.EXPECT().ReplacePostTags(gomock.Any(), post.ID, tc.expLookup).Return(nil)
+				observer.EXPECT().PostsChanged(gomock.Any())
+			}
+
+			tags, err := uc.ReplacePostTags(tc.ctx, post.ID, tc.tagIDs)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.found, tags)
+		})
+	}
+}
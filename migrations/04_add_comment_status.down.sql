@@ -0,0 +1,4 @@
+-- Drop moderation status from comments
+ALTER TABLE comments
+    DROP INDEX idx_comments_status_created_at,
+    DROP COLUMN status;
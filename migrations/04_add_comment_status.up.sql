@@ -0,0 +1,7 @@
+-- Add moderation status to comments
+ALTER TABLE comments
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'pending' AFTER content,
+    ADD INDEX idx_comments_status_created_at (status, created_at);
+
+-- Comments written before moderation existed stay visible
+UPDATE comments SET status = 'approved';
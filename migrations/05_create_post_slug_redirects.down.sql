@@ -0,0 +1,2 @@
+-- Drop table: post_slug_redirects
+DROP TABLE IF EXISTS post_slug_redirects;
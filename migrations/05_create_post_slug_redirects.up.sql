@@ -0,0 +1,9 @@
+-- Create table: post_slug_redirects
+CREATE TABLE IF NOT EXISTS post_slug_redirects (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    post_id BIGINT UNSIGNED NOT NULL,
+    old_slug VARCHAR(280) NOT NULL UNIQUE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_post_slug_redirects_post_id (post_id),
+    CONSTRAINT fk_post_slug_redirects_post_id FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,2 @@
+-- Drop table: post_revisions
+DROP TABLE IF EXISTS post_revisions;
@@ -0,0 +1,16 @@
+-- Create table: post_revisions
+CREATE TABLE IF NOT EXISTS post_revisions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    post_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    content LONGTEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_post_revisions_post_id (post_id, id),
+    CONSTRAINT fk_post_revisions_post_id FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE,
+    CONSTRAINT fk_post_revisions_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Existing posts start their history with the current content
+INSERT INTO post_revisions (post_id, user_id, title, content, created_at)
+SELECT id, user_id, title, content, updated_at FROM posts;
@@ -0,0 +1,2 @@
+-- Drop table: media
+DROP TABLE IF EXISTS media;
@@ -0,0 +1,18 @@
+-- Create table: media
+CREATE TABLE IF NOT EXISTS media (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    post_id BIGINT UNSIGNED NULL,
+    storage_key VARCHAR(255) NOT NULL UNIQUE,
+    thumbnail_key VARCHAR(255) NULL,
+    filename VARCHAR(255) NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    size BIGINT UNSIGNED NOT NULL,
+    width INT UNSIGNED NULL,
+    height INT UNSIGNED NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_media_user_id (user_id),
+    INDEX idx_media_post_id (post_id),
+    CONSTRAINT fk_media_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_media_post_id FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
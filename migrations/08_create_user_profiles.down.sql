@@ -0,0 +1,2 @@
+-- Drop table: user_profiles
+DROP TABLE IF EXISTS user_profiles;
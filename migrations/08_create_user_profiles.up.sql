@@ -0,0 +1,11 @@
+-- Create table: user_profiles
+CREATE TABLE IF NOT EXISTS user_profiles (
+    user_id BIGINT UNSIGNED PRIMARY KEY,
+    display_name VARCHAR(100) NOT NULL DEFAULT '',
+    bio TEXT NOT NULL,
+    avatar_url VARCHAR(500) NOT NULL DEFAULT '',
+    social_links JSON NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_profiles_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
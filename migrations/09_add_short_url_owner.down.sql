@@ -0,0 +1,5 @@
+-- Drop owner from short_urls
+ALTER TABLE short_urls
+    DROP FOREIGN KEY fk_short_urls_user_id,
+    DROP INDEX idx_short_urls_user_id,
+    DROP COLUMN user_id;
@@ -0,0 +1,5 @@
+-- Add owner to short_urls; anonymous short URLs keep a NULL owner
+ALTER TABLE short_urls
+    ADD COLUMN user_id BIGINT UNSIGNED NULL AFTER id,
+    ADD INDEX idx_short_urls_user_id (user_id),
+    ADD CONSTRAINT fk_short_urls_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+-- Drop rotation families from refresh_tokens
+ALTER TABLE refresh_tokens
+    DROP INDEX idx_refresh_tokens_family_id,
+    DROP COLUMN family_id;
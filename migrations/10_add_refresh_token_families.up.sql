@@ -0,0 +1,6 @@
+-- Group refresh tokens into rotation families; each existing token starts its own family
+ALTER TABLE refresh_tokens
+    ADD COLUMN family_id CHAR(36) NOT NULL DEFAULT '' AFTER token,
+    ADD INDEX idx_refresh_tokens_family_id (family_id);
+
+UPDATE refresh_tokens SET family_id = UUID() WHERE family_id = '';
@@ -0,0 +1,6 @@
+-- Drop device information from refresh_tokens
+ALTER TABLE refresh_tokens
+    DROP INDEX idx_refresh_tokens_user_id_revoked,
+    DROP COLUMN last_used_at,
+    DROP COLUMN ip,
+    DROP COLUMN user_agent;
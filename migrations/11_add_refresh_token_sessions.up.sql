@@ -0,0 +1,8 @@
+-- Record the device of each refresh token so users can see their sessions
+ALTER TABLE refresh_tokens
+    ADD COLUMN user_agent VARCHAR(255) NOT NULL DEFAULT '' AFTER family_id,
+    ADD COLUMN ip VARCHAR(45) NOT NULL DEFAULT '' AFTER user_agent,
+    ADD COLUMN last_used_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP AFTER ip,
+    ADD INDEX idx_refresh_tokens_user_id_revoked (user_id, revoked);
+
+UPDATE refresh_tokens SET last_used_at = created_at;
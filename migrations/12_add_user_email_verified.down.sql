@@ -0,0 +1,3 @@
+-- Drop the email verification flag from users
+ALTER TABLE users
+    DROP COLUMN email_verified;
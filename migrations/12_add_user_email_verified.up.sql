@@ -0,0 +1,6 @@
+-- Track whether a user has proven control of their email address
+ALTER TABLE users
+    ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT FALSE AFTER email;
+
+-- Accounts created before verification existed are trusted as they are
+UPDATE users SET email_verified = TRUE;
@@ -0,0 +1,2 @@
+-- Drop table: password_reset_tokens
+DROP TABLE IF EXISTS password_reset_tokens;
@@ -0,0 +1,3 @@
+-- Drop tables: user_recovery_codes, user_totp
+DROP TABLE IF EXISTS user_recovery_codes;
+DROP TABLE IF EXISTS user_totp;
@@ -0,0 +1,22 @@
+-- TOTP two-factor authentication; confirmed_at stays NULL until enrollment is confirmed.
+-- The secret is stored encrypted with TOTP_ENCRYPTION_KEY (base64 of nonce and AES-GCM ciphertext)
+CREATE TABLE IF NOT EXISTS user_totp (
+    user_id BIGINT UNSIGNED PRIMARY KEY,
+    secret VARCHAR(128) NOT NULL,
+    confirmed_at DATETIME NULL DEFAULT NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_totp_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Single-use codes for when the authenticator is lost; only their SHA-256 is stored
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    code_hash CHAR(64) NOT NULL,
+    used_at DATETIME NULL DEFAULT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_user_recovery_codes_user_id_code_hash (user_id, code_hash),
+    CONSTRAINT fk_user_recovery_codes_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/ductong169z/shorten-url/config"
+	"github.com/go-redis/redis/v8"
 )
 
 const (
@@ -12,11 +13,16 @@ const (
 	redisStandaloneMode = "standalone"
 )
 
+// Nil is returned by Get when the key does not exist
+const Nil = redis.Nil
+
 type (
 	Client interface {
 		Get(ctx context.Context, key string) ([]byte, error)
 		Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 		Del(ctx context.Context, keys ...string) error
+		// Incr increments the counter at key and (re)sets its expiration
+		Incr(ctx context.Context, key string, expiration time.Duration) (int64, error)
 		Close() error
 		Ping(ctx context.Context) error
 	}
@@ -49,6 +49,19 @@ func (r *RedisClient) Del(ctx context.Context, keys ...string) error {
 	return r.rdbClient.Del(ctx, keys...).Err()
 }
 
+func (r *RedisClient) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := r.rdbClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, expiration)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
 func (r *RedisClient) Close() error {
 	return r.rdbClient.Close()
 }
@@ -48,6 +48,19 @@ func (r *RedisCluster) Del(ctx context.Context, keys ...string) error {
 	return r.rdbCluster.Del(ctx, keys...).Err()
 }
 
+func (r *RedisCluster) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := r.rdbCluster.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, expiration)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
 func (r *RedisCluster) Close() error {
 	return r.rdbCluster.Close()
 }
@@ -0,0 +1,170 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// contextLines is the number of unchanged lines shown around each change
+	contextLines = 3
+	// maxTableCells bounds the LCS table (4 bytes per cell); larger changes are
+	// shown as the old lines replaced by the new ones
+	maxTableCells = 4 << 20
+)
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+type op struct {
+	kind opKind
+	line string
+	// a and b are the 1-based line numbers in from and to before this op
+	a, b int
+}
+
+// Unified returns a line-based unified diff turning from into to, with
+// fromLabel and toLabel in the --- and +++ headers. Identical inputs give "".
+func Unified(from, to, fromLabel, toLabel string) string {
+	ops := lineOps(splitLines(from), splitLines(to))
+
+	var b strings.Builder
+	for _, h := range hunks(ops) {
+		if b.Len() == 0 {
+			fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromLabel, toLabel)
+		}
+		writeHunk(&b, ops[h[0]:h[1]])
+	}
+	return b.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// lineOps computes an edit script from the longest common subsequence of the
+// lines left after trimming the common prefix and suffix. When those lines would
+// need more than maxTableCells, they are all deleted and inserted instead.
+func lineOps(a, b []string) []op {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	replace := len(midA) > 0 && len(midB) > maxTableCells/len(midA)
+
+	// lcs[i][j] is the LCS length of midA[i:] and midB[j:]
+	var lcs [][]int32
+	if !replace {
+		lcs = make([][]int32, len(midA)+1)
+		for i := range lcs {
+			lcs[i] = make([]int32, len(midB)+1)
+		}
+	}
+	for i := len(midA) - 1; i >= 0 && !replace; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			switch {
+			case midA[i] == midB[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]op, 0, len(a)+len(b))
+	la, lb := 1, 1
+	emit := func(kind opKind, line string) {
+		ops = append(ops, op{kind: kind, line: line, a: la, b: lb})
+		if kind != opInsert {
+			la++
+		}
+		if kind != opDelete {
+			lb++
+		}
+	}
+
+	for _, line := range a[:prefix] {
+		emit(opEqual, line)
+	}
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case !replace && i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			emit(opEqual, midA[i])
+			i++
+			j++
+		case j == len(midB) || (i < len(midA) && (replace || lcs[i+1][j] >= lcs[i][j+1])):
+			emit(opDelete, midA[i])
+			i++
+		default:
+			emit(opInsert, midB[j])
+			j++
+		}
+	}
+	for _, line := range a[len(a)-suffix:] {
+		emit(opEqual, line)
+	}
+	return ops
+}
+
+// hunks returns [start, end) op ranges covering every change with its
+// context; changes closer than twice the context share a hunk.
+func hunks(ops []op) [][2]int {
+	var result [][2]int
+	for i, o := range ops {
+		if o.kind == opEqual {
+			continue
+		}
+		start, end := max(i-contextLines, 0), min(i+1+contextLines, len(ops))
+		if n := len(result); n > 0 && start <= result[n-1][1] {
+			result[n-1][1] = end
+			continue
+		}
+		result = append(result, [2]int{start, end})
+	}
+	return result
+}
+
+func writeHunk(b *strings.Builder, ops []op) {
+	var countA, countB int
+	for _, o := range ops {
+		if o.kind != opInsert {
+			countA++
+		}
+		if o.kind != opDelete {
+			countB++
+		}
+	}
+	fmt.Fprintf(b, "@@ -%s +%s @@\n", hunkRange(ops[0].a, countA), hunkRange(ops[0].b, countB))
+	for _, o := range ops {
+		b.WriteByte(byte(o.kind))
+		b.WriteString(o.line)
+		b.WriteByte('\n')
+	}
+}
+
+// hunkRange formats a hunk side; an empty side names the line before it
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
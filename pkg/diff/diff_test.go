@@ -0,0 +1,71 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnified(t *testing.T) {
+	tcs := map[string]struct {
+		from string
+		to   string
+		exp  string
+	}{
+		"identical": {
+			from: "a\nb\n",
+			to:   "a\nb\n",
+			exp:  "",
+		},
+		"changed line": {
+			from: "a\nb\nc\n",
+			to:   "a\nB\nc\n",
+			exp:  "--- r1\n+++ r2\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		"append to empty": {
+			from: "",
+			to:   "hello\n",
+			exp:  "--- r1\n+++ r2\n@@ -0,0 +1 @@\n+hello\n",
+		},
+		"separate hunks": {
+			from: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			to:   "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			exp: "--- r1\n+++ r2\n" +
+				"@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+				"@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+		},
+		"insert and delete": {
+			from: "# Title\nintro\nold paragraph\nend\n",
+			to:   "# Title\nintro\nnew paragraph\nmore\nend\n",
+			exp:  "--- r1\n+++ r2\n@@ -1,4 +1,5 @@\n # Title\n intro\n-old paragraph\n+new paragraph\n+more\n end\n",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.exp, Unified(tc.from, tc.to, "r1", "r2"))
+		})
+	}
+}
+
+func TestUnified_LargeChange(t *testing.T) {
+	// 2500 x 2500 changed lines exceed maxTableCells: the shared line in the
+	// middle is no longer matched and the whole block is replaced
+	var from, to strings.Builder
+	for i := 0; i < 2500; i++ {
+		if i == 1250 {
+			from.WriteString("shared\n")
+			to.WriteString("shared\n")
+		}
+		from.WriteString("a" + strconv.Itoa(i) + "\n")
+		to.WriteString("b" + strconv.Itoa(i) + "\n")
+	}
+
+	got := Unified(from.String(), to.String(), "r1", "r2")
+	assert.True(t, strings.HasPrefix(got, "--- r1\n+++ r2\n@@ -1,2501 +1,2501 @@\n-a0\n"))
+	assert.Contains(t, got, "\n-shared\n")
+	assert.Contains(t, got, "\n+shared\n")
+	assert.NotContains(t, got, "\n shared\n")
+}
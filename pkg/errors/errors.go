@@ -17,17 +17,18 @@ const (
 )
 
 var (
-	BadRequest          = errors.New("Bad request")
-	NotFound            = errors.New("Not Found")
-	Unauthorized        = errors.New("Unauthorized")
-	Forbidden           = errors.New("Forbidden")
-	PermissionDenied    = errors.New("Permission Denied")
-	NotRequiredFields   = errors.New("No such required fields")
-	BadQueryParams      = errors.New("Invalid query params")
-	InternalServerError = errors.New("Internal Server Error")
-	RequestTimeoutError = errors.New("Request Timeout")
-	InvalidJWTToken     = errors.New("Invalid JWT token")
-	InvalidJWTClaims    = errors.New("Invalid JWT claims")
+	BadRequest               = errors.New("Bad request")
+	NotFound                 = errors.New("Not Found")
+	Unauthorized             = errors.New("Unauthorized")
+	Forbidden                = errors.New("Forbidden")
+	PermissionDenied         = errors.New("Permission Denied")
+	NotRequiredFields        = errors.New("No such required fields")
+	BadQueryParams           = errors.New("Invalid query params")
+	InternalServerError      = errors.New("Internal Server Error")
+	RequestTimeoutError      = errors.New("Request Timeout")
+	InvalidJWTToken          = errors.New("Invalid JWT token")
+	InvalidJWTClaims         = errors.New("Invalid JWT claims")
+	InvalidVerificationToken = errors.New("Invalid verification token")
 )
 
 // Error struct
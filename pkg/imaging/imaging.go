@@ -0,0 +1,46 @@
+package imaging
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Thumbnail scales src down to maxWidth keeping its aspect ratio, averaging
+// the source pixels that fall into each destination pixel. Images that are
+// already narrow enough are returned unchanged.
+func Thumbnail(src image.Image, maxWidth int) image.Image {
+	b := src.Bounds()
+	if maxWidth <= 0 || b.Dx() <= maxWidth {
+		return src
+	}
+	width := maxWidth
+	height := max(b.Dy()*width/b.Dx(), 1)
+
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*b.Dy()/height, max((y+1)*b.Dy()/height, y*b.Dy()/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*b.Dx()/width, max((x+1)*b.Dx()/width, x*b.Dx()/width+1)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					for c := 0; c < 4; c++ {
+						sum[c] += int(row[sx*4+c])
+					}
+				}
+			}
+
+			n := (y1 - y0) * (x1 - x0)
+			i := dst.PixOffset(x, y)
+			for c := 0; c < 4; c++ {
+				dst.Pix[i+c] = uint8(sum[c] / n)
+			}
+		}
+	}
+	return dst
+}
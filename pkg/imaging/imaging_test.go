@@ -0,0 +1,30 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThumbnail(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 400; x++ {
+			if x < 200 {
+				src.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				src.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+
+	thumb := Thumbnail(src, 100)
+	assert.Equal(t, image.Rect(0, 0, 100, 50), thumb.Bounds())
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, thumb.At(10, 10))
+	assert.Equal(t, color.RGBA{B: 255, A: 255}, thumb.At(90, 40))
+
+	small := image.NewRGBA(image.Rect(0, 0, 80, 60))
+	assert.Same(t, small, Thumbnail(small, 100))
+}
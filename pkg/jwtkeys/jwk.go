@@ -0,0 +1,65 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+// JSONWebKey is the public half of a signing key as described in RFC 7517
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	// RSA modulus and exponent
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 curve and public key
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JSONWebKeySet is the document served at /.well-known/jwks.json
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS returns the public keys verifiers need: every key that is not retired, including
+// scheduled ones so that verifiers can cache them before they start signing. HS256 keys are
+// never published.
+func (s *KeySet) JWKS() *JSONWebKeySet {
+	now := s.now()
+	set := &JSONWebKeySet{Keys: []JSONWebKey{}}
+	for i, key := range s.keys {
+		if s.retired(i, now) {
+			continue
+		}
+		if jwk, ok := key.jwk(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+func (k *Key) jwk() (JSONWebKey, bool) {
+	jwk := JSONWebKey{Use: "sig", Algorithm: k.Algorithm(), KeyID: k.ID}
+	switch public := k.verifyKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64URL(public.N.Bytes())
+		jwk.E = base64URL(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64URL(public)
+	default:
+		return JSONWebKey{}, false
+	}
+	return jwk, true
+}
+
+func base64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
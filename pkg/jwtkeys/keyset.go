@@ -0,0 +1,181 @@
+// Package jwtkeys manages the keys access tokens are signed with. Each key is identified by
+// the "kid" header of the tokens it signs and starts signing at its ActiveFrom time, so a
+// rotation is scheduled by adding the next key ahead of time. A replaced key stays valid for
+// verification until the last token it signed has expired.
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// minRSABits is the smallest RSA modulus accepted for RS256
+const minRSABits = 2048
+
+var (
+	// ErrNoActiveKey is returned when signing before the first key becomes active
+	ErrNoActiveKey = errors.New("no active signing key")
+	// ErrUnknownKey is returned for tokens whose kid is unknown or retired
+	ErrUnknownKey = errors.New("unknown signing key")
+	// ErrUnsupportedKey is returned for keys that are neither RSA nor Ed25519
+	ErrUnsupportedKey = errors.New("unsupported signing key")
+)
+
+// Key is one signing key
+type Key struct {
+	ID         string
+	ActiveFrom time.Time
+
+	method     jwt.SigningMethod
+	signingKey interface{}
+	verifyKey  interface{}
+}
+
+// NewKey creates an RS256 key from an *rsa.PrivateKey or an EdDSA key from an ed25519.PrivateKey
+func NewKey(id string, private crypto.PrivateKey, activeFrom time.Time) (*Key, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: empty kid", ErrUnsupportedKey)
+	}
+
+	key := &Key{ID: id, ActiveFrom: activeFrom, signingKey: private}
+	switch private := private.(type) {
+	case *rsa.PrivateKey:
+		if private.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("%w: RSA key %s is shorter than %d bits", ErrUnsupportedKey, id, minRSABits)
+		}
+		key.method, key.verifyKey = jwt.SigningMethodRS256, &private.PublicKey
+	case ed25519.PrivateKey:
+		key.method, key.verifyKey = jwt.SigningMethodEdDSA, private.Public()
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, private)
+	}
+	return key, nil
+}
+
+// newHMACKey creates the HS256 key tokens without a kid are signed with
+func newHMACKey(secret string) *Key {
+	return &Key{method: jwt.SigningMethodHS256, signingKey: []byte(secret), verifyKey: []byte(secret)}
+}
+
+// Algorithm returns the "alg" of the tokens the key signs
+func (k *Key) Algorithm() string {
+	return k.method.Alg()
+}
+
+// KeySet holds the signing keys ordered by the time they become active
+type KeySet struct {
+	keys []*Key
+	// legacy verifies HS256 tokens without a kid, signed before the keys were introduced
+	legacy *Key
+	// tokenLifetime is how long a replaced key has to stay valid for verification
+	tokenLifetime time.Duration
+	now           func() time.Time
+}
+
+// NewKeySet creates a key set from asymmetric keys; tokens signed by a key are accepted for
+// tokenLifetime after the next key became active
+func NewKeySet(keys []*Key, tokenLifetime time.Duration) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("jwtkeys: no keys")
+	}
+
+	sorted := make([]*Key, len(keys))
+	copy(sorted, keys)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ActiveFrom.Before(sorted[j].ActiveFrom)
+	})
+
+	seen := make(map[string]bool, len(sorted))
+	for _, key := range sorted {
+		if seen[key.ID] {
+			return nil, fmt.Errorf("jwtkeys: duplicate kid %q", key.ID)
+		}
+		seen[key.ID] = true
+	}
+
+	return &KeySet{keys: sorted, tokenLifetime: tokenLifetime, now: time.Now}, nil
+}
+
+// NewHMAC creates a key set signing HS256 tokens without a kid, as before asymmetric keys existed
+func NewHMAC(secret string) *KeySet {
+	return &KeySet{keys: []*Key{newHMACKey(secret)}, now: time.Now}
+}
+
+// AcceptHS256 makes the key set also verify HS256 tokens without a kid. It is meant for the
+// switch to asymmetric keys, until the HS256 tokens issued before it have expired.
+func (s *KeySet) AcceptHS256(secret string) {
+	s.legacy = newHMACKey(secret)
+}
+
+// SigningKey returns the most recently activated key
+func (s *KeySet) SigningKey() (*Key, error) {
+	now := s.now()
+	for i := len(s.keys) - 1; i >= 0; i-- {
+		if !s.keys[i].ActiveFrom.After(now) {
+			return s.keys[i], nil
+		}
+	}
+	return nil, ErrNoActiveKey
+}
+
+// Sign signs claims with the current signing key and sets its kid header
+func (s *KeySet) Sign(claims jwt.Claims) (string, error) {
+	key, err := s.SigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	return token.SignedString(key.signingKey)
+}
+
+// Keyfunc selects the verification key by the kid header of token, for jwt.Parse.
+// The algorithm of the token has to match the key so an RSA public key is never used as an HMAC secret.
+func (s *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key := s.verificationKey(kid)
+	if key == nil {
+		return nil, ErrUnknownKey
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %v for key %q", token.Header["alg"], kid)
+	}
+	return key.verifyKey, nil
+}
+
+// verificationKey returns the key with the given kid unless it is retired
+func (s *KeySet) verificationKey(kid string) *Key {
+	now := s.now()
+	for i, key := range s.keys {
+		if key.ID != kid {
+			continue
+		}
+		if s.retired(i, now) {
+			return nil
+		}
+		return key
+	}
+	if kid == "" {
+		return s.legacy
+	}
+	return nil
+}
+
+// retired reports whether every token signed by the i-th key has expired
+func (s *KeySet) retired(i int, now time.Time) bool {
+	if i == len(s.keys)-1 {
+		return false
+	}
+	return now.After(s.keys[i+1].ActiveFrom.Add(s.tokenLifetime))
+}
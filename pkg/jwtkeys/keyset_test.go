@@ -0,0 +1,259 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tokenLifetime = time.Hour
+
+func newRSAKey(t *testing.T, id string, activeFrom time.Time) *Key {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	key, err := NewKey(id, private, activeFrom)
+	require.NoError(t, err)
+	return key
+}
+
+func newEd25519Key(t *testing.T, id string, activeFrom time.Time) *Key {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := NewKey(id, private, activeFrom)
+	require.NoError(t, err)
+	return key
+}
+
+// parse verifies a token the way the auth middleware does
+func parse(set *KeySet, token string) (*jwt.Token, error) {
+	return jwt.Parse(token, set.Keyfunc)
+}
+
+// assertUnknownKey checks the Keyfunc error, which jwt v3 does not expose to errors.Is
+func assertUnknownKey(t *testing.T, err error) {
+	t.Helper()
+	var validationErr *jwt.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, ErrUnknownKey, validationErr.Inner)
+}
+
+func kidOf(t *testing.T, token string) string {
+	t.Helper()
+	parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	require.NoError(t, err)
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
+func TestKeySet_SignAndVerify(t *testing.T) {
+	tcs := map[string]func(t *testing.T) *Key{
+		"RS256": func(t *testing.T) *Key { return newRSAKey(t, "rsa-1", time.Time{}) },
+		"EdDSA": func(t *testing.T) *Key { return newEd25519Key(t, "ed-1", time.Time{}) },
+	}
+
+	for alg, newKey := range tcs {
+		t.Run(alg, func(t *testing.T) {
+			key := newKey(t)
+			set, err := NewKeySet([]*Key{key}, tokenLifetime)
+			require.NoError(t, err)
+
+			token, err := set.Sign(jwt.MapClaims{"id": 7})
+			require.NoError(t, err)
+			assert.Equal(t, key.ID, kidOf(t, token))
+
+			parsed, err := parse(set, token)
+			require.NoError(t, err)
+			assert.True(t, parsed.Valid)
+			assert.Equal(t, alg, parsed.Method.Alg())
+		})
+	}
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	now := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	old := newRSAKey(t, "2024-01", now.Add(-90*24*time.Hour))
+	current := newEd25519Key(t, "2024-04", now.Add(-time.Minute))
+	next := newRSAKey(t, "2024-07", now.Add(90*24*time.Hour))
+
+	set, err := NewKeySet([]*Key{next, old, current}, tokenLifetime)
+	require.NoError(t, err)
+	set.now = func() time.Time { return now }
+
+	signing, err := set.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, "2024-04", signing.ID)
+
+	// Tokens of the replaced key stay valid for one token lifetime
+	oldSet, err := NewKeySet([]*Key{old}, tokenLifetime)
+	require.NoError(t, err)
+	oldToken, err := oldSet.Sign(jwt.MapClaims{"id": 7})
+	require.NoError(t, err)
+	_, err = parse(set, oldToken)
+	assert.NoError(t, err)
+
+	set.now = func() time.Time { return now.Add(tokenLifetime) }
+	_, err = parse(set, oldToken)
+	assertUnknownKey(t, err)
+	assert.NotContains(t, kidsOf(set.JWKS()), "2024-01")
+
+	// The next key is published before it starts signing
+	assert.Equal(t, []string{"2024-04", "2024-07"}, kidsOf(set.JWKS()))
+
+	set.now = func() time.Time { return next.ActiveFrom }
+	signing, err = set.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, "2024-07", signing.ID)
+}
+
+func TestKeySet_NoActiveKey(t *testing.T) {
+	set, err := NewKeySet([]*Key{newEd25519Key(t, "later", time.Now().Add(time.Hour))}, tokenLifetime)
+	require.NoError(t, err)
+
+	_, err = set.Sign(jwt.MapClaims{})
+	assert.ErrorIs(t, err, ErrNoActiveKey)
+}
+
+func TestKeySet_RejectsForeignTokens(t *testing.T) {
+	key := newRSAKey(t, "rsa-1", time.Time{})
+	set, err := NewKeySet([]*Key{key}, tokenLifetime)
+	require.NoError(t, err)
+
+	// An HS256 token using the public key as secret must not pass for the RSA key
+	publicDER, err := x509.MarshalPKIXPublicKey(key.verifyKey)
+	require.NoError(t, err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"id": 1})
+	forged.Header["kid"] = "rsa-1"
+	forgedToken, err := forged.SignedString(publicDER)
+	require.NoError(t, err)
+	_, err = parse(set, forgedToken)
+	assert.Error(t, err)
+
+	// Tokens of unknown keys and legacy HS256 tokens are rejected
+	otherSet, err := NewKeySet([]*Key{newRSAKey(t, "other", time.Time{})}, tokenLifetime)
+	require.NoError(t, err)
+	otherToken, err := otherSet.Sign(jwt.MapClaims{"id": 1})
+	require.NoError(t, err)
+	_, err = parse(set, otherToken)
+	assertUnknownKey(t, err)
+
+	legacyToken, err := NewHMAC("secret").Sign(jwt.MapClaims{"id": 1})
+	require.NoError(t, err)
+	_, err = parse(set, legacyToken)
+	assertUnknownKey(t, err)
+
+	set.AcceptHS256("secret")
+	_, err = parse(set, legacyToken)
+	assert.NoError(t, err)
+}
+
+func TestNewHMAC(t *testing.T) {
+	set := NewHMAC("secret")
+
+	token, err := set.Sign(jwt.MapClaims{"id": 1})
+	require.NoError(t, err)
+	assert.Empty(t, kidOf(t, token))
+
+	_, err = parse(set, token)
+	assert.NoError(t, err)
+	assert.Empty(t, set.JWKS().Keys)
+}
+
+func TestKeySet_JWKS(t *testing.T) {
+	rsaKey := newRSAKey(t, "rsa-1", time.Time{})
+	edKey := newEd25519Key(t, "ed-1", time.Now().Add(-time.Minute))
+	set, err := NewKeySet([]*Key{rsaKey, edKey}, tokenLifetime)
+	require.NoError(t, err)
+
+	keys := set.JWKS().Keys
+	require.Len(t, keys, 2)
+
+	rsaJWK := keys[0]
+	assert.Equal(t, JSONWebKey{KeyType: "RSA", Use: "sig", Algorithm: "RS256", KeyID: "rsa-1", N: rsaJWK.N, E: "AQAB"}, rsaJWK)
+	n, err := base64.RawURLEncoding.DecodeString(rsaJWK.N)
+	require.NoError(t, err)
+	assert.Equal(t, rsaKey.verifyKey.(*rsa.PublicKey).N.Bytes(), n)
+
+	edJWK := keys[1]
+	assert.Equal(t, "OKP", edJWK.KeyType)
+	assert.Equal(t, "Ed25519", edJWK.Curve)
+	assert.Equal(t, "EdDSA", edJWK.Algorithm)
+	x, err := base64.RawURLEncoding.DecodeString(edJWK.X)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(edKey.verifyKey.(ed25519.PublicKey)), x)
+}
+
+func TestNewKeySet_DuplicateKid(t *testing.T) {
+	_, err := NewKeySet([]*Key{newEd25519Key(t, "a", time.Time{}), newEd25519Key(t, "a", time.Now())}, tokenLifetime)
+	assert.Error(t, err)
+}
+
+func TestNewKey_RejectsWeakRSA(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	_, err = NewKey("weak", private, time.Time{})
+	assert.ErrorIs(t, err, ErrUnsupportedKey)
+}
+
+func TestNew(t *testing.T) {
+	dir := t.TempDir()
+
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "2024-01.pem"), "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaPrivate))
+
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edDER, err := x509.MarshalPKCS8PrivateKey(edPrivate)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "2024-04.pem"), "PRIVATE KEY", edDER)
+
+	manifestPath := filepath.Join(dir, "keys.json")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`{"keys": [
+		{"kid": "2024-01", "private_key": "2024-01.pem"},
+		{"kid": "2024-04", "private_key": "2024-04.pem", "active_from": "2024-04-01T00:00:00Z"}
+	]}`), 0o600))
+
+	set, err := New(&config.JWTConfig{KeysFile: manifestPath, AcceptHS256: true}, "secret", tokenLifetime)
+	require.NoError(t, err)
+
+	signing, err := set.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, "2024-04", signing.ID)
+	assert.Equal(t, "EdDSA", signing.Algorithm())
+
+	legacyToken, err := NewHMAC("secret").Sign(jwt.MapClaims{"id": 1})
+	require.NoError(t, err)
+	_, err = parse(set, legacyToken)
+	assert.NoError(t, err)
+
+	_, err = New(&config.JWTConfig{KeysFile: filepath.Join(dir, "missing.json")}, "secret", tokenLifetime)
+	assert.Error(t, err)
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func kidsOf(set *JSONWebKeySet) []string {
+	kids := make([]string, 0, len(set.Keys))
+	for _, key := range set.Keys {
+		kids = append(kids, key.KeyID)
+	}
+	return kids
+}
@@ -0,0 +1,108 @@
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ductong169z/shorten-url/config"
+)
+
+// manifest is the JSON file listing the signing keys, for example
+//
+//	{"keys": [
+//	  {"kid": "2024-01", "private_key": "2024-01.pem"},
+//	  {"kid": "2024-04", "private_key": "2024-04.pem", "active_from": "2024-04-01T00:00:00Z"}
+//	]}
+//
+// Private keys are PEM encoded PKCS #8 (RSA or Ed25519) or PKCS #1 (RSA) files, relative
+// paths are resolved against the directory of the manifest.
+type manifest struct {
+	Keys []struct {
+		ID         string    `json:"kid"`
+		PrivateKey string    `json:"private_key"`
+		ActiveFrom time.Time `json:"active_from"`
+	} `json:"keys"`
+}
+
+// New creates the key set configured by cfg. Without a keys file tokens are signed with HS256
+// and secret, like before asymmetric keys existed.
+func New(cfg *config.JWTConfig, secret string, tokenLifetime time.Duration) (*KeySet, error) {
+	if cfg.KeysFile == "" {
+		return NewHMAC(secret), nil
+	}
+
+	keys, err := LoadFile(cfg.KeysFile)
+	if err != nil {
+		return nil, err
+	}
+	set, err := NewKeySet(keys, tokenLifetime)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.AcceptHS256 {
+		set.AcceptHS256(secret)
+	}
+	return set, nil
+}
+
+// LoadFile reads the keys listed by a manifest file
+func LoadFile(path string) ([]*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("jwtkeys: read manifest: %w", err)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("jwtkeys: parse manifest %s: %w", path, err)
+	}
+
+	keys := make([]*Key, 0, len(m.Keys))
+	for _, entry := range m.Keys {
+		keyPath := entry.PrivateKey
+		if !filepath.IsAbs(keyPath) {
+			keyPath = filepath.Join(filepath.Dir(path), keyPath)
+		}
+		private, err := readPrivateKey(keyPath)
+		if err != nil {
+			return nil, err
+		}
+		key, err := NewKey(entry.ID, private, entry.ActiveFrom)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func readPrivateKey(path string) (crypto.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("jwtkeys: read private key: %w", err)
+	}
+	return ParsePrivateKey(data)
+}
+
+// ParsePrivateKey decodes a PEM encoded PKCS #8 or PKCS #1 private key
+func ParsePrivateKey(data []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM data", ErrUnsupportedKey)
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%w: PEM type %q", ErrUnsupportedKey, block.Type)
+	}
+}
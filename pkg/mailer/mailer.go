@@ -0,0 +1,56 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ductong169z/shorten-url/config"
+)
+
+const (
+	memoryMailer    = "memory"
+	defaultSMTPPort = 25
+)
+
+// ErrInvalidMessage is returned for messages without a recipient or with line breaks in headers
+var ErrInvalidMessage = errors.New("invalid mail message")
+
+// Message is a plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends email
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// New creates the mailer selected by cfg.Driver, SMTP by default
+func New(cfg *config.MailConfig) Mailer {
+	if cfg.Driver == memoryMailer {
+		return NewMemory()
+	}
+
+	port := cfg.SMTPPort
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+	return NewSMTP(SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     port,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.From,
+	})
+}
+
+// validate rejects messages that would let a recipient or subject inject headers
+func (m *Message) validate() error {
+	if strings.TrimSpace(m.To) == "" || strings.ContainsAny(m.To+m.Subject, "\r\n") {
+		return ErrInvalidMessage
+	}
+	return nil
+}
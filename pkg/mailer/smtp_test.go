@@ -0,0 +1,124 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpTranscript is what a fake SMTP server received from one session
+type smtpTranscript struct {
+	from string
+	to   []string
+	data string
+}
+
+// serveSMTP accepts a single session on ln and answers just enough SMTP for SMTP.Send
+func serveSMTP(ln net.Listener) <-chan smtpTranscript {
+	done := make(chan smtpTranscript, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var got smtpTranscript
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+		reply("220 localhost ready")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			cmd := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				got.from = line[len("MAIL FROM:"):]
+				reply("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				got.to = append(got.to, line[len("RCPT TO:"):])
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				got.data = data.String()
+				reply("250 OK")
+			case cmd == "QUIT":
+				reply("221 bye")
+				done <- got
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}()
+	return done
+}
+
+func TestSMTP_Send(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	done := serveSMTP(ln)
+
+	addr := ln.Addr().(*net.TCPAddr)
+	m := NewSMTP(SMTPConfig{Host: "127.0.0.1", Port: addr.Port, From: "Blog <no-reply@example.com>"})
+	m.now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, m.Send(ctx, &Message{
+		To:      "jane@example.com",
+		Subject: "Vérifiez",
+		Body:    "Hello Jane,\nclick here.",
+	}))
+
+	got := <-done
+	assert.Equal(t, "<no-reply@example.com>", got.from)
+	assert.Equal(t, []string{"<jane@example.com>"}, got.to)
+	assert.Contains(t, got.data, "From: \"Blog\" <no-reply@example.com>\r\n")
+	assert.Contains(t, got.data, "To: <jane@example.com>\r\n")
+	assert.Contains(t, got.data, "Subject: =?utf-8?q?V=C3=A9rifiez?=\r\n")
+	assert.Contains(t, got.data, "Date: Wed, 01 May 2024 12:00:00 +0000\r\n")
+	assert.True(t, strings.HasSuffix(got.data, "\r\n\r\nHello Jane,\r\nclick here.\r\n"), got.data)
+}
+
+func TestSMTP_SendRejectsHeaderInjection(t *testing.T) {
+	m := NewSMTP(SMTPConfig{Host: "127.0.0.1", Port: 1, From: "no-reply@example.com"})
+	for _, msg := range []*Message{
+		{To: ""},
+		{To: "jane@example.com\r\nBcc: all@example.com"},
+		{To: "jane@example.com", Subject: "hi\nBcc: all@example.com"},
+	} {
+		assert.ErrorIs(t, m.Send(context.Background(), msg), ErrInvalidMessage, strconv.Quote(msg.To+msg.Subject))
+	}
+}
+
+func TestMemory_Send(t *testing.T) {
+	m := NewMemory()
+	require.NoError(t, m.Send(context.Background(), &Message{To: "jane@example.com", Subject: "Hi", Body: "Hello"}))
+	assert.ErrorIs(t, m.Send(context.Background(), &Message{}), ErrInvalidMessage)
+	assert.Equal(t, []Message{{To: "jane@example.com", Subject: "Hi", Body: "Hello"}}, m.Messages())
+}
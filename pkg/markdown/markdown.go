@@ -0,0 +1,144 @@
+package markdown
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ductong169z/shorten-url/pkg/sanitize"
+	"github.com/ductong169z/shorten-url/pkg/slug"
+	"github.com/russross/blackfriday/v2"
+)
+
+const (
+	wordsPerMinute   = 200
+	maxExcerptLength = 200
+	defaultAnchor    = "section"
+)
+
+const extensions = blackfriday.CommonExtensions | blackfriday.Footnotes
+
+// Heading is a table of contents entry pointing at a heading anchor
+type Heading struct {
+	Level  int    `json:"level"`
+	Title  string `json:"title"`
+	Anchor string `json:"anchor"`
+}
+
+// Document is Markdown source rendered for display
+type Document struct {
+	HTML        string    `json:"html"`
+	TOC         []Heading `json:"toc"`
+	ReadingTime int       `json:"reading_time"`
+	Excerpt     string    `json:"excerpt"`
+}
+
+// Render converts Markdown to sanitized HTML with heading anchors and collects
+// the table of contents, the reading time in minutes and a plain text excerpt.
+func Render(src string) *Document {
+	root := parse(src)
+
+	doc := &Document{TOC: make([]Heading, 0)}
+	anchors := make(map[string]int)
+	var firstParagraph string
+	root.Walk(func(node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		if !entering {
+			return blackfriday.GoToNext
+		}
+		switch node.Type {
+		case blackfriday.Heading:
+			title := plainText(node)
+			node.HeadingID = uniqueAnchor(anchors, title)
+			doc.TOC = append(doc.TOC, Heading{Level: node.Level, Title: title, Anchor: node.HeadingID})
+			return blackfriday.SkipChildren
+		case blackfriday.Paragraph:
+			if firstParagraph == "" {
+				firstParagraph = plainText(node)
+			}
+			return blackfriday.SkipChildren
+		}
+		return blackfriday.GoToNext
+	})
+
+	renderer := blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: blackfriday.CommonHTMLFlags | blackfriday.FootnoteReturnLinks,
+	})
+	var buf bytes.Buffer
+	renderer.RenderHeader(&buf, root)
+	root.Walk(func(node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		return renderer.RenderNode(&buf, node, entering)
+	})
+	renderer.RenderFooter(&buf, root)
+
+	doc.HTML = sanitize.SanitizeHTML(buf.String())
+	doc.ReadingTime = readingTime(plainText(root))
+	doc.Excerpt = truncate(firstParagraph, maxExcerptLength)
+	return doc
+}
+
+// PlainText returns the text of Markdown source without markup
+func PlainText(src string) string {
+	return plainText(parse(src))
+}
+
+func parse(src string) *blackfriday.Node {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	return blackfriday.New(blackfriday.WithExtensions(extensions)).Parse([]byte(src))
+}
+
+// plainText joins the literal text below node, one space between blocks
+func plainText(node *blackfriday.Node) string {
+	var b strings.Builder
+	node.Walk(func(n *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		if !entering {
+			return blackfriday.GoToNext
+		}
+		switch n.Type {
+		case blackfriday.Text, blackfriday.Code, blackfriday.CodeBlock:
+			b.Write(n.Literal)
+		case blackfriday.Softbreak, blackfriday.Hardbreak:
+			b.WriteByte(' ')
+		case blackfriday.Paragraph, blackfriday.Heading, blackfriday.Item, blackfriday.TableCell:
+			b.WriteByte(' ')
+		}
+		return blackfriday.GoToNext
+	})
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// uniqueAnchor slugifies title and numbers repeated anchors ("usage", "usage-1")
+func uniqueAnchor(seen map[string]int, title string) string {
+	anchor := slug.Make(title)
+	if anchor == "" {
+		anchor = defaultAnchor
+	}
+	n, ok := seen[anchor]
+	seen[anchor] = n + 1
+	if !ok {
+		return anchor
+	}
+	return anchor + "-" + strconv.Itoa(n)
+}
+
+func readingTime(text string) int {
+	words := len(strings.Fields(text))
+	minutes := (words + wordsPerMinute - 1) / wordsPerMinute
+	if minutes < 1 {
+		return 1
+	}
+	return minutes
+}
+
+// truncate cuts text to at most maxLen runes at a word boundary
+func truncate(text string, maxLen int) string {
+	if utf8.RuneCountInString(text) <= maxLen {
+		return text
+	}
+	runes := []rune(text)[:maxLen]
+	cut := strings.LastIndex(string(runes), " ")
+	if cut <= 0 {
+		return string(runes) + "…"
+	}
+	return strings.TrimRight(string(runes)[:cut], " ,.;:") + "…"
+}
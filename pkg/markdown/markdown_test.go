@@ -0,0 +1,38 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	src := "# Giới thiệu\n\nFirst **paragraph** with `code`.\n\n## Usage\n\nText <script>alert(1)</script>\n\n## Usage\n\n```go\nfmt.Println(1)\n```\n"
+
+	doc := Render(src)
+
+	assert.Equal(t, []Heading{
+		{Level: 1, Title: "Giới thiệu", Anchor: "gioi-thieu"},
+		{Level: 2, Title: "Usage", Anchor: "usage"},
+		{Level: 2, Title: "Usage", Anchor: "usage-1"},
+	}, doc.TOC)
+	assert.Contains(t, doc.HTML, `<h1 id="gioi-thieu">Giới thiệu</h1>`)
+	assert.Contains(t, doc.HTML, `<h2 id="usage-1">Usage</h2>`)
+	assert.Contains(t, doc.HTML, `<strong>paragraph</strong>`)
+	assert.NotContains(t, doc.HTML, "<script>")
+	assert.Equal(t, "First paragraph with code.", doc.Excerpt)
+	assert.Equal(t, 1, doc.ReadingTime)
+}
+
+func TestRender_LongContent(t *testing.T) {
+	doc := Render(strings.Repeat("word ", 450))
+
+	assert.Equal(t, 3, doc.ReadingTime)
+	assert.True(t, strings.HasSuffix(doc.Excerpt, "…"))
+	assert.LessOrEqual(t, len([]rune(doc.Excerpt)), maxExcerptLength+1)
+}
+
+func TestPlainText(t *testing.T) {
+	assert.Equal(t, "Title Some emphasis and a link.", PlainText("# Title\n\nSome *emphasis* and [a link](http://example.com)."))
+}
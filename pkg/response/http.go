@@ -1,6 +1,7 @@
 package response
 
 import (
+	stdErrors "errors"
 	"net/http"
 
 	"github.com/ductong169z/shorten-url/pkg/errors"
@@ -45,8 +46,17 @@ func WithErrorCode(c *gin.Context, code int, message string) {
 	})
 }
 
-// You call this in handlers for domain-layer errors
+// You call this in handlers for domain-layer errors.
+// Authentication and ownership errors map to 401 and 403 the same way in every domain.
 func WithMappedError(c *gin.Context, err error, mapFunc func(error) (int, string)) {
+	switch {
+	case stdErrors.Is(err, errors.Unauthorized):
+		WithErrorCode(c, http.StatusUnauthorized, errors.ErrUnauthorized)
+		return
+	case stdErrors.Is(err, errors.Forbidden):
+		WithErrorCode(c, http.StatusForbidden, errors.ErrForbidden)
+		return
+	}
 	code, msg := mapFunc(err)
 	WithErrorCode(c, code, msg)
 }
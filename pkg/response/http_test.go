@@ -0,0 +1,75 @@
+package response
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ductong169z/shorten-url/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var errDomain = stdErrors.New("post not found")
+
+// mapDomainError stands in for the MapError of a domain package
+func mapDomainError(err error) (int, string) {
+	if stdErrors.Is(err, errDomain) {
+		return http.StatusNotFound, errDomain.Error()
+	}
+	return http.StatusInternalServerError, "Internal server error"
+}
+
+func TestWithMappedError(t *testing.T) {
+	tcs := map[string]struct {
+		err     error
+		expCode int
+		expBody string
+	}{
+		"unauthorized": {
+			err:     errors.Unauthorized,
+			expCode: http.StatusUnauthorized,
+			expBody: `{"message":"Unauthorized"}`,
+		},
+		"wrapped unauthorized": {
+			err:     fmt.Errorf("get user: %w", errors.Unauthorized),
+			expCode: http.StatusUnauthorized,
+			expBody: `{"message":"Unauthorized"}`,
+		},
+		"forbidden": {
+			err:     errors.Forbidden,
+			expCode: http.StatusForbidden,
+			expBody: `{"message":"Forbidden"}`,
+		},
+		"wrapped forbidden": {
+			err:     fmt.Errorf("validate owner: %w", errors.Forbidden),
+			expCode: http.StatusForbidden,
+			expBody: `{"message":"Forbidden"}`,
+		},
+		"domain error": {
+			err:     errDomain,
+			expCode: http.StatusNotFound,
+			expBody: `{"message":"post not found"}`,
+		},
+		"unknown error": {
+			err:     stdErrors.New("connection refused"),
+			expCode: http.StatusInternalServerError,
+			expBody: `{"message":"Internal server error"}`,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			WithMappedError(c, tc.err, mapDomainError)
+
+			assert.Equal(t, tc.expCode, w.Code)
+			assert.JSONEq(t, tc.expBody, w.Body.String())
+		})
+	}
+}
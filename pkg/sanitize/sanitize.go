@@ -13,6 +13,11 @@ func init() {
 	sanitizer = bluemonday.UGCPolicy()
 }
 
+// SanitizeHTML strips unsafe markup from rendered HTML using the shared policy
+func SanitizeHTML(s string) string {
+	return sanitizer.Sanitize(s)
+}
+
 // Sanitize json
 func SanitizeJSON(s []byte) ([]byte, error) {
 	d := json.NewDecoder(bytes.NewReader(s))
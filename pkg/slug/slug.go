@@ -0,0 +1,113 @@
+package slug
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxAttempts bounds the numeric suffixes tried by Unique
+const maxAttempts = 1000
+
+var pattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// ErrNoUniqueSlug is returned when every suffix up to maxAttempts is taken
+var ErrNoUniqueSlug = errors.New("no unique slug available")
+
+// replacements covers letters that do not decompose into a base letter and a mark
+var replacements = map[rune]string{
+	'đ': "d", 'Đ': "d",
+	'ð': "d", 'Ð': "d",
+	'ø': "o", 'Ø': "o",
+	'ł': "l", 'Ł': "l",
+	'ß': "ss",
+	'æ': "ae", 'Æ': "ae",
+	'œ': "oe", 'Œ': "oe",
+	'þ': "th", 'Þ': "th",
+	'&': " and ",
+}
+
+// Make transliterates s into a lower-case, hyphen separated URL-safe slug,
+// e.g. "Xin chào Đà Nẵng!" becomes "xin-chao-da-nang".
+func Make(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	hyphen := false
+	for _, r := range norm.NFD.String(s) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if rep, ok := replacements[r]; ok {
+			for _, rr := range rep {
+				hyphen = write(&b, rr, hyphen)
+			}
+			continue
+		}
+		hyphen = write(&b, r, hyphen)
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// MakeOr is Make with a fallback for text without any Latin letter or digit, e.g. only
+// CJK characters or emoji: it then returns prefix followed by the Unix time, like "post-1700000000".
+func MakeOr(s, prefix string) string {
+	if made := Make(s); made != "" {
+		return made
+	}
+	return prefix + "-" + strconv.FormatInt(time.Now().Unix(), 10)
+}
+
+// write appends r lower-cased when it is an ASCII letter or digit, otherwise
+// it records a pending separator; it returns whether a separator is pending.
+func write(b *strings.Builder, r rune, hyphen bool) bool {
+	r = unicode.ToLower(r)
+	if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+		return b.Len() > 0
+	}
+	if hyphen {
+		b.WriteByte('-')
+	}
+	b.WriteRune(r)
+	return false
+}
+
+// IsValid reports whether s is a well-formed slug no longer than maxLen bytes
+func IsValid(s string, maxLen int) bool {
+	return len(s) <= maxLen && pattern.MatchString(s)
+}
+
+// Truncate shortens s to at most maxLen bytes without leaving a trailing hyphen
+func Truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return strings.TrimRight(s[:maxLen], "-")
+}
+
+// Unique returns base, or base with the first free numeric suffix ("post-2",
+// "post-3", ...), according to exists. The result never exceeds maxLen bytes.
+func Unique(base string, maxLen int, exists func(string) (bool, error)) (string, error) {
+	for i := 1; i <= maxAttempts; i++ {
+		candidate := Truncate(base, maxLen)
+		if i > 1 {
+			suffix := "-" + strconv.Itoa(i)
+			candidate = Truncate(base, maxLen-len(suffix)) + suffix
+		}
+
+		taken, err := exists(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+	}
+	return "", ErrNoUniqueSlug
+}
@@ -0,0 +1,71 @@
+package slug
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	tcs := map[string]struct {
+		input string
+		exp   string
+	}{
+		"ascii":            {input: "Hello World", exp: "hello-world"},
+		"vietnamese":       {input: "Xin chào Đà Nẵng!", exp: "xin-chao-da-nang"},
+		"vietnamese marks": {input: "Tiếng Việt có dấu ở đây", exp: "tieng-viet-co-dau-o-day"},
+		"european":         {input: "Straße Ærø Łódź", exp: "strasse-aero-lodz"},
+		"ampersand":        {input: "Go & Rust", exp: "go-and-rust"},
+		"separators":       {input: "  --Go__1.22 -- released--  ", exp: "go-1-22-released"},
+		"no letters":       {input: "!!!", exp: ""},
+		"non latin":        {input: "日本語 blog", exp: "blog"},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			got := Make(tc.input)
+			assert.Equal(t, tc.exp, got)
+			if got != "" {
+				assert.True(t, IsValid(got, 280))
+			}
+		})
+	}
+}
+
+func TestMakeOr(t *testing.T) {
+	assert.Equal(t, "hello-world", MakeOr("Hello World", "tag"))
+
+	for _, input := range []string{"日本語", "🚀🚀", "!!!", ""} {
+		got := MakeOr(input, "tag")
+		assert.Regexp(t, `^tag-[0-9]+$`, got, "input %q", input)
+		assert.True(t, IsValid(got, 280))
+	}
+}
+
+func TestUnique(t *testing.T) {
+	taken := map[string]bool{"hello": true, "hello-2": true, "abcd": true}
+	exists := func(s string) (bool, error) { return taken[s], nil }
+
+	got, err := Unique("hello", 280, exists)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello-3", got)
+
+	got, err = Unique("fresh", 280, exists)
+	assert.NoError(t, err)
+	assert.Equal(t, "fresh", got)
+
+	// the suffix still fits within the maximum length
+	got, err = Unique("abcd", 4, exists)
+	assert.NoError(t, err)
+	assert.Equal(t, "ab-2", got)
+
+	// the last suffix is checked as well
+	last := "post-" + strconv.Itoa(maxAttempts)
+	got, err = Unique("post", 280, func(s string) (bool, error) { return s != last, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, last, got)
+
+	_, err = Unique("post", 280, func(string) (bool, error) { return true, nil })
+	assert.ErrorIs(t, err, ErrNoUniqueSlug)
+}
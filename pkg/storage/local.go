@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local stores objects as files below a directory served at baseURL
+type Local struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocal creates a local filesystem storage rooted at dir
+func NewLocal(dir, baseURL string) *Local {
+	return &Local{dir: dir, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// Put implements Storage.
+// The object is written to a temporary file first so readers never see a partial file.
+func (l *Local) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	dst := filepath.Join(l.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
+
+// Delete implements Storage. Deleting a missing object is not an error.
+func (l *Local) Delete(ctx context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filepath.Join(l.dir, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// URL implements Storage.
+func (l *Local) URL(key string) string {
+	return l.baseURL + "/" + key
+}
+
+// Dir returns the directory objects are stored in
+func (l *Local) Dir() string {
+	return l.dir
+}
+
+// URLPath returns the path part of the base URL, where Dir has to be served
+func (l *Local) URLPath() string {
+	u, err := url.Parse(l.baseURL)
+	if err != nil || u.Path == "" {
+		return "/"
+	}
+	return u.Path
+}
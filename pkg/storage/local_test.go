@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocal_PutDelete(t *testing.T) {
+	dir := t.TempDir()
+	l := NewLocal(dir, "http://localhost/media/")
+	ctx := context.Background()
+
+	require.NoError(t, l.Put(ctx, "2024/05/a.png", strings.NewReader("png"), 3, "image/png"))
+	data, err := os.ReadFile(filepath.Join(dir, "2024", "05", "a.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "png", string(data))
+	assert.Equal(t, "http://localhost/media/2024/05/a.png", l.URL("2024/05/a.png"))
+
+	require.NoError(t, l.Delete(ctx, "2024/05/a.png"))
+	_, err = os.Stat(filepath.Join(dir, "2024", "05", "a.png"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.NoError(t, l.Delete(ctx, "2024/05/a.png"))
+
+	for _, key := range []string{"", "/etc/passwd", "../a.png", "a/../../b.png", `a\b.png`} {
+		assert.ErrorIs(t, l.Put(ctx, key, strings.NewReader(""), 0, ""), ErrInvalidKey, key)
+	}
+}
+
+func TestNew_LocalURLPath(t *testing.T) {
+	tcs := map[string]struct {
+		baseURL string
+		expPath string
+		expErr  bool
+	}{
+		"default":           {baseURL: "", expPath: "/media"},
+		"absolute url":      {baseURL: "http://localhost:1994/media/", expPath: "/media"},
+		"nested path":       {baseURL: "https://cdn.example.com/blog/media", expPath: "/blog/media"},
+		"host without path": {baseURL: "http://localhost:1994", expErr: true},
+		"root path":         {baseURL: "http://localhost:1994/", expErr: true},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			s, err := New(&config.MediaConfig{LocalDir: t.TempDir(), BaseURL: tc.baseURL})
+			if tc.expErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expPath, s.(*Local).URLPath())
+		})
+	}
+}
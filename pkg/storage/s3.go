@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	amzDateFormat    = "20060102T150405Z"
+)
+
+// S3 config; PathStyle addresses the bucket as endpoint/bucket, which most
+// S3-compatible servers such as MinIO expect. PublicURL overrides the base
+// of object URLs, e.g. for a CDN in front of the bucket.
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PathStyle bool
+	PublicURL string
+}
+
+// S3 stores objects in an S3-compatible bucket using signature version 4
+type S3 struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewS3 creates an S3 storage; a nil client uses http.DefaultClient
+func NewS3(cfg S3Config, client *http.Client) (*S3, error) {
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("storage: invalid s3 endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("storage: s3 bucket is required")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &S3{cfg: cfg, endpoint: endpoint, client: client, now: time.Now}, nil
+}
+
+// Put implements Storage.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return s.do(req, key, http.StatusOK)
+}
+
+// Delete implements Storage. Deleting a missing object is not an error.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+	return s.do(req, key, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
+
+// URL implements Storage.
+func (s *S3) URL(key string) string {
+	if s.cfg.PublicURL != "" {
+		return strings.TrimRight(s.cfg.PublicURL, "/") + "/" + key
+	}
+	return s.objectURL(key)
+}
+
+func (s *S3) objectURL(key string) string {
+	u := *s.endpoint
+	if s.cfg.PathStyle {
+		u.Path = u.Path + "/" + s.cfg.Bucket + "/" + key
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+		u.Path = u.Path + "/" + key
+	}
+	// Send the path exactly as it is signed
+	u.RawPath = canonicalURI(u.Path)
+	return u.String()
+}
+
+func (s *S3) do(req *http.Request, key string, okStatus ...int) error {
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	s.sign(req, unsignedPayload, s.now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	for _, status := range okStatus {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("storage: s3 %s %s: %s: %s", req.Method, key, resp.Status, strings.TrimSpace(string(body)))
+}
+
+// sign adds a signature version 4 Authorization header covering the host and
+// every header already set on req.
+func (s *S3) sign(req *http.Request, payloadHash string, t time.Time) {
+	t = t.UTC()
+	amzDate := t.Format(amzDateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL.Path),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := t.Format("20060102") + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{signingAlgorithm, amzDate, scope, hashHex(canonicalRequest)}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), t.Format("20060102"))
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, s.cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+// canonicalURI encodes each path segment, keeping the slashes between them
+func canonicalURI(p string) string {
+	if p == "" {
+		return "/"
+	}
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		vs := append([]string(nil), values[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, uriEncode(k)+"="+uriEncode(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes everything except the RFC 3986 unreserved characters
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func hashHex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/ductong169z/shorten-url/config"
+)
+
+const (
+	s3Storage       = "s3"
+	defaultLocalDir = "uploads"
+	defaultLocalURL = "/media"
+)
+
+// ErrInvalidKey is returned for empty keys and keys escaping the storage root
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// Storage stores objects under slash separated keys such as "media/2024/05/a.png"
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL the object is served from
+	URL(key string) string
+}
+
+// New creates the storage backend selected by cfg.Storage, local by default
+func New(cfg *config.MediaConfig) (Storage, error) {
+	if cfg.Storage == s3Storage {
+		return NewS3(S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PathStyle: cfg.S3PathStyle,
+			PublicURL: cfg.BaseURL,
+		}, nil)
+	}
+
+	dir, baseURL := cfg.LocalDir, cfg.BaseURL
+	if dir == "" {
+		dir = defaultLocalDir
+	}
+	if baseURL == "" {
+		baseURL = defaultLocalURL
+	}
+
+	// The directory is served below the path of the base URL, which must not
+	// shadow every other route
+	local := NewLocal(dir, baseURL)
+	if local.URLPath() == "/" {
+		return nil, fmt.Errorf("storage: MEDIA_BASE_URL %q needs a path such as %s", cfg.BaseURL, defaultLocalURL)
+	}
+	return local, nil
+}
+
+// cleanKey normalizes key and rejects absolute or parent relative keys
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	cleaned := path.Clean(key)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
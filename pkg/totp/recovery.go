@@ -0,0 +1,33 @@
+package totp
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+)
+
+// recoveryEncoding is Crockford's base32, which leaves out the easily confused i, l, o and u
+var recoveryEncoding = base32.NewEncoding("0123456789abcdefghjkmnpqrstvwxyz").WithPadding(base32.NoPadding)
+
+// recoveryLookalikes maps characters users may type for the ones in recoveryEncoding
+var recoveryLookalikes = strings.NewReplacer("o", "0", "i", "1", "l", "1", "-", "", " ", "")
+
+// GenerateRecoveryCodes returns n random single-use codes formatted as "xxxxx-xxxxx"
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	for i := range codes {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		code := recoveryEncoding.EncodeToString(b)[:10]
+		codes[i] = code[:5] + "-" + code[5:]
+	}
+	return codes, nil
+}
+
+// NormalizeRecoveryCode lower-cases code, strips separators and fixes look-alike
+// characters so it can be compared however the user typed it
+func NormalizeRecoveryCode(code string) string {
+	return recoveryLookalikes.Replace(strings.ToLower(code))
+}
@@ -0,0 +1,70 @@
+package totp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+)
+
+// KeySize is the length of the key secrets are encrypted with at rest (AES-256)
+const KeySize = 32
+
+var (
+	// ErrInvalidKey is returned for keys that are not KeySize bytes of base64
+	ErrInvalidKey = errors.New("totp: invalid encryption key")
+	// ErrInvalidSealedSecret is returned when a stored secret cannot be decrypted
+	ErrInvalidSealedSecret = errors.New("totp: invalid sealed secret")
+)
+
+// ParseKey decodes a base64 encryption key, as generated by `openssl rand -base64 32`
+func ParseKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+	return key, nil
+}
+
+// Seal encrypts secret with AES-256-GCM for storage; the result is the base64 of
+// the random nonce followed by the ciphertext.
+func Seal(key []byte, secret string) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(secret), nil)), nil
+}
+
+// Open decrypts a secret encrypted by Seal with the same key
+func Open(key []byte, sealed string) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil || len(data) < aead.NonceSize() {
+		return "", ErrInvalidSealedSecret
+	}
+	secret, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return "", ErrInvalidSealedSecret
+	}
+	return string(secret), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
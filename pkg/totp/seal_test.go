@@ -0,0 +1,46 @@
+package totp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKey(t *testing.T) {
+	key, err := ParseKey(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, KeySize)))
+	require.NoError(t, err)
+	assert.Len(t, key, KeySize)
+
+	for _, s := range []string{"", "not base64!", base64.StdEncoding.EncodeToString([]byte("too short"))} {
+		_, err := ParseKey(s)
+		assert.ErrorIs(t, err, ErrInvalidKey, s)
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, KeySize)
+
+	sealed, err := Seal(key, rfc6238Secret)
+	require.NoError(t, err)
+	assert.NotContains(t, sealed, rfc6238Secret)
+
+	again, err := Seal(key, rfc6238Secret)
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, again, "every seal uses a new nonce")
+
+	secret, err := Open(key, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, rfc6238Secret, secret)
+
+	_, err = Open(bytes.Repeat([]byte{2}, KeySize), sealed)
+	assert.ErrorIs(t, err, ErrInvalidSealedSecret)
+
+	_, err = Open(key, rfc6238Secret)
+	assert.ErrorIs(t, err, ErrInvalidSealedSecret)
+
+	_, err = Seal(key[:16], rfc6238Secret)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
@@ -0,0 +1,111 @@
+// Package totp implements RFC 6238 time-based one-time passwords as used by
+// authenticator apps: HMAC-SHA1, six digits and a 30 second period.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Period is the number of seconds each code is valid for
+	Period = 30
+	// Digits is the length of a code
+	Digits = 6
+
+	secretSize = 20 // 160 bits, as recommended by RFC 4226
+)
+
+// ErrInvalidSecret is returned for secrets that are not base32
+var ErrInvalidSecret = errors.New("totp: invalid secret")
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a random base32 encoded secret
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return secretEncoding.EncodeToString(b), nil
+}
+
+// URI returns the otpauth:// URI authenticator apps read from a QR code
+func URI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(Digits))
+	query.Set("period", strconv.Itoa(Period))
+	return "otpauth://totp/" + url.PathEscape(issuer+":"+account) + "?" + query.Encode()
+}
+
+// Step returns the time step t falls in
+func Step(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// Code returns the code of secret for a time step
+func Code(secret string, step int64) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, uint64(step), Digits), nil
+}
+
+// Validate checks code against the time step of t and skew steps on either side to
+// allow for clock drift. It returns the matched step, which callers should remember
+// so a code cannot be replayed.
+func Validate(secret, code string, t time.Time, skew int) (int64, bool) {
+	key, err := decodeSecret(secret)
+	if err != nil || len(code) != Digits {
+		return 0, false
+	}
+	now := Step(t)
+	for i := -int64(skew); i <= int64(skew); i++ {
+		step := now + i
+		if subtle.ConstantTimeCompare([]byte(hotp(key, uint64(step), Digits)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := secretEncoding.DecodeString(strings.TrimRight(secret, "="))
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
+
+// hotp computes the RFC 4226 HMAC-SHA1 one-time password for counter
+func hotp(key []byte, counter uint64, digits int) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
@@ -0,0 +1,81 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 test vectors, "12345678901234567890"
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestHOTP_RFC6238Vectors(t *testing.T) {
+	key, err := decodeSecret(rfc6238Secret)
+	require.NoError(t, err)
+
+	for unix, exp := range map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1111111111:  "14050471",
+		1234567890:  "89005924",
+		2000000000:  "69279037",
+		20000000000: "65353130",
+	} {
+		assert.Equal(t, exp, hotp(key, uint64(unix/Period), 8), unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	code, err := Code(rfc6238Secret, Step(now))
+	require.NoError(t, err)
+	assert.Equal(t, "050471", code)
+
+	step, ok := Validate(rfc6238Secret, code, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, Step(now), step)
+
+	// One period of clock drift is tolerated, two are not
+	_, ok = Validate(rfc6238Secret, code, now.Add(Period*time.Second), 1)
+	assert.True(t, ok)
+	_, ok = Validate(rfc6238Secret, code, now.Add(2*Period*time.Second), 1)
+	assert.False(t, ok)
+
+	_, ok = Validate(rfc6238Secret, "000000", now, 1)
+	assert.False(t, ok)
+	_, ok = Validate("not base32!", code, now, 1)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	_, err = Code(secret, 1)
+	assert.NoError(t, err)
+}
+
+func TestURI(t *testing.T) {
+	uri, err := url.Parse(URI("My Blog", "jane@example.com", "SECRET"))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/My Blog:jane@example.com", uri.Path)
+	assert.Equal(t, "SECRET", uri.Query().Get("secret"))
+	assert.Equal(t, "My Blog", uri.Query().Get("issuer"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+	assert.Regexp(t, `^[0-9a-hjkmnp-tv-z]{5}-[0-9a-hjkmnp-tv-z]{5}$`, codes[0])
+	assert.NotEqual(t, codes[0], codes[1])
+
+	assert.Equal(t, NormalizeRecoveryCode(codes[0]), NormalizeRecoveryCode(" "+codes[0]+" "))
+	assert.Equal(t, "a0b1c1d", NormalizeRecoveryCode("A-O B I-c L D"))
+}
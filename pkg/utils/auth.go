@@ -1,30 +1,44 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/errors"
+	"github.com/ductong169z/shorten-url/pkg/logger"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// // Validate is user from owner of content
-// func ValidateIsOwner(ctx context.Context, creatorID string, logger logger.Logger) error {
-// 	user, err := GetUserFromCtx(ctx)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	if user.Id.(String) != creatorID {
-// 		logger.Errorf(
-// 			ctx,
-// 			"ValidateIsOwner, userID: %v, creatorID: %v",
-// 			user.UserID.String(),
-// 			creatorID,
-// 		)
-// 		return errors.Forbidden
-// 	}
-
-// 	return nil
-// }
+// ValidateIsOwner checks that the user in context owns the content created by ownerID,
+// or that their role is granted override to modify content of other users; admins
+// always are. Content without an owner, such as guest comments, is passed as ownerID 0.
+func ValidateIsOwner(ctx context.Context, ownerID int, override models.Permission, logger logger.Logger) error {
+	user, err := GetUserFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if (ownerID == 0 || user.ID != ownerID) && !user.Role.Can(override) {
+		logger.Errorf(
+			ctx,
+			"ValidateIsOwner, userID: %v, ownerID: %v",
+			user.ID,
+			ownerID,
+		)
+		return errors.Forbidden
+	}
+
+	return nil
+}
+
+// OwnerID returns the ID for ValidateIsOwner of content whose owner is optional
+func OwnerID(userID *int) int {
+	if userID == nil {
+		return 0
+	}
+	return *userID
+}
 
 func HashPasswordBcrypt(password string) (string, error) {
 	hashedPasswordBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
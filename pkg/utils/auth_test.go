@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ductong169z/shorten-url/config"
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/errors"
+	"github.com/ductong169z/shorten-url/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateIsOwner(t *testing.T) {
+	author := &models.User{ID: 7, Role: models.RoleAuthor}
+	guestID := 0
+
+	tcs := map[string]struct {
+		user    *models.User
+		ownerID int
+		expErr  error
+	}{
+		"owner": {
+			user:    author,
+			ownerID: 7,
+		},
+		"other user": {
+			user:    author,
+			ownerID: 8,
+			expErr:  errors.Forbidden,
+		},
+		"override permission": {
+			user:    &models.User{ID: 9, Role: models.RoleEditor},
+			ownerID: 7,
+		},
+		"admin override": {
+			user:    &models.User{ID: 1, Role: models.RoleAdmin},
+			ownerID: 7,
+		},
+		"anonymous owner": {
+			user:    author,
+			ownerID: OwnerID(nil),
+			expErr:  errors.Forbidden,
+		},
+		"anonymous owner with a zero user": {
+			user:    &models.User{Role: models.RoleAuthor},
+			ownerID: OwnerID(&guestID),
+			expErr:  errors.Forbidden,
+		},
+		"anonymous owner with admin": {
+			user:    &models.User{ID: 1, Role: models.RoleAdmin},
+			ownerID: OwnerID(nil),
+		},
+		"unauthenticated": {
+			ownerID: 7,
+			expErr:  errors.Unauthorized,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			apiLogger := logger.NewApiLogger(cfg)
+			apiLogger.InitLogger()
+
+			ctx := context.Background()
+			if tc.user != nil {
+				ctx = context.WithValue(ctx, UserCtxKey{}, tc.user)
+			}
+
+			err := ValidateIsOwner(ctx, tc.ownerID, models.PermPostsEditAny, apiLogger)
+			if tc.expErr != nil {
+				assert.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestOwnerID(t *testing.T) {
+	id := 7
+	assert.Equal(t, 7, OwnerID(&id))
+	assert.Equal(t, 0, OwnerID(nil))
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/errors"
+)
+
+// emailVerificationPurpose separates verification signatures from other uses of the secret
+const emailVerificationPurpose = "email-verification"
+
+// GenerateEmailVerificationToken signs a token proving that whoever holds it received mail
+// at user.Email. It is bound to the address, so changing the email invalidates it.
+func GenerateEmailVerificationToken(user *models.User, secret string, ttl time.Duration) (string, time.Time) {
+	expiresAt := time.Now().Add(ttl)
+	payload := strings.Join([]string{
+		strconv.Itoa(user.ID),
+		strconv.FormatInt(expiresAt.Unix(), 10),
+		user.Email,
+	}, ":")
+
+	token := base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(signEmailVerification(payload, secret))
+	return token, expiresAt
+}
+
+// ParseEmailVerificationToken checks the signature and expiry of token and returns the
+// user ID and email it was issued for
+func ParseEmailVerificationToken(token, secret string) (int, string, error) {
+	encodedPayload, encodedSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, "", errors.InvalidVerificationToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return 0, "", errors.InvalidVerificationToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil {
+		return 0, "", errors.InvalidVerificationToken
+	}
+	if !hmac.Equal(sig, signEmailVerification(string(payload), secret)) {
+		return 0, "", errors.InvalidVerificationToken
+	}
+
+	// The email comes last since it is the only part that may contain a colon
+	parts := strings.SplitN(string(payload), ":", 3)
+	if len(parts) != 3 {
+		return 0, "", errors.InvalidVerificationToken
+	}
+	userID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", errors.InvalidVerificationToken
+	}
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return 0, "", errors.InvalidVerificationToken
+	}
+	return userID, parts[2], nil
+}
+
+func signEmailVerification(payload, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(emailVerificationPurpose + ":" + payload))
+	return mac.Sum(nil)
+}
@@ -38,11 +38,19 @@ func GetRequestCtx(c *gin.Context) context.Context {
 // UserCtxKey is a key used for the User object in the context
 type UserCtxKey struct{}
 
-// Get user ip address
+// Get user ip address; X-Forwarded-For is only used when the server trusts the proxy that set it
 func GetIPAddress(c *gin.Context) string {
 	return c.ClientIP()
 }
 
+// Get the device of the request for refresh token sessions
+func GetSessionClient(c *gin.Context) *models.SessionClient {
+	return &models.SessionClient{
+		UserAgent: c.Request.UserAgent(),
+		IP:        GetIPAddress(c),
+	}
+}
+
 // Get user from context
 func GetUserFromCtx(ctx context.Context) (*models.User, error) {
 	user, ok := ctx.Value(UserCtxKey{}).(*models.User)
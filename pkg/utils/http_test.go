@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSessionClient(t *testing.T) {
+	tcs := map[string]struct {
+		trustedProxies []string
+		remoteAddr     string
+		expIP          string
+	}{
+		"no trusted proxies ignores X-Forwarded-For": {
+			remoteAddr: "198.51.100.4:40000",
+			expIP:      "198.51.100.4",
+		},
+		"trusted proxy": {
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.2:40000",
+			expIP:          "203.0.113.9",
+		},
+		"untrusted proxy": {
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "198.51.100.4:40000",
+			expIP:          "198.51.100.4",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			engine := gin.New()
+			require.NoError(t, engine.SetTrustedProxies(tc.trustedProxies))
+
+			var client *models.SessionClient
+			engine.POST("/api/v1/auth/login", func(c *gin.Context) {
+				client = GetSessionClient(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil)
+			req.RemoteAddr = tc.remoteAddr
+			req.Header.Set("User-Agent", "test-agent")
+			req.Header.Set("X-Forwarded-For", "203.0.113.9")
+			engine.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.NotNil(t, client)
+			assert.Equal(t, tc.expIP, client.IP)
+			assert.Equal(t, "test-agent", client.UserAgent)
+		})
+	}
+}
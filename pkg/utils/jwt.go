@@ -1,44 +1,71 @@
 package utils
 
 import (
+	"context"
 	"time"
 
-	"github.com/ductong169z/shorten-url/config"
 	"github.com/ductong169z/shorten-url/internal/models"
+	"github.com/ductong169z/shorten-url/pkg/errors"
+	"github.com/ductong169z/shorten-url/pkg/jwtkeys"
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 )
 
+// AccessTokenDuration is how long an access JWT stays valid
+const AccessTokenDuration = 60 * time.Minute
+
 // JWT Claims struct
 type Claims struct {
-	Id       int    `json:"id"`
-	Role     string `json:"role"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
+	Id            int    `json:"id"`
+	Role          string `json:"role"`
+	Username      string `json:"username"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
 	jwt.StandardClaims
 }
 
-// Generate new JWT Token
-func GenerateJWTToken(user *models.User, config *config.Config) (string, time.Time, error) {
+// Generate new JWT Token signed with the current key of keys
+func GenerateJWTToken(user *models.User, keys *jwtkeys.KeySet) (string, time.Time, error) {
 	// Register the JWT claims, which includes the username and expiry time
-	expiredAt := time.Now().Add(time.Minute * 60)
+	now := time.Now()
+	expiredAt := now.Add(AccessTokenDuration)
 	claims := &Claims{
-		Id:       user.ID,
-		Role:     user.Role.String(),
-		Username: user.Username,
-		Email:    user.Email,
+		Id:            user.ID,
+		Role:          user.Role.String(),
+		Username:      user.Username,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Minute * 60).Unix(),
+			Id:        uuid.NewString(), // jti, used to denylist the token on logout
+			IssuedAt:  now.Unix(),
+			ExpiresAt: expiredAt.Unix(),
 		},
 	}
 
-	// Declare the token with the algorithm used for signing, and the claims
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
 	// Register the JWT string
-	tokenString, err := token.SignedString([]byte(config.Server.JwtSecretKey))
+	tokenString, err := keys.Sign(claims)
 	if err != nil {
 		return "", time.Time{}, err
 	}
 
 	return tokenString, expiredAt, nil
 }
+
+// AccessToken identifies the JWT a request was authenticated with
+type AccessToken struct {
+	ID        string
+	ExpiresAt time.Time
+}
+
+// AccessTokenCtxKey is a key used for the AccessToken in the context
+type AccessTokenCtxKey struct{}
+
+// Get the access token of the current request from context
+func GetAccessTokenFromCtx(ctx context.Context) (*AccessToken, error) {
+	token, ok := ctx.Value(AccessTokenCtxKey{}).(*AccessToken)
+	if !ok {
+		return nil, errors.Unauthorized
+	}
+
+	return token, nil
+}
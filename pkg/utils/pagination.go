@@ -10,6 +10,8 @@ import (
 
 const (
 	defaultSize = 10
+	// maxSize bounds the page size so a client cannot load a whole table in one request
+	maxSize = 100
 )
 
 // Pagination query params
@@ -29,6 +31,12 @@ func (q *PaginationQuery) SetSize(sizeQuery string) error {
 	if err != nil {
 		return err
 	}
+	if n <= 0 {
+		n = defaultSize
+	}
+	if n > maxSize {
+		n = maxSize
+	}
 	q.Size = n
 
 	return nil
@@ -44,6 +52,9 @@ func (q *PaginationQuery) SetPage(pageQuery string) error {
 	if err != nil {
 		return err
 	}
+	if n < 1 {
+		n = 1
+	}
 	q.Page = n
 
 	return nil
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// GeneratePasswordResetToken returns a random token to email and the hash to store for it
+func GeneratePasswordResetToken() (string, string, error) {
+	token, err := GenerateRefreshToken()
+	if err != nil {
+		return "", "", err
+	}
+	return token, HashPasswordResetToken(token), nil
+}
+
+// HashPasswordResetToken returns the hex SHA-256 of token. The token is random, so a fast
+// hash is enough and lets the stored value be looked up directly.
+func HashPasswordResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}